@@ -0,0 +1,196 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"wallkeiro/core/auth"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/validate"
+	"wallkeiro/core/web"
+)
+
+// Version is the version of the API, which prefixes every path.
+const Version string = "v1"
+
+// DefaultAddress is the address the API is served on by default, reachable from this computer only.
+const DefaultAddress string = "127.0.0.1:8421"
+
+// maxBodySize is the largest request body accepted, which is plenty for a whole profile.
+const maxBodySize int64 = 10 << 20
+
+// Server serves the REST API. Requests are served concurrently: the storage writes a changed
+// profile only if it is still the version the change was made to (see config.Backend), and
+// the request fails with 409 Conflict otherwise.
+type Server struct{}
+
+// route is a handler for the requests whose path, split at the slashes after the version,
+// matches the pattern. Parts of the pattern in braces match any value, which is passed
+// to the handler in the same order.
+type route struct {
+	method  string
+	pattern []string
+	handle  func(w http.ResponseWriter, r *http.Request, params []string)
+}
+
+// storeKey is the key of the auth.Store of the logged in user in the context of a request.
+type storeKey struct{}
+
+// storeOf returns the auth.Store of the user who sent the request, through which every
+// profile is read and written, so the permissions of the user are always checked.
+func storeOf(r *http.Request) auth.Store {
+	store, _ := r.Context().Value(storeKey{}).(auth.Store)
+	return store
+}
+
+// Handler returns the http.Handler of the API.
+func Handler() http.Handler {
+	return &Server{}
+}
+
+// Serve serves the API on the given address until it fails, together with the
+// web interface, which is served on every path outside of the API.
+func Serve(address string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/"+Version+"/", Handler())
+	mux.Handle("/", web.Handler())
+	server := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+	log.Printf("Serving wallkeiro on http://%s/ and its API on http://%s/%s/", address, address, Version)
+	return server.ListenAndServe()
+}
+
+// ServeHTTP routes the request to the handler of its method and path.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !auth.Enabled() && !auth.LocalHost(r.Host) {
+		// protects the API from web pages that rebind their domain to 127.0.0.1,
+		// as long as there are no user accounts which make every request log in
+		writeError(w, http.StatusForbidden, fmt.Errorf("requests must be addressed to localhost"))
+		return
+	}
+	store, err := auth.Login(r.Header.Get("Authorization"))
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", `Basic realm="wallkeiro", charset="UTF-8"`)
+		writeFailure(w, err)
+		return
+	}
+	r = r.WithContext(context.WithValue(r.Context(), storeKey{}, store))
+	path := strings.Trim(r.URL.Path, "/")
+	parts := strings.Split(path, "/")
+	if parts[0] != Version {
+		writeError(w, http.StatusNotFound, errors.ErrNotFound)
+		return
+	}
+	parts = parts[1:]
+	allowed := []string{}
+	for _, route := range s.routes() {
+		params, ok := match(route.pattern, parts)
+		if !ok {
+			continue
+		}
+		if route.method != r.Method {
+			allowed = append(allowed, route.method)
+			continue
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		route.handle(w, r, params)
+		return
+	}
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+	writeError(w, http.StatusNotFound, errors.ErrNotFound)
+}
+
+// match returns the values of the parameters of the pattern if the path matches it.
+func match(pattern, parts []string) ([]string, bool) {
+	if len(pattern) != len(parts) {
+		return nil, false
+	}
+	var params []string
+	for i, part := range pattern {
+		if strings.HasPrefix(part, "{") {
+			if parts[i] == "" {
+				return nil, false
+			}
+			params = append(params, parts[i])
+		} else if part != parts[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// writeJSON writes the value as the JSON body of the response with the given status.
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(value)
+}
+
+// writeError writes the error as a JSON body with the given status.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// writeFailure writes the error with the status that fits it: unauthorized for failed logins,
+// forbidden for changes to profiles shared for reading, not found for missing profiles and
+// items, conflict for existing ones, and bad request for invalid input.
+func writeFailure(w http.ResponseWriter, err error) {
+	switch {
+	case goerrors.Is(err, errors.ErrUnauthorized):
+		writeError(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+	case goerrors.Is(err, errors.ErrForbidden):
+		writeError(w, http.StatusForbidden, errors.ErrForbidden)
+	case goerrors.Is(err, fs.ErrNotExist), goerrors.Is(err, errors.ErrNotFound):
+		writeError(w, http.StatusNotFound, errors.ErrNotFound)
+	case goerrors.Is(err, os.ErrExist), goerrors.Is(err, errors.ErrAlreadyExists):
+		writeError(w, http.StatusConflict, errors.ErrAlreadyExists)
+	case goerrors.Is(err, errors.ErrProfileChanged):
+		writeError(w, http.StatusConflict, errors.ErrProfileChanged)
+	case validate.InvalidInput(err):
+		writeError(w, http.StatusBadRequest, err)
+	default:
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+// readJSON decodes the JSON body of the request into the value, refusing unknown fields.
+func readJSON(r *http.Request, value interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(value); err != nil {
+		return fmt.Errorf("%w: %v", errors.ErrInvalidRequest, err)
+	}
+	return nil
+}
+
+// queryInt returns the whole number query parameter with the given name, or the default.
+func queryInt(r *http.Request, name string, defaultValue int) (int, error) {
+	text := r.URL.Query().Get(name)
+	if text == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.Atoi(text)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("%w: %s=%q", errors.ErrInvalidRequest, name, text)
+	}
+	return value, nil
+}
@@ -0,0 +1,17 @@
+package api
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// openAPI is the OpenAPI 3 description of the API.
+//
+//go:embed openapi.json
+var openAPI []byte
+
+// serveOpenAPI writes the OpenAPI description of the API.
+func serveOpenAPI(w http.ResponseWriter, r *http.Request, params []string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPI)
+}
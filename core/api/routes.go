@@ -0,0 +1,513 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"wallkeiro/core/auth"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/expenses"
+	"wallkeiro/core/validate"
+)
+
+// routes returns every route of the API.
+func (s *Server) routes() []route {
+	routes := []route{
+		{http.MethodGet, []string{"openapi.json"}, serveOpenAPI},
+		{http.MethodGet, []string{"profiles"}, listProfiles},
+		{http.MethodPost, []string{"profiles"}, createProfile},
+		{http.MethodGet, []string{"profiles", "{name}"}, getProfile},
+		{http.MethodPut, []string{"profiles", "{name}"}, putProfile},
+		{http.MethodDelete, []string{"profiles", "{name}"}, deleteProfile},
+		{http.MethodPost, []string{"profiles", "{name}", "rename"}, renameProfile},
+		{http.MethodGet, []string{"profiles", "{name}", "config"}, getConfig},
+		{http.MethodPut, []string{"profiles", "{name}", "config"}, putConfig},
+		{http.MethodGet, []string{"profiles", "{name}", "transactions"}, readOnly(func(p config.ProfileData, r *http.Request) (interface{}, error) {
+			return nonNil(p.Transactions), nil
+		})},
+		{http.MethodGet, []string{"profiles", "{name}", "calculate"}, readOnly(calculate)},
+		{http.MethodGet, []string{"profiles", "{name}", "upcoming"}, readOnly(upcoming)},
+		{http.MethodGet, []string{"profiles", "{name}", "cashflow"}, readOnly(cashFlow)},
+		{http.MethodGet, []string{"profiles", "{name}", "emergency-fund"}, readOnly(func(p config.ProfileData, r *http.Request) (interface{}, error) {
+			return expenses.CalculateEmergencyFund(p), nil
+		})},
+		{http.MethodGet, []string{"profiles", "{name}", "goal-progress"}, readOnly(func(p config.ProfileData, r *http.Request) (interface{}, error) {
+			progress := []expenses.GoalProgress{}
+			for _, goal := range p.Goals {
+				progress = append(progress, expenses.CalculateGoal(goal, time.Now()))
+			}
+			return progress, nil
+		})},
+		{http.MethodGet, []string{"profiles", "{name}", "projection"}, readOnly(projection)},
+		{http.MethodGet, []string{"profiles", "{name}", "recurring"}, readOnly(func(p config.ProfileData, r *http.Request) (interface{}, error) {
+			return nonNil(expenses.DetectRecurring(p)), nil
+		})},
+		{http.MethodGet, []string{"profiles", "{name}", "scenarios"}, listScenarios},
+		{http.MethodPost, []string{"profiles", "{name}", "scenarios"}, createScenario},
+		{http.MethodGet, []string{"profiles", "{name}", "scenarios", "{scenario}"}, getScenario},
+		{http.MethodPut, []string{"profiles", "{name}", "scenarios", "{scenario}"}, putScenario},
+		{http.MethodDelete, []string{"profiles", "{name}", "scenarios", "{scenario}"}, deleteScenario},
+		{http.MethodGet, []string{"profiles", "{name}", "scenarios", "{scenario}", "calculate"}, calculateScenario},
+		{http.MethodGet, []string{"profiles", "{name}", "access"}, getAccess},
+		{http.MethodPut, []string{"profiles", "{name}", "access", "{user}"}, putShare},
+		{http.MethodDelete, []string{"profiles", "{name}", "access", "{user}"}, deleteShare},
+		{http.MethodGet, []string{"profiles", "{name}", "audit"}, getAudit},
+	}
+	routes = append(routes, listRoutes("expenses", func(p *config.ProfileData) *[]config.ExpensesStuct { return &p.Expenses }, validate.Expense,
+		func(e config.ExpensesStuct) string { return e.ID })...)
+	routes = append(routes, listRoutes("debts", func(p *config.ProfileData) *[]config.DebtStruct { return &p.Debts }, validate.Debt, nil)...)
+	routes = append(routes, listRoutes("goals", func(p *config.ProfileData) *[]config.GoalStruct { return &p.Goals }, validate.Goal, nil)...)
+	routes = append(routes, listRoutes("rules", func(p *config.ProfileData) *[]config.RuleStruct { return &p.Rules }, validate.Rule, nil)...)
+	return routes
+}
+
+// listRoutes returns the routes that list, add, read, replace and delete the items of one
+// of the lists of a profile. Items are addressed by their position in the list, from 0, or
+// for lists whose items have an ID, by their ID, which keeps addressing the same item while
+// others are added or deleted. A new version of an item giving another ID than the one of the
+// item it replaces is refused with errors.ErrProfileChanged, since the list changed meanwhile.
+func listRoutes[T any](resource string, list func(p *config.ProfileData) *[]T, valid func(item T) error, id func(item T) string) []route {
+	item := func(w http.ResponseWriter, r *http.Request, params []string, change func(p *config.ProfileData, items *[]T, index int) (int, interface{}, error)) {
+		profileData, err := storeOf(r).Read(params[0])
+		if err != nil {
+			writeFailure(w, err)
+			return
+		}
+		index := -1
+		if len(params) > 1 {
+			index = position(*list(&profileData), params[1], id)
+			if index < 0 {
+				writeError(w, http.StatusNotFound, errors.ErrNotFound)
+				return
+			}
+		}
+		status, result, err := change(&profileData, list(&profileData), index)
+		if err != nil {
+			writeFailure(w, err)
+			return
+		}
+		if r.Method != http.MethodGet {
+			if err := storeOf(r).Update(params[0], &profileData); err != nil {
+				writeFailure(w, err)
+				return
+			}
+		}
+		if result == nil {
+			w.WriteHeader(status)
+			return
+		}
+		writeJSON(w, status, result)
+	}
+	decode := func(r *http.Request) (T, error) {
+		var value T
+		if err := readJSON(r, &value); err != nil {
+			return value, err
+		}
+		return value, valid(value)
+	}
+	return []route{
+		{http.MethodGet, []string{"profiles", "{name}", resource}, func(w http.ResponseWriter, r *http.Request, params []string) {
+			item(w, r, params, func(p *config.ProfileData, items *[]T, index int) (int, interface{}, error) {
+				return http.StatusOK, nonNil(*items), nil
+			})
+		}},
+		{http.MethodPost, []string{"profiles", "{name}", resource}, func(w http.ResponseWriter, r *http.Request, params []string) {
+			item(w, r, params, func(p *config.ProfileData, items *[]T, index int) (int, interface{}, error) {
+				value, err := decode(r)
+				if err != nil {
+					return 0, nil, err
+				}
+				*items = append(*items, value)
+				// the item is returned as it is stored, with the ID it is given
+				config.NewIDs(p)
+				return http.StatusCreated, (*items)[len(*items)-1], nil
+			})
+		}},
+		{http.MethodGet, []string{"profiles", "{name}", resource, "{item}"}, func(w http.ResponseWriter, r *http.Request, params []string) {
+			item(w, r, params, func(p *config.ProfileData, items *[]T, index int) (int, interface{}, error) {
+				return http.StatusOK, (*items)[index], nil
+			})
+		}},
+		{http.MethodPut, []string{"profiles", "{name}", resource, "{item}"}, func(w http.ResponseWriter, r *http.Request, params []string) {
+			item(w, r, params, func(p *config.ProfileData, items *[]T, index int) (int, interface{}, error) {
+				value, err := decode(r)
+				if err != nil {
+					return 0, nil, err
+				}
+				if id != nil && id(value) != "" && id(value) != id((*items)[index]) {
+					return 0, nil, errors.ErrProfileChanged
+				}
+				// a replaced expense keeps its ID, so it stays the same expense
+				if expense, ok := any(&value).(*config.ExpensesStuct); ok && expense.ID == "" {
+					expense.ID = any((*items)[index]).(config.ExpensesStuct).ID
+				}
+				(*items)[index] = value
+				return http.StatusOK, value, nil
+			})
+		}},
+		{http.MethodDelete, []string{"profiles", "{name}", resource, "{item}"}, func(w http.ResponseWriter, r *http.Request, params []string) {
+			item(w, r, params, func(p *config.ProfileData, items *[]T, index int) (int, interface{}, error) {
+				*items = append((*items)[:index], (*items)[index+1:]...)
+				return http.StatusNoContent, nil, nil
+			})
+		}},
+	}
+}
+
+// position returns the position of the item the key of a path addresses in the list: the
+// item of that ID if the items have one, or else the item at that position. It returns -1
+// if there is no such item.
+func position[T any](items []T, key string, id func(item T) string) int {
+	if id != nil {
+		for i, item := range items {
+			if id(item) == key {
+				return i
+			}
+		}
+	}
+	index, err := strconv.Atoi(key)
+	if err != nil || index < 0 || index >= len(items) {
+		return -1
+	}
+	return index
+}
+
+// readOnly returns a handler that reads the profile and writes what compute returns for it.
+func readOnly(compute func(p config.ProfileData, r *http.Request) (interface{}, error)) func(w http.ResponseWriter, r *http.Request, params []string) {
+	return func(w http.ResponseWriter, r *http.Request, params []string) {
+		profileData, err := storeOf(r).Read(params[0])
+		if err != nil {
+			writeFailure(w, err)
+			return
+		}
+		result, err := compute(profileData, r)
+		if err != nil {
+			writeFailure(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+func listProfiles(w http.ResponseWriter, r *http.Request, params []string) {
+	profiles, err := storeOf(r).Profiles()
+	if err != nil {
+		writeFailure(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, profiles)
+}
+
+func createProfile(w http.ResponseWriter, r *http.Request, params []string) {
+	var body struct {
+		Name string `json:"name"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	if err := validate.Name(body.Name); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	if err := storeOf(r).Create(body.Name); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	profileData, err := storeOf(r).Read(body.Name)
+	if err != nil {
+		writeFailure(w, err)
+		return
+	}
+	w.Header().Set("Location", fmt.Sprintf("/%s/profiles/%s", Version, strings.ToLower(body.Name)))
+	writeJSON(w, http.StatusCreated, profileData)
+}
+
+func getProfile(w http.ResponseWriter, r *http.Request, params []string) {
+	profileData, err := storeOf(r).Read(params[0])
+	if err != nil {
+		writeFailure(w, err)
+		return
+	}
+	w.Header().Set("ETag", etag(profileData.Version))
+	writeJSON(w, http.StatusOK, profileData)
+}
+
+// putProfile replaces the profile. With an If-Match header, it is only replaced if it is
+// still the version the header names; without it, it replaces whatever version there is.
+func putProfile(w http.ResponseWriter, r *http.Request, params []string) {
+	current, err := storeOf(r).Read(params[0])
+	if err != nil {
+		writeFailure(w, err)
+		return
+	}
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch != "" && !matches(ifMatch, etag(current.Version)) {
+		writeError(w, http.StatusPreconditionFailed, errors.ErrProfileChanged)
+		return
+	}
+	var profileData config.ProfileData
+	if err := readJSON(r, &profileData); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	if err := validate.Profile(profileData); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	if ifMatch != "" {
+		// the storage refuses the write if another one came in since the version was compared
+		profileData.Version = current.Version
+	}
+	if err := storeOf(r).Update(params[0], &profileData); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	w.Header().Set("ETag", etag(profileData.Version))
+	writeJSON(w, http.StatusOK, profileData)
+}
+
+// etag returns the entity tag of the version of a profile.
+func etag(version string) string {
+	sum := sha256.Sum256([]byte(version))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// matches reports whether the entity tag is one of the comma separated tags of an If-Match
+// header, or the header is "*".
+func matches(header, tag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func deleteProfile(w http.ResponseWriter, r *http.Request, params []string) {
+	if err := storeOf(r).Delete(params[0]); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func renameProfile(w http.ResponseWriter, r *http.Request, params []string) {
+	var body struct {
+		Name string `json:"name"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	if err := validate.Name(body.Name); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	if err := storeOf(r).Rename(params[0], body.Name); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	w.Header().Set("Location", fmt.Sprintf("/%s/profiles/%s", Version, strings.ToLower(body.Name)))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func getConfig(w http.ResponseWriter, r *http.Request, params []string) {
+	readOnly(func(p config.ProfileData, r *http.Request) (interface{}, error) {
+		return p.Config, nil
+	})(w, r, params)
+}
+
+func putConfig(w http.ResponseWriter, r *http.Request, params []string) {
+	profileData, err := storeOf(r).Read(params[0])
+	if err != nil {
+		writeFailure(w, err)
+		return
+	}
+	var configData config.ConfigStruct
+	if err := readJSON(r, &configData); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	if err := validate.Config(configData); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	profileData.Config = configData
+	if err := storeOf(r).Update(params[0], &profileData); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, configData)
+}
+
+func calculate(p config.ProfileData, r *http.Request) (interface{}, error) {
+	return expenses.Compute(p), nil
+}
+
+func upcoming(p config.ProfileData, r *http.Request) (interface{}, error) {
+	days, err := queryInt(r, "days", 30)
+	if err != nil {
+		return nil, err
+	}
+	return nonNil(expenses.Upcoming(p, time.Now(), days)), nil
+}
+
+func cashFlow(p config.ProfileData, r *http.Request) (interface{}, error) {
+	day := time.Now()
+	result := expenses.ComputeAt(p, day)
+	return expenses.CashFlow(p, expenses.LastPayday(p, day), result.WithdrawnAmount), nil
+}
+
+func projection(p config.ProfileData, r *http.Request) (interface{}, error) {
+	years, err := queryInt(r, "years", 1)
+	if err != nil {
+		return nil, err
+	}
+	projected := expenses.Project(p, years)
+	return map[string]interface{}{
+		"years":       years,
+		"expenses":    nonNil(projected.Expenses),
+		"calculation": expenses.Compute(projected),
+	}, nil
+}
+
+func listScenarios(w http.ResponseWriter, r *http.Request, params []string) {
+	scenarios, err := storeOf(r).Scenarios(params[0])
+	if err != nil {
+		writeFailure(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, nonNil(scenarios))
+}
+
+func createScenario(w http.ResponseWriter, r *http.Request, params []string) {
+	var body struct {
+		Name string `json:"name"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	if err := validate.Name(body.Name); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	if err := storeOf(r).CreateScenario(params[0], body.Name); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	scenarioData, err := storeOf(r).ReadScenario(params[0], body.Name)
+	if err != nil {
+		writeFailure(w, err)
+		return
+	}
+	w.Header().Set("Location", fmt.Sprintf("/%s/profiles/%s/scenarios/%s", Version, strings.ToLower(params[0]), strings.ToLower(body.Name)))
+	writeJSON(w, http.StatusCreated, scenarioData)
+}
+
+func getScenario(w http.ResponseWriter, r *http.Request, params []string) {
+	scenarioData, err := storeOf(r).ReadScenario(params[0], params[1])
+	if err != nil {
+		writeFailure(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, scenarioData)
+}
+
+func putScenario(w http.ResponseWriter, r *http.Request, params []string) {
+	if _, err := storeOf(r).ReadScenario(params[0], params[1]); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	var scenarioData config.ProfileData
+	if err := readJSON(r, &scenarioData); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	if err := validate.Profile(scenarioData); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	if err := storeOf(r).UpdateScenario(params[0], params[1], &scenarioData); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, scenarioData)
+}
+
+func deleteScenario(w http.ResponseWriter, r *http.Request, params []string) {
+	if err := storeOf(r).DeleteScenario(params[0], params[1]); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func calculateScenario(w http.ResponseWriter, r *http.Request, params []string) {
+	scenarioData, err := storeOf(r).ReadScenario(params[0], params[1])
+	if err != nil {
+		writeFailure(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, expenses.Compute(scenarioData))
+}
+
+// nonNil returns an empty list instead of nil, so empty lists are written as [] rather than null.
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+func getAccess(w http.ResponseWriter, r *http.Request, params []string) {
+	access, err := storeOf(r).Access(params[0])
+	if err != nil {
+		writeFailure(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, access)
+}
+
+func putShare(w http.ResponseWriter, r *http.Request, params []string) {
+	var body struct {
+		Permission auth.Permission `json:"permission"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	if body.Permission != auth.Read && body.Permission != auth.Edit {
+		writeFailure(w, errors.ErrInvalidPermission)
+		return
+	}
+	if err := storeOf(r).Share(params[0], params[1], body.Permission); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	getAccess(w, r, params)
+}
+
+func deleteShare(w http.ResponseWriter, r *http.Request, params []string) {
+	if err := storeOf(r).Share(params[0], params[1], auth.None); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func getAudit(w http.ResponseWriter, r *http.Request, params []string) {
+	entries, err := storeOf(r).Audit(params[0])
+	if err != nil {
+		writeFailure(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"wallkeiro/core/config"
+)
+
+// testProfile makes a temporary folder the working directory, with a new profile of the given name.
+func testProfile(t *testing.T, name string) {
+	t.Helper()
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(workingDirectory) })
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(config.ProfilesFolder, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.CreateNewProfile(name); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// send sends a request to the API on localhost and returns the response.
+func send(t *testing.T, method, path, body string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	request := httptest.NewRequest(method, "/v1"+path, strings.NewReader(body))
+	request.Host = "localhost"
+	for key, values := range header {
+		request.Header[key] = values
+	}
+	response := httptest.NewRecorder()
+	(&Server{}).ServeHTTP(response, request)
+	return response
+}
+
+// mustSend sends a request and fails the test unless it is answered with the expected status.
+// The body of the response is decoded into result, unless it is nil.
+func mustSend(t *testing.T, method, path, body string, expected int, result interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	response := send(t, method, path, body, nil)
+	if response.Code != expected {
+		t.Fatalf("%s %s: got %d %s, want %d", method, path, response.Code, response.Body, expected)
+	}
+	if result != nil {
+		if err := json.Unmarshal(response.Body.Bytes(), result); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return response
+}
+
+func TestExpensesByID(t *testing.T) {
+	testProfile(t, "home")
+	var rent, food config.ExpensesStuct
+	mustSend(t, http.MethodPost, "/profiles/home/expenses", `{"name":"Rent","amount":900}`, http.StatusCreated, &rent)
+	mustSend(t, http.MethodPost, "/profiles/home/expenses", `{"name":"Food","amount":300}`, http.StatusCreated, &food)
+	if rent.ID == "" || food.ID == "" || rent.ID == food.ID {
+		t.Fatalf("added expenses have the IDs %q and %q", rent.ID, food.ID)
+	}
+
+	// deleting the rent moves the food to the front, where it is still found by its ID
+	mustSend(t, http.MethodDelete, "/profiles/home/expenses/"+rent.ID, "", http.StatusNoContent, nil)
+	var saved config.ExpensesStuct
+	mustSend(t, http.MethodPut, "/profiles/home/expenses/"+food.ID, `{"name":"Food","amount":350}`, http.StatusOK, &saved)
+	if saved.ID != food.ID || saved.Amount != 350 {
+		t.Fatalf("saved %+v, want the food with an amount of 350", saved)
+	}
+	mustSend(t, http.MethodGet, "/profiles/home/expenses/0", "", http.StatusOK, &saved)
+	if saved.ID != food.ID {
+		t.Fatalf("the first expense is %+v, want the food", saved)
+	}
+
+	// a position giving the ID of another expense than the one there now is refused
+	mustSend(t, http.MethodPost, "/profiles/home/expenses", `{"name":"Rent","amount":950}`, http.StatusCreated, &rent)
+	mustSend(t, http.MethodPut, "/profiles/home/expenses/1", `{"id":"`+food.ID+`","name":"Food","amount":400}`, http.StatusConflict, nil)
+	mustSend(t, http.MethodGet, "/profiles/home/expenses/"+rent.ID, "", http.StatusOK, &saved)
+	if saved.Name != "Rent" || saved.Amount != 950 {
+		t.Fatalf("the rent is %+v after a refused change", saved)
+	}
+	mustSend(t, http.MethodGet, "/profiles/home/expenses/"+rent.ID+"0", "", http.StatusNotFound, nil)
+}
+
+func TestPutProfileIfMatch(t *testing.T) {
+	testProfile(t, "home")
+	var profile config.ProfileData
+	first := mustSend(t, http.MethodGet, "/profiles/home", "", http.StatusOK, &profile).Header().Get("ETag")
+	if first == "" {
+		t.Fatal("the profile has no ETag")
+	}
+	profile.Config.Salary = 2000
+	body, err := json.Marshal(profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response := send(t, http.MethodPut, "/profiles/home", string(body), http.Header{"If-Match": {first}})
+	second := response.Header().Get("ETag")
+	if response.Code != http.StatusOK || second == "" || second == first {
+		t.Fatalf("PUT with the current ETag: got %d with the ETag %q", response.Code, second)
+	}
+
+	// a change edited from the first version would undo the one just made
+	profile.Config.Salary = 1000
+	body, err = json.Marshal(profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response := send(t, http.MethodPut, "/profiles/home", string(body), http.Header{"If-Match": {first}}); response.Code != http.StatusPreconditionFailed {
+		t.Fatalf("PUT with an old ETag: got %d, want %d", response.Code, http.StatusPreconditionFailed)
+	}
+	var saved config.ProfileData
+	if tag := mustSend(t, http.MethodGet, "/profiles/home", "", http.StatusOK, &saved).Header().Get("ETag"); tag != second || saved.Config.Salary != 2000 {
+		t.Fatalf("after a refused PUT the salary is %v with the ETag %q, want 2000 with %q", saved.Config.Salary, tag, second)
+	}
+
+	// without If-Match the profile is replaced whatever its version
+	mustSend(t, http.MethodPut, "/profiles/home", string(body), http.StatusOK, &saved)
+	if saved.Config.Salary != 1000 {
+		t.Fatalf("PUT without If-Match saved a salary of %v, want 1000", saved.Config.Salary)
+	}
+}
@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"bufio"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/table"
+)
+
+// Entry is a single change recorded in the audit log.
+type Entry struct {
+	Time    time.Time `json:"time"`
+	User    string    `json:"user"`
+	Profile string    `json:"profile"`
+	Action  string    `json:"action"`
+	Detail  string    `json:"detail"`
+}
+
+// auditMu makes entries be appended one at a time, so lines of the log never mix.
+var auditMu sync.Mutex
+
+// auditPath returns the path of the audit log, which has one JSON entry per line.
+func auditPath() string {
+	return fmt.Sprintf("%s/%s/audit.log", config.ProfilesFolder, UsersFolder)
+}
+
+// Record appends an entry to the audit log. The log is only ever appended to.
+func Record(entry Entry) error {
+	auditMu.Lock()
+	defer auditMu.Unlock()
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	err := os.MkdirAll(fmt.Sprintf("%s/%s", config.ProfilesFolder, UsersFolder), 0700)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(auditPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Audit returns the entries of the audit log for the given profile, oldest first,
+// or all entries if the profile is empty.
+func Audit(profile string) ([]Entry, error) {
+	entries := []Entry{}
+	file, err := os.Open(auditPath())
+	if goerrors.Is(err, fs.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return entries, err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return entries, err
+		}
+		if profile == "" || strings.EqualFold(entry.Profile, profile) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// ShowAudit prints a table of the given entries of the audit log.
+func ShowAudit(entries []Entry) {
+	if len(entries) == 0 {
+		fmt.Println("No changes recorded yet.")
+		return
+	}
+	var rows [][]string
+	for _, entry := range entries {
+		rows = append(rows, []string{entry.Time.Local().Format("2006-01-02 15:04"), entry.User, entry.Profile, entry.Action, entry.Detail})
+	}
+	table.Print([]string{"Time", "User", "Profile", "Action", "Detail"}, rows)
+}
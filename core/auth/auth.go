@@ -0,0 +1,464 @@
+// Package auth holds the user accounts of a wallkeiro server, who owns which profile and
+// with whom it is shared, and the audit log of every change made through the server.
+// As long as no user account exists, the server is open to this computer only, like before.
+// Once the first account is added, every request to the server has to log in, and every
+// profile is only visible to its owner, the users it is shared with, and the admins.
+// The menus of wallkeiro are not affected, since they read the profile files directly.
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// UsersFolder is the folder inside the profiles folder where the user accounts and the
+// audit log are stored, so they never show up as profiles.
+const UsersFolder string = "users"
+
+// TokenPrefix starts every API token, so tokens are easy to recognize.
+const TokenPrefix string = "wk_"
+
+// MinPasswordLength is the shortest password accepted for a user account.
+const MinPasswordLength int = 8
+
+// Permission is what a user may do with a profile. Every permission includes the ones before it.
+type Permission string
+
+const (
+	None  Permission = ""
+	Read  Permission = "read"
+	Edit  Permission = "edit"
+	Owner Permission = "owner"
+)
+
+// Allows reports whether the permission includes the given one.
+func (p Permission) Allows(needed Permission) bool {
+	rank := map[Permission]int{None: 0, Read: 1, Edit: 2, Owner: 3}
+	return rank[p] >= rank[needed]
+}
+
+// User is an account of a person using the server. Admins may use every profile,
+// including the ones created in the menus, which have no owner.
+type User struct {
+	Name         string  `json:"name"`
+	PasswordHash string  `json:"password_hash"`
+	Admin        bool    `json:"admin"`
+	Tokens       []Token `json:"tokens"`
+}
+
+// Token is an API token of a user, for services that can not ask for a password.
+// Only the SHA-256 hash of the token is stored, so the token is shown once when it is created.
+type Token struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Hash    string `json:"hash"`
+	Created string `json:"created"`
+}
+
+// ProfileAccess tells who owns a profile and with whom it is shared, and how.
+type ProfileAccess struct {
+	Owner  string                `json:"owner"`
+	Shares map[string]Permission `json:"shares"`
+}
+
+// Accounts are the user accounts and the access to the profiles, as stored in the users folder.
+// Profiles are keyed by their lower case name, like their files.
+type Accounts struct {
+	Users    []User                   `json:"users"`
+	Profiles map[string]ProfileAccess `json:"profiles"`
+}
+
+// mu makes changes to the accounts happen one at a time, since every change reads and writes the whole file.
+var mu sync.Mutex
+
+// dummyHash is compared with the passwords of unknown users.
+var dummyHash []byte
+var dummyOnce sync.Once
+
+// loaded are the accounts last read, with the modification time and size of their file, so
+// the file is only read again once it changed.
+var loaded struct {
+	sync.Mutex
+	valid    bool
+	modified time.Time
+	size     int64
+	accounts Accounts
+}
+
+// verified holds when passwords were last accepted by bcrypt, so a client sending its
+// password with every request does not wait for bcrypt every time. The key is a hash of the
+// user name, the password and its bcrypt hash, so the passwords are never kept, and a
+// changed password is checked by bcrypt again.
+var verified = struct {
+	sync.Mutex
+	at map[string]time.Time
+}{at: map[string]time.Time{}}
+
+// verifiedFor is how long a password accepted by bcrypt is accepted without checking it again.
+const verifiedFor time.Duration = 5 * time.Minute
+
+// accountsPath returns the path of the file containing the accounts.
+func accountsPath() string {
+	return fmt.Sprintf("%s/%s/accounts.json", config.ProfilesFolder, UsersFolder)
+}
+
+// Load reads the accounts. If there are none yet, it returns empty accounts. The accounts
+// are read from their file only if it changed since they were last read.
+func Load() (Accounts, error) {
+	accounts := Accounts{Users: []User{}, Profiles: map[string]ProfileAccess{}}
+	info, err := os.Stat(accountsPath())
+	if goerrors.Is(err, fs.ErrNotExist) {
+		return accounts, nil
+	}
+	if err != nil {
+		return accounts, err
+	}
+	loaded.Lock()
+	defer loaded.Unlock()
+	if loaded.valid && loaded.modified.Equal(info.ModTime()) && loaded.size == info.Size() {
+		return loaded.accounts.clone(), nil
+	}
+	data, err := os.ReadFile(accountsPath())
+	if err != nil {
+		return accounts, err
+	}
+	if err := json.Unmarshal(data, &accounts); err != nil {
+		return accounts, err
+	}
+	if accounts.Profiles == nil {
+		accounts.Profiles = map[string]ProfileAccess{}
+	}
+	loaded.valid, loaded.modified, loaded.size, loaded.accounts = true, info.ModTime(), info.Size(), accounts
+	return accounts.clone(), nil
+}
+
+// clone returns a copy of the accounts sharing nothing with them, so changing the accounts
+// returned by Load never changes the ones it keeps.
+func (a Accounts) clone() Accounts {
+	cloned := Accounts{Users: make([]User, len(a.Users)), Profiles: make(map[string]ProfileAccess, len(a.Profiles))}
+	for i, user := range a.Users {
+		if user.Tokens != nil {
+			user.Tokens = append(make([]Token, 0, len(user.Tokens)), user.Tokens...)
+		}
+		cloned.Users[i] = user
+	}
+	for profile, access := range a.Profiles {
+		if access.Shares != nil {
+			shares := make(map[string]Permission, len(access.Shares))
+			for name, permission := range access.Shares {
+				shares[name] = permission
+			}
+			access.Shares = shares
+		}
+		cloned.Profiles[profile] = access
+	}
+	return cloned
+}
+
+// Save writes the accounts. The file is only readable by the user running wallkeiro,
+// since it contains the password hashes.
+func Save(accounts *Accounts) error {
+	err := os.MkdirAll(fmt.Sprintf("%s/%s", config.ProfilesFolder, UsersFolder), 0700)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(accounts, "", "  ")
+	if err != nil {
+		return err
+	}
+	// a file changed twice within the resolution of its modification time may keep its size
+	loaded.Lock()
+	defer loaded.Unlock()
+	loaded.valid = false
+	return os.WriteFile(accountsPath(), data, 0600)
+}
+
+// Change loads the accounts, changes them and saves them, one change at a time.
+// If change returns an error, nothing is saved.
+func Change(change func(accounts *Accounts) error) error {
+	mu.Lock()
+	defer mu.Unlock()
+	accounts, err := Load()
+	if err != nil {
+		return err
+	}
+	if err := change(&accounts); err != nil {
+		return err
+	}
+	return Save(&accounts)
+}
+
+// Enabled reports whether there is at least one user account, so the server requires logging in.
+func Enabled() bool {
+	accounts, err := Load()
+	// accounts that can not be read must not open the server to everyone
+	return err != nil || len(accounts.Users) > 0
+}
+
+// User returns the user with the given name.
+func (a *Accounts) User(name string) (*User, bool) {
+	for i := range a.Users {
+		if strings.EqualFold(a.Users[i].Name, name) {
+			return &a.Users[i], true
+		}
+	}
+	return nil, false
+}
+
+// UserNames returns the names of all users, sorted.
+func (a *Accounts) UserNames() []string {
+	var names []string
+	for _, user := range a.Users {
+		names = append(names, user.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AddUser adds a user with the given password. The first user becomes an admin,
+// so the profiles that already exist are not locked away from everyone.
+func (a *Accounts) AddUser(name, password string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.ErrNameRequired
+	}
+	if strings.ContainsAny(name, ": ") {
+		return errors.ErrInvalidName
+	}
+	if _, ok := a.User(name); ok {
+		return errors.ErrAlreadyExists
+	}
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	a.Users = append(a.Users, User{Name: name, PasswordHash: hash, Admin: len(a.Users) == 0, Tokens: []Token{}})
+	return nil
+}
+
+// SetPassword sets a new password for the user.
+func (a *Accounts) SetPassword(name, password string) error {
+	user, ok := a.User(name)
+	if !ok {
+		return errors.ErrNotFound
+	}
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = hash
+	return nil
+}
+
+// DeleteUser deletes the user together with their tokens and shares.
+// The profiles they own are left without an owner, so only admins can use them.
+func (a *Accounts) DeleteUser(name string) error {
+	for i, user := range a.Users {
+		if !strings.EqualFold(user.Name, name) {
+			continue
+		}
+		a.Users = append(a.Users[:i], a.Users[i+1:]...)
+		for profile, access := range a.Profiles {
+			if strings.EqualFold(access.Owner, name) {
+				access.Owner = ""
+			}
+			for shared := range access.Shares {
+				if strings.EqualFold(shared, name) {
+					delete(access.Shares, shared)
+				}
+			}
+			a.Profiles[profile] = access
+		}
+		return nil
+	}
+	return errors.ErrNotFound
+}
+
+// CreateToken creates a new API token for the user and returns it.
+// The token itself is not stored, so it can not be shown again.
+func (a *Accounts) CreateToken(name, tokenName string) (string, error) {
+	user, ok := a.User(name)
+	if !ok {
+		return "", errors.ErrNotFound
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	token := TokenPrefix + hex.EncodeToString(secret)
+	id := make([]byte, 4)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	user.Tokens = append(user.Tokens, Token{
+		ID:      hex.EncodeToString(id),
+		Name:    tokenName,
+		Hash:    hashToken(token),
+		Created: time.Now().Format(config.DateFormat),
+	})
+	return token, nil
+}
+
+// RevokeToken deletes the token with the given ID of the user.
+func (a *Accounts) RevokeToken(name, id string) error {
+	user, ok := a.User(name)
+	if !ok {
+		return errors.ErrNotFound
+	}
+	for i, token := range user.Tokens {
+		if token.ID == id {
+			user.Tokens = append(user.Tokens[:i], user.Tokens[i+1:]...)
+			return nil
+		}
+	}
+	return errors.ErrNotFound
+}
+
+// Authenticate returns the name of the user if the password is theirs.
+func (a *Accounts) Authenticate(name, password string) (string, error) {
+	user, ok := a.User(name)
+	if !ok {
+		// compare anyway, so the time taken does not tell which users exist
+		dummyOnce.Do(func() {
+			dummyHash, _ = bcrypt.GenerateFromPassword([]byte("wallkeiro"), bcrypt.DefaultCost)
+		})
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return "", errors.ErrUnauthorized
+	}
+	sum := sha256.Sum256([]byte(name + "\x00" + password + "\x00" + user.PasswordHash))
+	key := hex.EncodeToString(sum[:])
+	verified.Lock()
+	at, ok := verified.at[key]
+	verified.Unlock()
+	if ok && time.Since(at) < verifiedFor {
+		return user.Name, nil
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		return "", errors.ErrUnauthorized
+	}
+	verified.Lock()
+	defer verified.Unlock()
+	for old, at := range verified.at {
+		if time.Since(at) >= verifiedFor {
+			delete(verified.at, old)
+		}
+	}
+	verified.at[key] = time.Now()
+	return user.Name, nil
+}
+
+// AuthenticateToken returns the name of the user the API token belongs to.
+func (a *Accounts) AuthenticateToken(token string) (string, error) {
+	hash := []byte(hashToken(token))
+	for _, user := range a.Users {
+		for _, userToken := range user.Tokens {
+			if subtle.ConstantTimeCompare(hash, []byte(userToken.Hash)) == 1 {
+				return user.Name, nil
+			}
+		}
+	}
+	return "", errors.ErrUnauthorized
+}
+
+// Permission returns what the user may do with the profile.
+func (a *Accounts) Permission(name, profile string) Permission {
+	user, ok := a.User(name)
+	if !ok {
+		return None
+	}
+	if user.Admin {
+		return Owner
+	}
+	access := a.Profiles[strings.ToLower(profile)]
+	if strings.EqualFold(access.Owner, user.Name) {
+		return Owner
+	}
+	for shared, permission := range access.Shares {
+		if strings.EqualFold(shared, user.Name) {
+			return permission
+		}
+	}
+	return None
+}
+
+// SetOwner makes the user the owner of the profile, keeping its shares.
+func (a *Accounts) SetOwner(profile, name string) error {
+	user, ok := a.User(name)
+	if !ok {
+		return errors.ErrNotFound
+	}
+	access := a.Profiles[strings.ToLower(profile)]
+	access.Owner = user.Name
+	a.Profiles[strings.ToLower(profile)] = access
+	return nil
+}
+
+// Share shares the profile with the user for reading or editing. Sharing with
+// the permission None stops sharing the profile with the user.
+func (a *Accounts) Share(profile, name string, permission Permission) error {
+	if permission != None && permission != Read && permission != Edit {
+		return errors.ErrInvalidPermission
+	}
+	user, ok := a.User(name)
+	if !ok {
+		return errors.ErrNotFound
+	}
+	access := a.Profiles[strings.ToLower(profile)]
+	if access.Shares == nil {
+		access.Shares = map[string]Permission{}
+	}
+	if permission == None {
+		delete(access.Shares, user.Name)
+	} else {
+		access.Shares[user.Name] = permission
+	}
+	a.Profiles[strings.ToLower(profile)] = access
+	return nil
+}
+
+// RenameProfile moves the owner and the shares of a profile to its new name.
+func (a *Accounts) RenameProfile(oldName, newName string) {
+	access, ok := a.Profiles[strings.ToLower(oldName)]
+	delete(a.Profiles, strings.ToLower(oldName))
+	if ok {
+		a.Profiles[strings.ToLower(newName)] = access
+	}
+}
+
+// DeleteProfile forgets the owner and the shares of a deleted profile,
+// so a new profile of the same name is not shared by accident.
+func (a *Accounts) DeleteProfile(profile string) {
+	delete(a.Profiles, strings.ToLower(profile))
+}
+
+// hashPassword returns the bcrypt hash of the password, refusing passwords that are too short.
+func hashPassword(password string) (string, error) {
+	if len([]rune(password)) < MinPasswordLength {
+		return "", errors.ErrPasswordTooShort
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// hashToken returns the SHA-256 hash of an API token.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,331 @@
+package auth
+
+import (
+	"encoding/base64"
+	"net"
+	"strings"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+)
+
+// Store performs the operations on profiles on behalf of a user of the server.
+// It checks the permission of the user before every operation, and records every
+// change in the audit log. Users only see the profiles they may read, and profiles
+// they may not read are reported as not found, so their names are not given away.
+type Store struct {
+	// User is the name of the logged in user, or empty if the server has no user
+	// accounts, in which case every operation is allowed.
+	User string
+}
+
+// LocalUser is the user recorded in the audit log for changes made while there are no user accounts.
+const LocalUser string = "local"
+
+// require checks that the user of the store has the given permission for the profile.
+func (s Store) require(profile string, needed Permission) error {
+	permission := Owner
+	if s.User != "" {
+		accounts, err := Load()
+		if err != nil {
+			return err
+		}
+		permission = accounts.Permission(s.User, profile)
+	} else if Enabled() {
+		return errors.ErrUnauthorized
+	}
+	if !permission.Allows(Read) {
+		return errors.ErrNotFound
+	}
+	if !permission.Allows(needed) {
+		return errors.ErrForbidden
+	}
+	return nil
+}
+
+// record appends a change of the profile by the user of the store to the audit log.
+func (s Store) record(profile, action, detail string) error {
+	user := s.User
+	if user == "" {
+		user = LocalUser
+	}
+	return Record(Entry{User: user, Profile: strings.ToLower(profile), Action: action, Detail: detail})
+}
+
+// Profiles returns the names of the profiles the user may read.
+func (s Store) Profiles() ([]string, error) {
+	profiles := []string{}
+	for _, profile := range config.GetProfiles() {
+		err := s.require(profile, Read)
+		if err == nil {
+			profiles = append(profiles, profile)
+		} else if err != errors.ErrNotFound {
+			return nil, err
+		}
+	}
+	return profiles, nil
+}
+
+// Read reads the profile with the given name.
+func (s Store) Read(name string) (config.ProfileData, error) {
+	if err := s.require(name, Read); err != nil {
+		return config.ProfileData{}, err
+	}
+	return config.ReadProfile(name)
+}
+
+// Create creates a new profile owned by the user. It returns errors.ErrAlreadyExists
+// if a profile with the same name exists, even one the user may not see.
+func (s Store) Create(name string) error {
+	if s.User == "" && Enabled() {
+		return errors.ErrUnauthorized
+	}
+	for _, profile := range config.GetProfiles() {
+		if strings.EqualFold(profile, name) {
+			return errors.ErrAlreadyExists
+		}
+	}
+	if err := config.CreateNewProfile(name); err != nil {
+		return err
+	}
+	if s.User != "" {
+		err := Change(func(accounts *Accounts) error {
+			accounts.DeleteProfile(name)
+			return accounts.SetOwner(name, s.User)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return s.record(name, "create", "profile created")
+}
+
+// Update replaces the profile with the given data, recording what changed.
+func (s Store) Update(name string, data *config.ProfileData) error {
+	if err := s.require(name, Edit); err != nil {
+		return err
+	}
+	old, err := config.ReadProfile(name)
+	if err != nil {
+		return err
+	}
+	// a profile sent whole, without the version it was edited from, replaces the current one
+	if data.Version == "" {
+		data.Version = old.Version
+	}
+	if err := config.UpdateProfile(name, data); err != nil {
+		return err
+	}
+	changes := config.Changes(old, *data)
+	if len(changes) == 0 {
+		return nil
+	}
+	return s.record(name, "update", strings.Join(changes, "; "))
+}
+
+// Delete deletes the profile with its scenarios. Only its owner may delete it.
+func (s Store) Delete(name string) error {
+	if err := s.require(name, Owner); err != nil {
+		return err
+	}
+	if err := config.DeleteProfile(name); err != nil {
+		return err
+	}
+	err := Change(func(accounts *Accounts) error {
+		accounts.DeleteProfile(name)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return s.record(name, "delete", "profile deleted")
+}
+
+// Rename renames the profile, keeping its owner and shares. Only its owner may rename it.
+func (s Store) Rename(oldName, newName string) error {
+	if err := s.require(oldName, Owner); err != nil {
+		return err
+	}
+	if !strings.EqualFold(oldName, newName) {
+		for _, profile := range config.GetProfiles() {
+			if strings.EqualFold(profile, newName) {
+				return errors.ErrAlreadyExists
+			}
+		}
+	}
+	if err := config.RenameProfile(oldName, newName); err != nil {
+		return err
+	}
+	err := Change(func(accounts *Accounts) error {
+		accounts.RenameProfile(oldName, newName)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return s.record(newName, "rename", "renamed from "+strings.ToLower(oldName))
+}
+
+// Scenarios returns the names of the scenarios of the profile.
+func (s Store) Scenarios(name string) ([]string, error) {
+	if err := s.require(name, Read); err != nil {
+		return nil, err
+	}
+	if _, err := config.ReadProfile(name); err != nil {
+		return nil, err
+	}
+	return config.GetScenarios(name), nil
+}
+
+// ReadScenario reads a scenario of the profile.
+func (s Store) ReadScenario(name, scenario string) (config.ProfileData, error) {
+	if err := s.require(name, Read); err != nil {
+		return config.ProfileData{}, err
+	}
+	return config.ReadScenario(name, scenario)
+}
+
+// CreateScenario creates a scenario from the profile.
+func (s Store) CreateScenario(name, scenario string) error {
+	if err := s.require(name, Edit); err != nil {
+		return err
+	}
+	if err := config.CreateScenario(name, scenario); err != nil {
+		return err
+	}
+	return s.record(name, "create scenario", "scenario "+strings.ToLower(scenario)+" created")
+}
+
+// UpdateScenario replaces a scenario of the profile with the given data.
+func (s Store) UpdateScenario(name, scenario string, data *config.ProfileData) error {
+	if err := s.require(name, Edit); err != nil {
+		return err
+	}
+	old, err := config.ReadScenario(name, scenario)
+	if err != nil {
+		return err
+	}
+	// like a profile, a scenario sent whole replaces the current one
+	if data.Version == "" {
+		data.Version = old.Version
+	}
+	if err := config.UpdateScenario(name, scenario, data); err != nil {
+		return err
+	}
+	changes := config.Changes(old, *data)
+	if len(changes) == 0 {
+		return nil
+	}
+	return s.record(name, "update scenario", "scenario "+strings.ToLower(scenario)+": "+strings.Join(changes, "; "))
+}
+
+// DeleteScenario deletes a scenario of the profile.
+func (s Store) DeleteScenario(name, scenario string) error {
+	if err := s.require(name, Edit); err != nil {
+		return err
+	}
+	if _, err := config.ReadScenario(name, scenario); err != nil {
+		return err
+	}
+	if err := config.DeleteScenario(name, scenario); err != nil {
+		return err
+	}
+	return s.record(name, "delete scenario", "scenario "+strings.ToLower(scenario)+" deleted")
+}
+
+// Access returns who owns the profile and with whom it is shared.
+func (s Store) Access(name string) (ProfileAccess, error) {
+	if err := s.require(name, Read); err != nil {
+		return ProfileAccess{}, err
+	}
+	if _, err := config.ReadProfile(name); err != nil {
+		return ProfileAccess{}, err
+	}
+	accounts, err := Load()
+	if err != nil {
+		return ProfileAccess{}, err
+	}
+	access := accounts.Profiles[strings.ToLower(name)]
+	if access.Shares == nil {
+		access.Shares = map[string]Permission{}
+	}
+	return access, nil
+}
+
+// Share shares the profile with another user for reading or editing, or stops
+// sharing it if the permission is None. Only the owner of the profile may share it.
+func (s Store) Share(name, user string, permission Permission) error {
+	if err := s.require(name, Owner); err != nil {
+		return err
+	}
+	if _, err := config.ReadProfile(name); err != nil {
+		return err
+	}
+	err := Change(func(accounts *Accounts) error {
+		return accounts.Share(name, user, permission)
+	})
+	if err != nil {
+		return err
+	}
+	if permission == None {
+		return s.record(name, "unshare", "stopped sharing with "+user)
+	}
+	if permission == Read {
+		return s.record(name, "share", "shared with "+user+" for reading")
+	}
+	return s.record(name, "share", "shared with "+user+" for editing")
+}
+
+// Audit returns the changes recorded for the profile. Only its owner may read them.
+func (s Store) Audit(name string) ([]Entry, error) {
+	if err := s.require(name, Owner); err != nil {
+		return nil, err
+	}
+	return Audit(name)
+}
+
+// Login returns the store of the user logging in with the value of an HTTP Authorization
+// header or of gRPC authorization metadata, which is either "Basic" with the user name and
+// password, or "Bearer" with an API token. Without user accounts, no login is needed.
+func Login(authorization string) (Store, error) {
+	if !Enabled() {
+		return Store{}, nil
+	}
+	accounts, err := Load()
+	if err != nil {
+		return Store{}, err
+	}
+	scheme, credentials, _ := strings.Cut(authorization, " ")
+	credentials = strings.TrimSpace(credentials)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		decoded, err := base64.StdEncoding.DecodeString(credentials)
+		if err != nil {
+			return Store{}, errors.ErrUnauthorized
+		}
+		name, password, ok := strings.Cut(string(decoded), ":")
+		if !ok {
+			return Store{}, errors.ErrUnauthorized
+		}
+		user, err := accounts.Authenticate(name, password)
+		return Store{User: user}, err
+	case "bearer":
+		user, err := accounts.AuthenticateToken(credentials)
+		return Store{User: user}, err
+	}
+	return Store{}, errors.ErrUnauthorized
+}
+
+// LocalHost reports whether the host a request is addressed to names this computer.
+// Servers without user accounts only answer such requests, which protects them from
+// web pages that rebind their domain to 127.0.0.1.
+func LocalHost(host string) bool {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+	host = strings.Trim(host, "[]")
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// BanksFolder is the folder inside the profiles folder where the CSV column
+// mappings of the banks are stored, so they can be reused by every profile.
+const BanksFolder string = "banks"
+
+// Sign conventions of the amount column in a bank's CSV export.
+const (
+	// NegativeDebit means outgoing payments have a negative amount.
+	NegativeDebit string = "negative-debit"
+	// PositiveDebit means outgoing payments have a positive amount and incoming ones a negative amount.
+	PositiveDebit string = "positive-debit"
+)
+
+// BankMapping describes how the CSV export of a bank is read.
+// Columns are given either by their header name or by their position, starting with 1.
+// DateFormat is a Go time layout, for example "02.01.2006". If DebitColumn and CreditColumn
+// are set, they are used instead of AmountColumn for banks that split the amount in two columns.
+type BankMapping struct {
+	Name             string `json:"name"`
+	Delimiter        string `json:"delimiter"`
+	Encoding         string `json:"encoding"`
+	SkipRows         int    `json:"skip_rows"`
+	HasHeader        bool   `json:"has_header"`
+	DateColumn       string `json:"date_column"`
+	DateFormat       string `json:"date_format"`
+	AmountColumn     string `json:"amount_column"`
+	DebitColumn      string `json:"debit_column"`
+	CreditColumn     string `json:"credit_column"`
+	PayeeColumn      string `json:"payee_column"`
+	MemoColumn       string `json:"memo_column"`
+	SignConvention   string `json:"sign_convention"`
+	DecimalSeparator string `json:"decimal_separator"`
+}
+
+// bankMappingPath returns the path of the file containing the mapping of the given bank.
+func bankMappingPath(bankName string) string {
+	return fmt.Sprintf("%s/%s/%s.json", ProfilesFolder, BanksFolder, strings.ToLower(bankName))
+}
+
+// GetBankMappings returns the names of all saved bank mappings.
+func GetBankMappings() []string {
+	var banks []string
+	files, err := os.ReadDir(fmt.Sprintf("%s/%s", ProfilesFolder, BanksFolder))
+	if err != nil {
+		return banks
+	}
+	for _, file := range files {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), ".json") {
+			banks = append(banks, strings.TrimSuffix(file.Name(), ".json"))
+		}
+	}
+	return banks
+}
+
+// ReadBankMapping reads the saved mapping of the given bank.
+// It returns an error if there was an error reading the file or unmarshaling the JSON.
+func ReadBankMapping(bankName string) (BankMapping, error) {
+	data, err := os.ReadFile(bankMappingPath(bankName))
+	if err != nil {
+		return BankMapping{}, err
+	}
+	var mapping BankMapping
+	err = json.Unmarshal(data, &mapping)
+	if err != nil {
+		return BankMapping{}, err
+	}
+	return mapping, nil
+}
+
+// SaveBankMapping writes the given mapping to the banks folder, overwriting
+// the saved mapping of the same bank if there is one.
+func SaveBankMapping(mapping *BankMapping) error {
+	err := os.MkdirAll(fmt.Sprintf("%s/%s", ProfilesFolder, BanksFolder), 0755)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(mapping)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(bankMappingPath(mapping.Name), data, 0644)
+	if err != nil {
+		return err
+	}
+	return changed(fmt.Sprintf("bank mapping %s saved", strings.ToLower(mapping.Name)), bankMappingPath(mapping.Name))
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// OnChange, if set, is called after a profile, a scenario or a bank mapping was written,
+// renamed or deleted, with a message describing the change and the paths of the files and
+// folders it touched. The history package sets it to commit every change to git.
+var OnChange func(message string, paths ...string) error
+
+// changed calls OnChange, if it is set.
+func changed(message string, paths ...string) error {
+	if OnChange == nil {
+		return nil
+	}
+	return OnChange(message, paths...)
+}
+
+// describeUpdate returns the message for OnChange telling how a profile or scenario changed.
+func describeUpdate(name string, old, new ProfileData) string {
+	changes := Changes(old, new)
+	if len(changes) == 0 {
+		return name + ": saved without changes"
+	}
+	return name + ": " + strings.Join(changes, "; ")
+}
+
+// Changes describes the differences between two versions of a profile, one line for each
+// changed setting and for each expense, debt, goal or rule that was added, removed or changed.
+// Expenses are told apart by their ID, so renaming one is a change, the other items by their
+// name. Imported transactions are only counted.
+func Changes(old, new ProfileData) []string {
+	var changes []string
+	if old.Config.Salary != new.Config.Salary || old.Config.SalaryType != new.Config.SalaryType {
+		changes = append(changes, fmt.Sprintf("salary changed to %.2f€ (%s)", new.Config.Salary, new.Config.SalaryType))
+	}
+	if old.Config.SavingLevel != new.Config.SavingLevel {
+		changes = append(changes, fmt.Sprintf("saving level changed to %d", new.Config.SavingLevel))
+	}
+	if old.Config.Payday != new.Config.Payday {
+		changes = append(changes, fmt.Sprintf("payday changed to %d", new.Config.Payday))
+	}
+	if old.Config.Savings != new.Config.Savings || old.Config.EmergencyMonths != new.Config.EmergencyMonths {
+		changes = append(changes, fmt.Sprintf("savings changed to %.2f€ for %d months", new.Config.Savings, new.Config.EmergencyMonths))
+	}
+	if old.Config.Inflation != new.Config.Inflation || !reflect.DeepEqual(old.Config.CategoryInflation, new.Config.CategoryInflation) {
+		changes = append(changes, "inflation rates changed")
+	}
+	changes = append(changes, listChanges("expense", old.Expenses, new.Expenses, func(e ExpensesStuct) string { return e.ID }, func(e ExpensesStuct) string { return e.Name })...)
+	changes = append(changes, listChanges("debt", old.Debts, new.Debts, func(d DebtStruct) string { return d.Name }, nil)...)
+	changes = append(changes, listChanges("goal", old.Goals, new.Goals, func(g GoalStruct) string { return g.Name }, nil)...)
+	changes = append(changes, listChanges("rule", old.Rules, new.Rules, func(r RuleStruct) string { return r.Name }, nil)...)
+	if len(new.Transactions) > len(old.Transactions) {
+		changes = append(changes, fmt.Sprintf("%d transactions imported", len(new.Transactions)-len(old.Transactions)))
+	} else if len(new.Transactions) < len(old.Transactions) {
+		changes = append(changes, fmt.Sprintf("%d transactions removed", len(old.Transactions)-len(new.Transactions)))
+	} else if !reflect.DeepEqual(old.Transactions, new.Transactions) {
+		changes = append(changes, "transactions recategorized")
+	}
+	return changes
+}
+
+// listChanges describes the items of one of the lists of a profile that were added, removed or changed.
+// Items are matched by their key, and named by their name, which is the key if name is nil.
+func listChanges[T any](kind string, old, new []T, key, name func(item T) string) []string {
+	if name == nil {
+		name = key
+	}
+	var changes []string
+	before := map[string]T{}
+	for _, item := range old {
+		before[key(item)] = item
+	}
+	after := map[string]bool{}
+	for _, item := range new {
+		after[key(item)] = true
+		previous, ok := before[key(item)]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("%s %s added", kind, name(item)))
+		case name(previous) != name(item):
+			changes = append(changes, fmt.Sprintf("%s %s renamed to %s", kind, name(previous), name(item)))
+		case !reflect.DeepEqual(previous, item):
+			changes = append(changes, fmt.Sprintf("%s %s changed", kind, name(item)))
+		}
+	}
+	for _, item := range old {
+		if !after[key(item)] {
+			changes = append(changes, fmt.Sprintf("%s %s removed", kind, name(item)))
+		}
+	}
+	return changes
+}
@@ -3,18 +3,30 @@ package config
 import (
 	"wallkeiro/core/errors"
 	"strings"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 )
 
 const ProfilesFolder string = "profiles"
 
+// DateFormat is the layout of the dates stored in profiles.
+const DateFormat string = "2006-01-02"
+
 var MinimalBalanceAfterExpenses float64 = 150
 
 type ProfileData struct {
 	Config ConfigStruct `json:"config"`
 	Expenses []ExpensesStuct `json:"expenses"`
+	Debts []DebtStruct `json:"debts"`
+	Goals []GoalStruct `json:"goals"`
+	Transactions []TransactionStruct `json:"transactions"`
+	Rules []RuleStruct `json:"rules"`
+	// Version is the version of the profile in the storage it was read from, see Backend.
+	// UpdateProfile writes the profile as edited from that version.
+	Version string `json:"-"`
 }
 
 type SalaryType string
@@ -28,15 +40,128 @@ const (
 	Fixed SalaryType = "fixed"
 )
 
+// DefaultEmergencyFundMonths is the number of months of essential expenses
+// the emergency fund should cover when the profile does not set its own target.
+const DefaultEmergencyFundMonths int = 3
+
+type Frequency string
+
+func (f Frequency) String() string {
+	return string(f)
+}
+
+const (
+	Monthly Frequency = "monthly"
+	Yearly Frequency = "yearly"
+)
+
 type ConfigStruct struct {
 	Salary     		float64    `json:"salary"`
 	SalaryType 		SalaryType `json:"salary_type"`
 	SavingLevel     int        `json:"level"`
+	// Payday is the day of the month the salary arrives, 0 means the first day.
+	Payday          int        `json:"payday"`
+	Savings         float64    `json:"savings"`
+	EmergencyMonths int        `json:"emergency_months"`
+	// Inflation is the yearly inflation rate in percent used for expenses
+	// whose category has no rate of its own in CategoryInflation.
+	Inflation         float64            `json:"inflation"`
+	CategoryInflation map[string]float64 `json:"category_inflation"`
 }
 
+// ExpensesStuct describes a single recurring expense of a profile.
+// Expenses are essential unless Discretionary is set, so the emergency fund
+// never underestimates the bills that have to be paid.
+// Monthly expenses are due on DueDay of every month, yearly expenses on DueDay
+// of DueMonth. A DueDay of 0 means the expense has no known due date.
+// ID identifies the expense across renames and devices; see AssignIDs.
 type ExpensesStuct struct {
-	Name   string  `json:"name"`
-	Amount float64 `json:"amount"`
+	ID            string    `json:"id,omitempty"`
+	Name          string    `json:"name"`
+	Amount        float64   `json:"amount"`
+	Discretionary bool      `json:"discretionary"`
+	Category      string    `json:"category"`
+	Frequency     Frequency `json:"frequency"`
+	DueDay        int       `json:"due_day"`
+	DueMonth      int       `json:"due_month"`
+}
+
+// TransactionStruct describes a single booked transaction imported from a bank statement.
+// Outgoing payments have a negative Amount. ID identifies the transaction across imports,
+// so importing an overlapping statement twice does not add the same transaction again.
+// Date is the booking date, ValueDate the day the amount is credited or debited if the
+// statement gives it. IBAN is the account of the counterparty, and Memo the remittance text.
+type TransactionStruct struct {
+	ID        string  `json:"id"`
+	Date      string  `json:"date"`
+	ValueDate string  `json:"value_date"`
+	Amount    float64 `json:"amount"`
+	Payee     string  `json:"payee"`
+	IBAN      string  `json:"iban"`
+	Memo      string  `json:"memo"`
+	Category  string   `json:"category"`
+	Tags      []string `json:"tags,omitempty"`
+	Source    string   `json:"source"`
+}
+
+// RuleStruct describes a categorization rule for imported transactions. Every condition that
+// is set has to match: Payee is a regular expression matched against the payee, MinAmount and
+// MaxAmount bound the absolute amount (zero means no bound), IBAN is the counterparty account
+// and MemoKeywords are words of which at least one has to appear in the memo. A matching rule
+// sets the Category and adds the Tags. Rules with a higher Priority are tried first.
+type RuleStruct struct {
+	Name         string   `json:"name"`
+	Priority     int      `json:"priority"`
+	Payee        string   `json:"payee"`
+	MinAmount    float64  `json:"min_amount"`
+	MaxAmount    float64  `json:"max_amount"`
+	IBAN         string   `json:"iban"`
+	MemoKeywords []string `json:"memo_keywords"`
+	Category     string   `json:"category"`
+	Tags         []string `json:"tags"`
+}
+
+// GoalStruct describes a savings goal, the amount saved for it so far,
+// and the day it should be reached by in the format "2006-01-02".
+type GoalStruct struct {
+	Name     string  `json:"name"`
+	Target   float64 `json:"target"`
+	Saved    float64 `json:"saved"`
+	Deadline string  `json:"deadline"`
+}
+
+// DeadlineDate returns the deadline of the goal as a time.Time.
+// If the goal has no deadline or it can not be parsed, ok is false.
+func (g GoalStruct) DeadlineDate() (deadline time.Time, ok bool) {
+	deadline, err := time.ParseInLocation(DateFormat, g.Deadline, time.Local)
+	return deadline, err == nil
+}
+
+// EffectiveFrequency returns the frequency of the expense. Expenses without one are monthly.
+func (e ExpensesStuct) EffectiveFrequency() Frequency {
+	if e.Frequency == "" {
+		return Monthly
+	}
+	return e.Frequency
+}
+
+// MonthlyAmount returns the amount of the expense spread over a single month.
+// Yearly expenses are divided by twelve, every other expense is monthly.
+func (e ExpensesStuct) MonthlyAmount() float64 {
+	if e.EffectiveFrequency() == Yearly {
+		return e.Amount / 12
+	}
+	return e.Amount
+}
+
+// DebtStruct describes a loan or credit line that is paid off over time.
+// APR is the yearly interest rate in percent, and MinimumPayment is the
+// amount that has to be paid every month until the balance reaches zero.
+type DebtStruct struct {
+	Name           string  `json:"name"`
+	Balance        float64 `json:"balance"`
+	APR            float64 `json:"apr"`
+	MinimumPayment float64 `json:"minimum_payment"`
 }
 
 // SetSalary sets the salary and salary type of a profile.
@@ -72,26 +197,62 @@ func SetSalary(profile string, salary float64, salaryType SalaryType) error {
 // If there was an error reading the file, unmarshaling the JSON, or writing the file,
 // this function returns that error.
 func SetSavingLevel(profile string, level int) error {
-	filePath := fmt.Sprintf("%s/%s.json", ProfilesFolder, strings.ToLower(profile))
-	profileData, err := os.ReadFile(filePath)
+	configData, err := ReadProfile(profile)
 	if err != nil {
 		return err
 	}
-	var configData ProfileData
-	err = json.Unmarshal(profileData, &configData)
+	configData.Config.SavingLevel = level
+	return UpdateProfile(profile, &configData)
+}
+
+// SetPayday sets the day of the month the salary of a profile arrives.
+// If there was an error reading or writing the profile, this function returns that error.
+func SetPayday(profile string, payday int) error {
+	configData, err := ReadProfile(profile)
 	if err != nil {
 		return err
 	}
-	configData.Config.SavingLevel = level
-	data, err := json.Marshal(configData)
+
+	configData.Config.Payday = payday
+
+	return UpdateProfile(profile, &configData)
+}
+
+// SetSavings sets the current savings and the emergency fund target of a profile.
+// The target is the number of months of essential expenses the savings should cover.
+// If there was an error reading or writing the profile, this function returns that error.
+func SetSavings(profile string, savings float64, emergencyMonths int) error {
+	configData, err := ReadProfile(profile)
 	if err != nil {
 		return err
 	}
-	err = os.WriteFile(filePath, data, 0644)
+
+	configData.Config.Savings = savings
+	configData.Config.EmergencyMonths = emergencyMonths
+
+	return UpdateProfile(profile, &configData)
+}
+
+// SetInflation sets the yearly inflation rate in percent of a profile.
+// If category is empty, the global rate is set, which is used for every expense
+// whose category has no rate of its own. Otherwise the rate of the given category is set.
+// If there was an error reading or writing the profile, this function returns that error.
+func SetInflation(profile string, category string, rate float64) error {
+	configData, err := ReadProfile(profile)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	if category == "" {
+		configData.Config.Inflation = rate
+	} else {
+		if configData.Config.CategoryInflation == nil {
+			configData.Config.CategoryInflation = map[string]float64{}
+		}
+		configData.Config.CategoryInflation[strings.ToLower(category)] = rate
+	}
+
+	return UpdateProfile(profile, &configData)
 }
 
 // CreateProfilesFolder creates the profiles folder if it does not exist.
@@ -105,20 +266,11 @@ func CreateProfilesFolder() error {
 	return nil
 }
 
-// GetProfiles returns a list of all profiles in the profiles folder.
+// GetProfiles returns a list of all profiles in the storage.
 // Each profile is represented by the name of the JSON file containing
 // the profile's configuration, without the ".json" extension.
 func GetProfiles() []string {
-	var profiles []string
-	files, err := os.ReadDir(ProfilesFolder)
-	if err != nil {
-		return profiles
-	}
-	for _, file := range files {
-		if !file.IsDir() {
-			profiles = append(profiles, file.Name()[:len(file.Name())-5])
-		}
-	}
+	profiles, _ := Storage.List()
 	return profiles
 }
 
@@ -126,8 +278,7 @@ func GetProfiles() []string {
 // It returns a ProfileData struct containing the profile's configuration, and an error if there was an error reading the file or unmarshaling the JSON.
 // If the file does not exist, this function returns an error.
 func ReadProfile(profileName string) (ProfileData, error) {
-	filePath := fmt.Sprintf("%s/%s.json", ProfilesFolder, strings.ToLower(profileName))
-	profileData, err := os.ReadFile(filePath)
+	profileData, version, err := Storage.Read(strings.ToLower(profileName))
 	if err != nil {
 		return ProfileData{}, err
 	}
@@ -136,26 +287,47 @@ func ReadProfile(profileName string) (ProfileData, error) {
 	if err != nil {
 		return ProfileData{}, err
 	}
+	AssignIDs(&configData)
+	configData.Version = version
 	return configData, nil
 }
 
 // UpdateProfile updates the profile with the given name.
 // It takes a pointer to a ProfileData struct as an argument, marshals it to JSON,
 // and writes it to the file with the given name in the profiles folder.
-// If the file already exists, this function will overwrite it.
+// If the file already exists, this function will overwrite it. The profile is written as
+// edited from data.Version, so a storage shared with other computers merges changes made
+// there since; data is updated to what was written and its new version.
 // If there was an error marshaling the JSON or writing the file, this function
 // returns that error.
 func UpdateProfile(profileName string, data *ProfileData) error {
-	filePath := fmt.Sprintf("%s/%s.json", ProfilesFolder, strings.ToLower(profileName))
+	filePath := profilePath(profileName)
+	NewIDs(data)
+	// the version replaced is only needed to describe the change; reading it does not change
+	// the version the data is written as edited from
+	var old ProfileData
+	if OnChange != nil {
+		old, _ = ReadProfile(profileName)
+	}
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return err
 	}
-	err = os.WriteFile(filePath, jsonData, 0644)
+	written, version, err := Storage.Write(strings.ToLower(profileName), jsonData, data.Version)
 	if err != nil {
 		return err
 	}
-	return nil
+	// the storage may have merged changes made elsewhere, which the data has to show from now on
+	if !bytes.Equal(written, jsonData) {
+		var merged ProfileData
+		if err := json.Unmarshal(written, &merged); err != nil {
+			return err
+		}
+		AssignIDs(&merged)
+		*data = merged
+	}
+	data.Version = version
+	return changed(describeUpdate(strings.ToLower(profileName), old, *data), filePath)
 }
 
 // CreateNewProfile creates a new profile with the given name.
@@ -164,56 +336,75 @@ func UpdateProfile(profileName string, data *ProfileData) error {
 // creating the file.
 // If the profile is successfully created, it returns nil.
 func CreateNewProfile(profileName string) error {
-	filePath := fmt.Sprintf("%s/%s.json", ProfilesFolder, strings.ToLower(profileName))
-	_, err := os.Create(filePath)
+	filePath := profilePath(profileName)
 	// initialize with default config
 	defaultConfig := ProfileData{
 		Config: ConfigStruct{
 			Salary:      0,
 			SalaryType:  Fixed,
 			SavingLevel: 1,
+			EmergencyMonths: DefaultEmergencyFundMonths,
 		},
 		Expenses: []ExpensesStuct{},
+		Debts: []DebtStruct{},
+		Goals: []GoalStruct{},
+		Transactions: []TransactionStruct{},
+		Rules: []RuleStruct{},
 	}
 	data, err := json.Marshal(defaultConfig)
 	if err != nil {
 		return err
 	}
-	err = os.WriteFile(filePath, data, 0644)
+	_, _, err = Storage.Write(strings.ToLower(profileName), data, "")
 	if err != nil {
 		return err
 	}
-	return err
+	return changed(strings.ToLower(profileName)+": profile created", filePath)
 }
 
 // DeleteProfile deletes the profile with the given name.
-// It removes the corresponding file for the profile from the profiles folder.
+// It removes the corresponding file for the profile from the profiles folder,
+// together with all scenarios created from the profile.
 // If the profile does not exist, it returns an error.
 func DeleteProfile(profileName string) error {
-	filePath := fmt.Sprintf("%s/%s.json", ProfilesFolder, strings.ToLower(profileName))
-	err := os.Remove(filePath)
-	return err
+	filePath := profilePath(profileName)
+	err := Storage.Remove(strings.ToLower(profileName))
+	if err != nil {
+		return err
+	}
+	err = os.RemoveAll(scenariosPath(profileName))
+	if err != nil {
+		return err
+	}
+	return changed(strings.ToLower(profileName)+": profile deleted", filePath, scenariosPath(profileName))
 }
 
 // RenameProfile renames a profile from oldName to newName.
+// The scenarios of the profile are moved along with it.
 // It does not perform any additional validation or checks,
 // so it is up to the caller to ensure that the oldName exists and
 // that the newName is valid.
 func RenameProfile(oldName, newName string) error {
-	oldPath := fmt.Sprintf("%s/%s.json", ProfilesFolder, strings.ToLower(oldName))
-	newPath := fmt.Sprintf("%s/%s.json", ProfilesFolder, strings.ToLower(newName))
-	err := os.Rename(oldPath, newPath)
-	return err
+	oldPath := profilePath(oldName)
+	newPath := profilePath(newName)
+	err := Storage.Rename(strings.ToLower(oldName), strings.ToLower(newName))
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(scenariosPath(oldName)); err == nil {
+		err = os.Rename(scenariosPath(oldName), scenariosPath(newName))
+		if err != nil {
+			return err
+		}
+	}
+	return changed(fmt.Sprintf("%s: profile renamed to %s", strings.ToLower(oldName), strings.ToLower(newName)),
+		oldPath, newPath, scenariosPath(oldName), scenariosPath(newName))
 }
 
 // SetLevel sets the minimum balance after expenses that the application should
-// target. The level parameter determines the minimum balance after expenses
-// as follows:
-//
-// Level 1: 190
-// Level 2: 170
-// Level 3: 150
-// Level 4: 100
+// target to the balance of the given level, see LevelBalance.
+// It changes MinimalBalanceAfterExpenses for the whole application, so calculations that
+// may run at the same time, like the ones of the servers, use LevelBalance instead.
 //
 // If the level parameter is greater than 4, this function returns an error.
 // Otherwise, it returns nil.
@@ -221,15 +412,29 @@ func SetLevel(level int) error {
 	if level > 4 {
 		return errors.ErrLevelTooHigh
 	}
+	if level >= 1 {
+		MinimalBalanceAfterExpenses = LevelBalance(level)
+	}
+	return nil
+}
+
+// LevelBalance returns the minimum balance after expenses that the application should
+// target for the given saving level, as follows:
+//
+// Level 1: 190
+// Level 2: 170
+// Level 3: 150
+// Level 4: 100
+//
+// Any other level gets the balance of level 3, the default.
+func LevelBalance(level int) float64 {
 	switch level {
 	case 1:
-		MinimalBalanceAfterExpenses = 190
+		return 190
 	case 2:
-		MinimalBalanceAfterExpenses = 170
-	case 3:
-		MinimalBalanceAfterExpenses = 150
+		return 170
 	case 4:
-		MinimalBalanceAfterExpenses = 100
+		return 100
 	}
-	return nil
+	return 150
 }
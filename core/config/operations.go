@@ -0,0 +1,284 @@
+package config
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"wallkeiro/core/errors"
+)
+
+// The types of operations changing a profile.
+const (
+	OpAdd    string = "add"
+	OpSet    string = "set"
+	OpRemove string = "remove"
+)
+
+// Lists are the lists of a profile that operations change, next to "config" for the settings.
+var Lists = []string{"expenses", "debts", "goals", "transactions", "rules"}
+
+// keyFields are the fields identifying the items of each list. Expenses and transactions
+// have stable IDs, the other items are told apart by their name.
+var keyFields = map[string]string{"expenses": "id", "debts": "name", "goals": "name", "transactions": "id", "rules": "name"}
+
+// Operation is a single change to a profile: adding an item to one of its lists, setting
+// one field of an item or of the settings, or removing an item. Changes made on different
+// devices are merged by replaying the operations of one on the profile of the other, so
+// changes to different items, or to different fields of the same item, never get lost.
+type Operation struct {
+	Type string `json:"op"`
+	// List is one of Lists, or "config" for the settings, which have no ID.
+	List string `json:"list"`
+	ID   string `json:"id,omitempty"`
+	// Field is the JSON name of the field set.
+	Field string `json:"field,omitempty"`
+	// Value is the whole item added, or the new value of the field set.
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// item is an item of a list, or the settings, as its JSON fields.
+type item map[string]json.RawMessage
+
+// document is a profile as the JSON fields of its settings and of the items of its lists.
+type document struct {
+	config item
+	lists  map[string][]item
+}
+
+// AssignIDs gives IDs to the expenses of a profile read from storage. A profile written before
+// expenses had IDs has none at all; its IDs are derived from the names of the expenses, so two
+// devices migrating the same older profile agree on them. Any other expense without an ID, or
+// with one already used by another expense, gets a new random ID from NewIDs.
+func AssignIDs(p *ProfileData) {
+	for _, expense := range p.Expenses {
+		if expense.ID != "" {
+			NewIDs(p)
+			return
+		}
+	}
+	taken := map[string]bool{}
+	for i := range p.Expenses {
+		id := ""
+		for n := 0; id == "" || taken[id]; n++ {
+			sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d", strings.ToLower(p.Expenses[i].Name), n)))
+			id = hex.EncodeToString(sum[:6])
+		}
+		taken[id] = true
+		p.Expenses[i].ID = id
+	}
+}
+
+// NewIDs gives every expense of the profile without an ID, such as one just added, a random ID.
+// An expense whose ID is already used by another one gets a new ID too. The IDs are random, so
+// expenses with the same name added on two devices stay apart when the devices are merged.
+func NewIDs(p *ProfileData) {
+	taken := map[string]bool{}
+	duplicate := make([]bool, len(p.Expenses))
+	for i, expense := range p.Expenses {
+		duplicate[i] = taken[expense.ID]
+		taken[expense.ID] = true
+	}
+	for i := range p.Expenses {
+		if p.Expenses[i].ID != "" && !duplicate[i] {
+			continue
+		}
+		id := ""
+		for id == "" || taken[id] {
+			random := make([]byte, 6)
+			if _, err := rand.Read(random); err != nil {
+				panic(err)
+			}
+			id = hex.EncodeToString(random)
+		}
+		taken[id] = true
+		p.Expenses[i].ID = id
+	}
+}
+
+// Diff returns the operations that turn the old version of a profile into the new one.
+func Diff(old, new ProfileData) ([]Operation, error) {
+	before, err := toDocument(old)
+	if err != nil {
+		return nil, err
+	}
+	after, err := toDocument(new)
+	if err != nil {
+		return nil, err
+	}
+	operations := diffFields("config", "", before.config, after.config)
+	for _, list := range Lists {
+		oldItems := map[string]item{}
+		for _, oldItem := range before.lists[list] {
+			oldItems[oldItem.key(list)] = oldItem
+		}
+		newKeys := map[string]bool{}
+		for _, newItem := range after.lists[list] {
+			key := newItem.key(list)
+			newKeys[key] = true
+			oldItem, ok := oldItems[key]
+			if !ok {
+				value, err := json.Marshal(newItem)
+				if err != nil {
+					return nil, err
+				}
+				operations = append(operations, Operation{Type: OpAdd, List: list, ID: key, Value: value})
+				continue
+			}
+			operations = append(operations, diffFields(list, key, oldItem, newItem)...)
+		}
+		for _, oldItem := range before.lists[list] {
+			if !newKeys[oldItem.key(list)] {
+				operations = append(operations, Operation{Type: OpRemove, List: list, ID: oldItem.key(list)})
+			}
+		}
+	}
+	return operations, nil
+}
+
+// Apply applies the operations to the profile one after the other. Adding an item that is
+// already there replaces it. Setting a field of an item that is not there fails with
+// errors.ErrNotFound, while removing it does nothing.
+func Apply(p *ProfileData, operations []Operation) error {
+	doc, err := toDocument(*p)
+	if err != nil {
+		return err
+	}
+	for _, operation := range operations {
+		if operation.List == "config" {
+			if operation.Type != OpSet {
+				return fmt.Errorf("%w: %s on the settings", errors.ErrInvalidRequest, operation.Type)
+			}
+			doc.config[operation.Field] = operation.Value
+			continue
+		}
+		if _, ok := keyFields[operation.List]; !ok {
+			return fmt.Errorf("%w: unknown list %q", errors.ErrInvalidRequest, operation.List)
+		}
+		items := doc.lists[operation.List]
+		index := -1
+		for i, listItem := range items {
+			if listItem.key(operation.List) == operation.ID {
+				index = i
+			}
+		}
+		switch operation.Type {
+		case OpAdd:
+			var added item
+			if err := json.Unmarshal(operation.Value, &added); err != nil {
+				return err
+			}
+			if index >= 0 {
+				items[index] = added
+			} else {
+				items = append(items, added)
+			}
+		case OpSet:
+			if index < 0 {
+				return fmt.Errorf("%w: %s %s", errors.ErrNotFound, operation.List, operation.ID)
+			}
+			items[index][operation.Field] = operation.Value
+		case OpRemove:
+			if index >= 0 {
+				items = append(items[:index], items[index+1:]...)
+			}
+		default:
+			return fmt.Errorf("%w: unknown operation %q", errors.ErrInvalidRequest, operation.Type)
+		}
+		doc.lists[operation.List] = items
+	}
+	return doc.to(p)
+}
+
+// diffFields returns the operations setting the fields of the new version of an item that differ from the old one.
+func diffFields(list, id string, old, new item) []Operation {
+	var operations []Operation
+	for _, field := range fieldNames(old, new) {
+		if string(old[field]) != string(new[field]) {
+			value := new[field]
+			if value == nil {
+				value = json.RawMessage("null")
+			}
+			operations = append(operations, Operation{Type: OpSet, List: list, ID: id, Field: field, Value: value})
+		}
+	}
+	return operations
+}
+
+// fieldNames returns the names of the fields of both versions of an item, in a fixed order.
+func fieldNames(old, new item) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, fields := range []item{old, new} {
+		for name := range fields {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// KeyField returns the JSON name of the field identifying the items of the given list.
+func KeyField(list string) string {
+	return keyFields[list]
+}
+
+// key returns the value of the field identifying the item in the given list.
+func (i item) key(list string) string {
+	var key string
+	json.Unmarshal(i[keyFields[list]], &key)
+	return key
+}
+
+// toDocument turns the profile into its JSON fields. Values are marshalled compactly,
+// so equal values always have the same JSON.
+func toDocument(p ProfileData) (document, error) {
+	doc := document{lists: map[string][]item{}}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return doc, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return doc, err
+	}
+	if err := json.Unmarshal(fields["config"], &doc.config); err != nil {
+		return doc, err
+	}
+	for _, list := range Lists {
+		var items []item
+		if err := json.Unmarshal(fields[list], &items); err != nil {
+			return doc, err
+		}
+		doc.lists[list] = items
+	}
+	return doc, nil
+}
+
+// to turns the JSON fields back into the profile.
+func (d document) to(p *ProfileData) error {
+	fields := map[string]interface{}{"config": d.config}
+	for _, list := range Lists {
+		items := d.lists[list]
+		if items == nil {
+			items = []item{}
+		}
+		fields[list] = items
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	var profile ProfileData
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return err
+	}
+	*p = profile
+	return nil
+}
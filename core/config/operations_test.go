@@ -0,0 +1,126 @@
+package config
+
+import (
+	goerrors "errors"
+	"testing"
+	"wallkeiro/core/errors"
+)
+
+// oldProfile returns a profile as written before expenses had IDs.
+func oldProfile() ProfileData {
+	return ProfileData{Expenses: []ExpensesStuct{
+		{Name: "Rent", Amount: 900, DueDay: 1},
+		{Name: "Food", Amount: 300},
+		{Name: "food", Amount: 50},
+	}}
+}
+
+func TestAssignIDs(t *testing.T) {
+	first, second := oldProfile(), oldProfile()
+	AssignIDs(&first)
+	AssignIDs(&second)
+	seen := map[string]bool{}
+	for i := range first.Expenses {
+		id := first.Expenses[i].ID
+		if id == "" || seen[id] {
+			t.Fatalf("expense %d has the ID %q, which is empty or taken", i, id)
+		}
+		seen[id] = true
+		if second.Expenses[i].ID != id {
+			t.Fatalf("migrating on two devices gave expense %d the IDs %q and %q", i, id, second.Expenses[i].ID)
+		}
+	}
+
+	// once a profile has IDs, new and duplicate IDs are random instead
+	first.Expenses = append(first.Expenses, ExpensesStuct{Name: "Water"}, ExpensesStuct{ID: first.Expenses[0].ID, Name: "Rent"})
+	AssignIDs(&first)
+	if id := first.Expenses[0].ID; id != second.Expenses[0].ID {
+		t.Fatalf("the ID of the rent changed to %q", id)
+	}
+	for _, expense := range first.Expenses[3:] {
+		if expense.ID == "" || seen[expense.ID] {
+			t.Fatalf("%s has the ID %q, which is empty or taken", expense.Name, expense.ID)
+		}
+		seen[expense.ID] = true
+	}
+}
+
+func TestDiffApply(t *testing.T) {
+	tests := []struct {
+		name string
+		// here and there change the same profile on two devices
+		here, there func(p *ProfileData)
+		// check checks the profile changed here once the changes made there are applied to it
+		check func(t *testing.T, p ProfileData)
+		// err is the error applying the changes made there fails with
+		err error
+	}{
+		{
+			name:  "same-named expenses added on two devices both survive",
+			here:  func(p *ProfileData) { p.Expenses = append(p.Expenses, ExpensesStuct{Name: "Water", Amount: 20}) },
+			there: func(p *ProfileData) { p.Expenses = append(p.Expenses, ExpensesStuct{Name: "Water", Amount: 30}) },
+			check: func(t *testing.T, p ProfileData) {
+				var amounts []float64
+				for _, expense := range p.Expenses {
+					if expense.Name == "Water" {
+						amounts = append(amounts, expense.Amount)
+					}
+				}
+				if len(amounts) != 2 || amounts[0] != 20 || amounts[1] != 30 {
+					t.Fatalf("the water expenses have the amounts %v, want 20 and 30", amounts)
+				}
+			},
+		},
+		{
+			name:  "edits to different fields of one expense are combined",
+			here:  func(p *ProfileData) { p.Expenses[0].Amount = 950 },
+			there: func(p *ProfileData) { p.Expenses[0].DueDay = 5 },
+			check: func(t *testing.T, p ProfileData) {
+				if rent := p.Expenses[0]; rent.Amount != 950 || rent.DueDay != 5 {
+					t.Fatalf("the rent is %+v, want an amount of 950 due on day 5", rent)
+				}
+			},
+		},
+		{
+			name:  "settings and expenses are changed independently",
+			here:  func(p *ProfileData) { p.Config.Salary = 3000 },
+			there: func(p *ProfileData) { p.Expenses = p.Expenses[1:] },
+			check: func(t *testing.T, p ProfileData) {
+				if p.Config.Salary != 3000 || len(p.Expenses) != 2 || p.Expenses[0].Name != "Food" {
+					t.Fatalf("got the salary %v and the expenses %+v, want 3000 without the rent", p.Config.Salary, p.Expenses)
+				}
+			},
+		},
+		{
+			name:  "an edit of an expense removed here can not be applied",
+			here:  func(p *ProfileData) { p.Expenses = p.Expenses[1:] },
+			there: func(p *ProfileData) { p.Expenses[0].Amount = 950 },
+			err:   errors.ErrNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			base := oldProfile()
+			AssignIDs(&base)
+			here, there := base, base
+			for _, p := range []*ProfileData{&here, &there} {
+				p.Expenses = append([]ExpensesStuct(nil), base.Expenses...)
+			}
+			test.here(&here)
+			test.there(&there)
+			NewIDs(&here)
+			NewIDs(&there)
+			operations, err := Diff(base, there)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = Apply(&here, operations)
+			if !goerrors.Is(err, test.err) {
+				t.Fatalf("applying %+v: got %v, want %v", operations, err, test.err)
+			}
+			if test.check != nil {
+				test.check(t, here)
+			}
+		})
+	}
+}
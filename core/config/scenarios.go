@@ -0,0 +1,113 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ScenariosFolder is the folder inside the profiles folder where the what-if
+// scenarios of every profile are stored. Each profile has its own subfolder,
+// so scenarios never show up as profiles and never overwrite the real profile file.
+const ScenariosFolder string = "scenarios"
+
+// scenariosPath returns the folder containing the scenarios of the given profile.
+func scenariosPath(profileName string) string {
+	return fmt.Sprintf("%s/%s/%s", ProfilesFolder, ScenariosFolder, strings.ToLower(profileName))
+}
+
+// scenarioPath returns the path of the file containing the given scenario of the given profile.
+func scenarioPath(profileName, scenarioName string) string {
+	return fmt.Sprintf("%s/%s.json", scenariosPath(profileName), strings.ToLower(scenarioName))
+}
+
+// CreateScenario clones the profile with the given name into a new scenario.
+// The scenario starts as an exact copy of the profile's ProfileData and can be
+// changed with UpdateScenario without ever touching the original profile file.
+// If a scenario with the same name already exists, this function returns an error.
+func CreateScenario(profileName, scenarioName string) error {
+	profileData, err := ReadProfile(profileName)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(scenariosPath(profileName), 0755)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(scenarioPath(profileName, scenarioName)); err == nil {
+		return os.ErrExist
+	}
+	// the scenario is a new file, not a version of the profile file
+	profileData.Version = ""
+	return UpdateScenario(profileName, scenarioName, &profileData)
+}
+
+// GetScenarios returns a list of all scenarios of the given profile.
+// Each scenario is represented by the name of its JSON file, without the ".json" extension.
+func GetScenarios(profileName string) []string {
+	var scenarios []string
+	files, err := os.ReadDir(scenariosPath(profileName))
+	if err != nil {
+		return scenarios
+	}
+	for _, file := range files {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), ".json") {
+			scenarios = append(scenarios, strings.TrimSuffix(file.Name(), ".json"))
+		}
+	}
+	return scenarios
+}
+
+// ReadScenario reads the given scenario of the given profile.
+// It returns a ProfileData struct containing the scenario's configuration and expenses,
+// and an error if there was an error reading the file or unmarshaling the JSON.
+func ReadScenario(profileName, scenarioName string) (ProfileData, error) {
+	scenarioData, version, err := readFile(scenarioPath(profileName, scenarioName))
+	if err != nil {
+		return ProfileData{}, err
+	}
+	var configData ProfileData
+	err = json.Unmarshal(scenarioData, &configData)
+	if err != nil {
+		return ProfileData{}, err
+	}
+	AssignIDs(&configData)
+	configData.Version = version
+	return configData, nil
+}
+
+// UpdateScenario marshals the given ProfileData to JSON and writes it to the
+// file of the given scenario, overwriting it if it already exists, unless it changed since
+// data.Version was read: it then returns errors.ErrProfileChanged, like UpdateProfile.
+// The profile the scenario belongs to is never modified.
+func UpdateScenario(profileName, scenarioName string, data *ProfileData) error {
+	NewIDs(data)
+	var old ProfileData
+	if OnChange != nil {
+		old, _ = ReadScenario(profileName, scenarioName)
+	}
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	err = writeFile(scenarioPath(profileName, scenarioName), jsonData, data.Version)
+	if err != nil {
+		return err
+	}
+	data.Version = hash(jsonData)
+	name := fmt.Sprintf("%s (scenario %s)", strings.ToLower(profileName), strings.ToLower(scenarioName))
+	return changed(describeUpdate(name, old, *data), scenarioPath(profileName, scenarioName))
+}
+
+// DeleteScenario deletes the given scenario of the given profile.
+// If the scenario does not exist, it returns an error.
+func DeleteScenario(profileName, scenarioName string) error {
+	localMu.Lock()
+	err := os.Remove(scenarioPath(profileName, scenarioName))
+	localMu.Unlock()
+	if err != nil {
+		return err
+	}
+	return changed(fmt.Sprintf("%s: scenario %s deleted", strings.ToLower(profileName), strings.ToLower(scenarioName)), scenarioPath(profileName, scenarioName))
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"wallkeiro/core/errors"
+)
+
+// Backend stores the profile files, which hold the JSON of a ProfileData each. Profiles are
+// named by their lower case name. Read returns an error wrapping fs.ErrNotExist for profiles
+// that do not exist. Scenarios and bank mappings are always kept in the profiles folder.
+//
+// Read returns the version of the profile next to its file, a token telling the versions of
+// the file apart. Write is given the version the data was edited from, so it can tell whether
+// the profile changed elsewhere since: a backend shared between computers then merges both
+// instead of overwriting the other change, while the profiles folder refuses the write with
+// errors.ErrProfileChanged. A shared backend refuses it as well if it no longer keeps the
+// version edited, which the merge needs. An empty version stands for a profile not read before, like a new
+// one, which the profiles folder refuses with errors.ErrAlreadyExists if the profile exists.
+// Write returns the data written, which differs from the data given if it was merged, and
+// its version.
+type Backend interface {
+	List() ([]string, error)
+	Read(name string) ([]byte, string, error)
+	Write(name string, data []byte, version string) ([]byte, string, error)
+	Remove(name string) error
+	Rename(oldName, newName string) error
+}
+
+// Storage is the backend the profiles are read from and written to. It is the profiles
+// folder unless another backend is set up, like the webdav package does.
+var Storage Backend = LocalBackend{}
+
+// LocalBackend keeps every profile in a JSON file in the profiles folder.
+type LocalBackend struct{}
+
+// localMu makes the profile and scenario files be read and written one at a time, by the menus, the
+// servers and syncing alike, so a write is compared with the version it was edited from
+// while no other write can happen in between.
+var localMu sync.Mutex
+
+// profilePath returns the path of the file of the profile in the profiles folder.
+func profilePath(name string) string {
+	return fmt.Sprintf("%s/%s.json", ProfilesFolder, strings.ToLower(name))
+}
+
+// List returns the names of the JSON files in the profiles folder, without the ".json" extension.
+func (LocalBackend) List() ([]string, error) {
+	var profiles []string
+	files, err := os.ReadDir(ProfilesFolder)
+	if err != nil {
+		return profiles, err
+	}
+	for _, file := range files {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), ".json") {
+			profiles = append(profiles, strings.TrimSuffix(file.Name(), ".json"))
+		}
+	}
+	return profiles, nil
+}
+
+// Read reads the file of the profile. Its version is the hash of its contents.
+func (LocalBackend) Read(name string) ([]byte, string, error) {
+	return readFile(profilePath(name))
+}
+
+// Write writes the file of the profile, creating it if it does not exist. If the file
+// changed since the version it was edited from, it returns errors.ErrProfileChanged instead,
+// and errors.ErrAlreadyExists if it exists although no version was given.
+func (LocalBackend) Write(name string, data []byte, version string) ([]byte, string, error) {
+	if err := writeFile(profilePath(name), data, version); err != nil {
+		return nil, "", err
+	}
+	return data, hash(data), nil
+}
+
+// readFile reads a profile or scenario file in the profiles folder and returns its version.
+func readFile(path string) ([]byte, string, error) {
+	localMu.Lock()
+	defer localMu.Unlock()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, hash(data), nil
+}
+
+// writeFile writes a profile or scenario file in the profiles folder as edited from the
+// given version, see LocalBackend.Write. The file is written under another name first and
+// then renamed, so it is never read half written.
+func writeFile(path string, data []byte, version string) error {
+	localMu.Lock()
+	defer localMu.Unlock()
+	current, err := os.ReadFile(path)
+	switch {
+	case err != nil:
+	case version == "":
+		return errors.ErrAlreadyExists
+	case hash(current) != version:
+		return errors.ErrProfileChanged
+	}
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// hash returns the version of a profile or scenario file in the profiles folder.
+func hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Remove removes the file of the profile.
+func (LocalBackend) Remove(name string) error {
+	localMu.Lock()
+	defer localMu.Unlock()
+	return os.Remove(profilePath(name))
+}
+
+// Rename renames the file of the profile.
+func (LocalBackend) Rename(oldName, newName string) error {
+	localMu.Lock()
+	defer localMu.Unlock()
+	return os.Rename(profilePath(oldName), profilePath(newName))
+}
@@ -1,13 +1,16 @@
 package core
 
 import (
+	"wallkeiro/core/auth"
 	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
 	"wallkeiro/core/expenses"
+	"wallkeiro/core/tui"
 	
-	"wallkeiro/core/errors"
 	"github.com/manifoldco/promptui"
 
 	"fmt"
+	"time"
 )
 
 const CreateNewProfile string = "create new profile"
@@ -35,18 +38,22 @@ func Start() error {
 			return err
 		}
 		err = config.CreateNewProfile(profileName)
-		if err != nil {
+		if err == errors.ErrAlreadyExists {
+			// the existing profile is opened instead of being replaced by an empty one
+			fmt.Printf("Profile %s already exists.\n", profileName)
+		} else if err != nil {
 			return err
+		} else {
+			fmt.Printf("Profile %s created successfully.\n", profileName)
 		}
 		selectedProfile = profileName
-		fmt.Printf("Profile %s created successfully.\n", profileName)
 	} else {
 		selectedProfile = profileSelector
 	}
 	fmt.Printf("Profile %s selected.\n", selectedProfile)
 	prompt = promptui.Select{
 		Label: "Select Action",
-		Items: []string{"Calculate Savings", "Edit Saving Level", "Edit Salary", "Show Expenses", "Add Expense", "Edit Expenses", "Edit Profile Name", "Delete Profile"},
+		Items: []string{"Open Dashboard", "Calculate Savings", "Upcoming Payments", "Cash-Flow Calendar", "Edit Saving Level", "Edit Salary", "Edit Payday", "Show Expenses", "Add Expense", "Edit Expenses", "Manage Debts", "Savings Goals", "Emergency Fund", "Inflation Planning", "What-If Scenarios", "Import Statement", "Detect Recurring Expenses", "Categorization Rules", "Monthly Report", "Export", "Sync With Server", "Show History", "Edit Profile Name", "Delete Profile"},
 	}
 	ActionMenu:
 	_, actionSelector, err := prompt.Run()
@@ -54,91 +61,84 @@ func Start() error {
 		return err
 	}
 	switch actionSelector {
-	case "Calculate Savings":
-		profileData, err := config.ReadProfile(selectedProfile)
+	case "Open Dashboard":
+		err = tui.Run(selectedProfile)
 		if err != nil {
 			return err
 		}
-		expenses.Calculate(profileData)
-	case "Edit Salary":
-		// fixed or hourtly wage
-		prompt := promptui.Select{
-			Label: "Select Salary Type",
-			Items: []config.SalaryType{config.Fixed, config.Hourly},
-		}
-		_, salaryType, err := prompt.Run()
+		// the dashboard has its own way out, so go straight back to the menu
+		goto ActionMenu
+	case "Calculate Savings":
+		profileData, err := config.ReadProfile(selectedProfile)
 		if err != nil {
 			return err
 		}
-		var salaryValuePrompt promptui.Prompt
-		if salaryType == config.Fixed.String() {
-			salaryValuePrompt = promptui.Prompt{
-				Label: "Enter Fixed Salary Amount",
+		expenses.Calculate(profileData)
+		if hasInflation(profileData) {
+			prompt := promptui.Select{
+				Label: "Show next year's expected bills?",
+				Items: []string{"Yes", "No"},
 			}
-		} else {
-			salaryValuePrompt = promptui.Prompt{
-				Label: "Enter Hourly Wage Amount",
+			_, result, err := prompt.Run()
+			if err != nil {
+				return err
+			}
+			if result == "Yes" {
+				expenses.ShowProjection(profileData)
 			}
 		}
-		salaryValueStr, err := salaryValuePrompt.Run()
+	case "Edit Salary":
+		// fixed or hourtly wage
+		salaryValue, salaryType, err := promptSalary()
 		if err != nil {
 			return err
 		}
-		var salaryValue float64
-		_, err = fmt.Sscanf(salaryValueStr, "%f", &salaryValue)
+		err = config.SetSalary(selectedProfile, salaryValue, salaryType)
 		if err != nil {
 			return err
 		}
-		err = config.SetSalary(selectedProfile, salaryValue, config.SalaryType(salaryType))
 	case "Edit Saving Level":
-		prompt := promptui.Prompt{
-			Label: "Enter New Saving Level (1-4)",
-			Default: "1",
-			Validate: func(input string) error {
-				var level int
-				_, err := fmt.Sscanf(input, "%d", &level)
-				if err != nil || (level < 1 || level > 4) {
-					return errors.ErrLevelTooHigh
-				}
-				return nil
-			},
-		}
-		levelStr, err := prompt.Run()
+		level, err := promptSavingLevel()
 		if err != nil {
 			return err
 		}
-		var level int
-		_, err = fmt.Sscanf(levelStr, "%d", &level)
+		err = config.SetSavingLevel(selectedProfile, level)
 		if err != nil {
 			return err
 		}
-		err = config.SetSavingLevel(selectedProfile, level)
+	case "Upcoming Payments":
+		days, err := promptAmount("Show payments due in the next N days", "30")
 		if err != nil {
 			return err
 		}
-	case "Show Expenses":
 		profileData, err := config.ReadProfile(selectedProfile)
 		if err != nil {
 			return err
 		}
-		expenses.Show(profileData)
-	case "Add Expense":
-		promptName := promptui.Prompt{
-			Label: "Enter Expense Name",
+		expenses.ShowUpcoming(profileData, time.Now(), int(days))
+	case "Cash-Flow Calendar":
+		profileData, err := config.ReadProfile(selectedProfile)
+		if err != nil {
+			return err
 		}
-		expenseName, err := promptName.Run()
+		expenses.ShowCashFlow(profileData, time.Now())
+	case "Edit Payday":
+		payday, err := promptDay("Enter Payday (day of the month)", "1")
 		if err != nil {
 			return err
 		}
-		promptAmount := promptui.Prompt{
-			Label: "Enter Expense Amount",
+		err = config.SetPayday(selectedProfile, payday)
+		if err != nil {
+			return err
 		}
-		expenseAmountStr, err := promptAmount.Run()
+	case "Show Expenses":
+		profileData, err := config.ReadProfile(selectedProfile)
 		if err != nil {
 			return err
 		}
-		var expenseAmount float64
-		_, err = fmt.Sscanf(expenseAmountStr, "%f", &expenseAmount)
+		expenses.Show(profileData)
+	case "Add Expense":
+		expenseName, expenseAmount, err := promptExpense()
 		if err != nil {
 			return err
 		}
@@ -167,6 +167,69 @@ func Start() error {
 		} else {
 			fmt.Println("No expenses to edit.")
 		}
+	case "Manage Debts":
+		err = DebtMenu(selectedProfile)
+		if err != nil {
+			return err
+		}
+	case "Savings Goals":
+		err = GoalMenu(selectedProfile)
+		if err != nil {
+			return err
+		}
+	case "Emergency Fund":
+		err = EmergencyFundMenu(selectedProfile)
+		if err != nil {
+			return err
+		}
+	case "Inflation Planning":
+		err = InflationMenu(selectedProfile)
+		if err != nil {
+			return err
+		}
+	case "What-If Scenarios":
+		err = ScenarioMenu(selectedProfile)
+		if err != nil {
+			return err
+		}
+	case "Import Statement":
+		err = ImportMenu(selectedProfile)
+		if err != nil {
+			return err
+		}
+	case "Detect Recurring Expenses":
+		err = RecurringMenu(selectedProfile)
+		if err != nil {
+			return err
+		}
+	case "Categorization Rules":
+		err = RulesMenu(selectedProfile)
+		if err != nil {
+			return err
+		}
+	case "Monthly Report":
+		err = ReportMenu(selectedProfile)
+		if err != nil {
+			return err
+		}
+	case "Export":
+		err = ExportMenu(selectedProfile)
+		if err != nil {
+			return err
+		}
+	case "Sync With Server":
+		err = SyncMenu(selectedProfile)
+		if err != nil {
+			return err
+		}
+	case "Show History":
+		err = ShowHistory(selectedProfile)
+		if err == errors.ErrHistoryDisabled {
+			// the error tells how to start the history, so the menu goes on
+			fmt.Println(err.Error())
+		} else if err != nil {
+			return err
+		}
 	case "Edit Profile Name":
 		prompt := promptui.Prompt{
 			Label: "Enter New Profile Name",
@@ -176,9 +239,26 @@ func Start() error {
 			return err
 		}
 		config.RenameProfile(selectedProfile, newProfileName)
+		// the owner and the shares on the server move along with the profile
+		if auth.Enabled() {
+			err = auth.Change(func(accounts *auth.Accounts) error {
+				accounts.RenameProfile(selectedProfile, newProfileName)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
 		selectedProfile = newProfileName
 	case "Delete Profile":
-		return config.DeleteProfile(selectedProfile)
+		err = config.DeleteProfile(selectedProfile)
+		if err != nil || !auth.Enabled() {
+			return err
+		}
+		return auth.Change(func(accounts *auth.Accounts) error {
+			accounts.DeleteProfile(selectedProfile)
+			return nil
+		})
 	}
 	if goBack, err := GoBackPrompt(); err != nil {
 		return err
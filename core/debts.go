@@ -0,0 +1,134 @@
+package core
+
+import (
+	"wallkeiro/core/config"
+	"wallkeiro/core/debts"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/expenses"
+
+	"github.com/manifoldco/promptui"
+
+	"fmt"
+	"time"
+)
+
+// DebtMenu lets the user manage the debts of the given profile and plan their payoff.
+// Debts can be added, have their balance updated or be deleted. The payoff plan uses
+// the suggested withdrawal of Calculate as the extra money put towards the debts every month,
+// and shows the avalanche and snowball strategies next to each other.
+func DebtMenu(selectedProfile string) error {
+	prompt := promptui.Select{
+		Label: "Select Debt Action",
+		Items: []string{"Plan Payoff", "Show Debts", "Add Debt", "Update Debt Balance", "Delete Debt"},
+	}
+	_, actionSelector, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+	profileData, err := config.ReadProfile(selectedProfile)
+	if err != nil {
+		return err
+	}
+	switch actionSelector {
+	case "Plan Payoff":
+		surplus := expenses.Compute(profileData).WithdrawnAmount
+		var schedules []debts.Schedule
+		for _, strategy := range []debts.Strategy{debts.Avalanche, debts.Snowball} {
+			schedule, err := debts.Plan(profileData.Debts, surplus, strategy, time.Now())
+			if err == errors.ErrNoDebts || err == errors.ErrDebtNeverPaidOff {
+				// nothing to plan is an answer, not a failure, so the menu goes on
+				fmt.Println(err.Error())
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			debts.Show(schedule)
+			schedules = append(schedules, schedule)
+		}
+		prompt := promptui.Select{
+			Label: "Show Monthly Schedule",
+			Items: []string{"No", debts.Avalanche.String(), debts.Snowball.String()},
+		}
+		_, scheduleSelector, err := prompt.Run()
+		if err != nil {
+			return err
+		}
+		for _, schedule := range schedules {
+			if schedule.Strategy.String() == scheduleSelector {
+				debts.ShowSchedule(schedule)
+			}
+		}
+		return nil
+	case "Show Debts":
+		if len(profileData.Debts) == 0 {
+			fmt.Println("No debts added.")
+		}
+		for _, debt := range profileData.Debts {
+			fmt.Printf("%s: balance %.2f€, APR %.2f%%, minimum payment %.2f€\n", debt.Name, debt.Balance, debt.APR, debt.MinimumPayment)
+		}
+		return nil
+	case "Add Debt":
+		debt, err := promptDebt()
+		if err != nil {
+			return err
+		}
+		profileData.Debts = append(profileData.Debts, debt)
+		fmt.Printf("Debt %s of balance %.2f€ added successfully.\n", debt.Name, debt.Balance)
+	case "Update Debt Balance", "Delete Debt":
+		if len(profileData.Debts) == 0 {
+			fmt.Println("No debts to edit.")
+			return nil
+		}
+		var debtNames []string
+		for _, debt := range profileData.Debts {
+			debtNames = append(debtNames, debt.Name)
+		}
+		prompt := promptui.Select{
+			Label: "Select Debt",
+			Items: debtNames,
+		}
+		debtIndex, _, err := prompt.Run()
+		if err != nil {
+			return err
+		}
+		if actionSelector == "Delete Debt" {
+			profileData.Debts = append(profileData.Debts[:debtIndex], profileData.Debts[debtIndex+1:]...)
+			break
+		}
+		balance, err := promptAmount("Enter New Balance", fmt.Sprintf("%.2f", profileData.Debts[debtIndex].Balance))
+		if err != nil {
+			return err
+		}
+		profileData.Debts[debtIndex].Balance = balance
+	}
+	return config.UpdateProfile(selectedProfile, &profileData)
+}
+
+// promptDebt asks the user for the name, balance, APR and minimum payment of a new debt.
+// It returns the entered debt, and an error if there was an error running the prompts
+// or parsing the amounts.
+func promptDebt() (config.DebtStruct, error) {
+	var debt config.DebtStruct
+	promptName := promptui.Prompt{
+		Label: "Enter Debt Name",
+	}
+	name, err := promptName.Run()
+	if err != nil {
+		return debt, err
+	}
+	debt.Name = name
+	debt.Balance, err = promptAmount("Enter Debt Balance", "")
+	if err != nil {
+		return debt, err
+	}
+	debt.APR, err = promptAmount("Enter Yearly Interest Rate (APR %)", "")
+	if err != nil {
+		return debt, err
+	}
+	debt.MinimumPayment, err = promptAmount("Enter Minimum Monthly Payment", "")
+	if err != nil {
+		return debt, err
+	}
+	return debt, nil
+}
@@ -0,0 +1,217 @@
+package debts
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/table"
+)
+
+// MaxMonths is the longest payoff period the planner simulates. If the debts
+// are not paid off after this many months, Plan returns ErrDebtNeverPaidOff.
+const MaxMonths int = 600
+
+type Strategy string
+
+func (s Strategy) String() string {
+	return string(s)
+}
+
+const (
+	// Avalanche puts the extra money towards the debt with the highest APR first.
+	Avalanche Strategy = "avalanche"
+	// Snowball puts the extra money towards the debt with the smallest balance first.
+	Snowball Strategy = "snowball"
+)
+
+// DebtResult holds the outcome of a payoff plan for a single debt.
+type DebtResult struct {
+	Name         string
+	Months       int
+	PayoffDate   time.Time
+	InterestPaid float64
+	TotalPaid    float64
+}
+
+// MonthPlan holds the payments made and the balances left for every debt in a single month.
+// Payments and Balances are in the same order as the debts passed to Plan.
+type MonthPlan struct {
+	Date     time.Time
+	Payments []float64
+	Balances []float64
+}
+
+// Schedule is the result of Plan. It contains the month by month payments,
+// the payoff date and interest paid for every debt, and the totals of the plan.
+type Schedule struct {
+	Strategy      Strategy
+	Surplus       float64
+	Months        []MonthPlan
+	Debts         []DebtResult
+	TotalInterest float64
+	TotalPaid     float64
+	PayoffDate    time.Time
+}
+
+// Plan simulates paying off the given debts month by month, starting with the month after start.
+// Every month the interest is added to each balance, the minimum payment of every debt is paid,
+// and the surplus is put towards a single target debt chosen by the strategy.
+// When a debt is paid off, its minimum payment is rolled into the surplus for the remaining debts.
+// If there are no debts with a balance, it returns ErrNoDebts, and if the debts can not be paid off
+// within MaxMonths, it returns ErrDebtNeverPaidOff.
+func Plan(debts []config.DebtStruct, surplus float64, strategy Strategy, start time.Time) (Schedule, error) {
+	schedule := Schedule{Strategy: strategy, Surplus: surplus}
+	balances := make([]float64, len(debts))
+	remaining := 0
+	for i, debt := range debts {
+		balances[i] = debt.Balance
+		if debt.Balance > 0 {
+			remaining++
+		}
+		schedule.Debts = append(schedule.Debts, DebtResult{Name: debt.Name})
+	}
+	if remaining == 0 {
+		return schedule, errors.ErrNoDebts
+	}
+
+	for month := 1; remaining > 0; month++ {
+		if month > MaxMonths {
+			return schedule, errors.ErrDebtNeverPaidOff
+		}
+		date := start.AddDate(0, month, 0)
+		payments := make([]float64, len(debts))
+		available := surplus
+
+		// interest and minimum payments
+		for i, debt := range debts {
+			if balances[i] <= 0 {
+				// the minimum payment of a debt paid off by this plan is rolled over
+				if debt.Balance > 0 {
+					available += debt.MinimumPayment
+				}
+				continue
+			}
+			interest := round(balances[i] * debt.APR / 100 / 12)
+			balances[i] += interest
+			schedule.Debts[i].InterestPaid += interest
+			payments[i] = math.Min(debt.MinimumPayment, balances[i])
+			available += debt.MinimumPayment - payments[i]
+			balances[i] = round(balances[i] - payments[i])
+		}
+
+		// extra payments, following the strategy order
+		for _, i := range order(debts, balances, strategy) {
+			if available <= 0 {
+				break
+			}
+			extra := math.Min(available, balances[i])
+			payments[i] += extra
+			balances[i] = round(balances[i] - extra)
+			available -= extra
+		}
+
+		for i := range debts {
+			schedule.Debts[i].TotalPaid += payments[i]
+			if payments[i] > 0 && balances[i] <= 0 {
+				schedule.Debts[i].Months = month
+				schedule.Debts[i].PayoffDate = date
+				remaining--
+			}
+		}
+		schedule.Months = append(schedule.Months, MonthPlan{
+			Date:     date,
+			Payments: payments,
+			Balances: append([]float64{}, balances...),
+		})
+		schedule.PayoffDate = date
+	}
+
+	for _, result := range schedule.Debts {
+		schedule.TotalInterest += result.InterestPaid
+		schedule.TotalPaid += result.TotalPaid
+	}
+	return schedule, nil
+}
+
+// order returns the indexes of the debts that still have a balance,
+// sorted in the order the strategy pays them off.
+func order(debts []config.DebtStruct, balances []float64, strategy Strategy) []int {
+	var indexes []int
+	for i := range debts {
+		if balances[i] > 0 {
+			indexes = append(indexes, i)
+		}
+	}
+	sort.SliceStable(indexes, func(a, b int) bool {
+		x, y := indexes[a], indexes[b]
+		if strategy == Snowball {
+			if balances[x] != balances[y] {
+				return balances[x] < balances[y]
+			}
+			return debts[x].APR > debts[y].APR
+		}
+		if debts[x].APR != debts[y].APR {
+			return debts[x].APR > debts[y].APR
+		}
+		return balances[x] < balances[y]
+	})
+	return indexes
+}
+
+// round rounds the given amount to whole cents.
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// Show prints a table with the payoff date, number of months and interest paid
+// for every debt in the given Schedule, followed by the totals of the plan.
+func Show(schedule Schedule) {
+	columns := []string{"Debt", "Payoff Date", "Months", "Interest Paid", "Total Paid"}
+	var rows [][]string
+	for _, result := range schedule.Debts {
+		payoff := "-"
+		if !result.PayoffDate.IsZero() {
+			payoff = result.PayoffDate.Format("2006-01")
+		}
+		rows = append(rows, []string{
+			result.Name,
+			payoff,
+			fmt.Sprintf("%d", result.Months),
+			fmt.Sprintf("%.2f€", result.InterestPaid),
+			fmt.Sprintf("%.2f€", result.TotalPaid),
+		})
+	}
+	rows = append(rows, []string{
+		"Total",
+		schedule.PayoffDate.Format("2006-01"),
+		fmt.Sprintf("%d", len(schedule.Months)),
+		fmt.Sprintf("%.2f€", schedule.TotalInterest),
+		fmt.Sprintf("%.2f€", schedule.TotalPaid),
+	})
+	fmt.Printf("\nStrategy %s with %.2f€ extra per month:\n", schedule.Strategy, schedule.Surplus)
+	table.Print(columns, rows)
+}
+
+// ShowSchedule prints the month by month payments of the given Schedule,
+// with one column for every debt and the remaining total balance.
+func ShowSchedule(schedule Schedule) {
+	columns := []string{"Month"}
+	for _, result := range schedule.Debts {
+		columns = append(columns, result.Name)
+	}
+	columns = append(columns, "Balance Left")
+	var rows [][]string
+	for _, month := range schedule.Months {
+		row := []string{month.Date.Format("2006-01")}
+		left := 0.0
+		for i := range month.Payments {
+			row = append(row, fmt.Sprintf("%.2f€", month.Payments[i]))
+			left += month.Balances[i]
+		}
+		rows = append(rows, append(row, fmt.Sprintf("%.2f€", left)))
+	}
+	table.Print(columns, rows)
+}
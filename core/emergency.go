@@ -0,0 +1,54 @@
+package core
+
+import (
+	"wallkeiro/core/config"
+	"wallkeiro/core/expenses"
+
+	"github.com/manifoldco/promptui"
+
+	"fmt"
+)
+
+// EmergencyFundMenu shows how many months of essential expenses the savings of the
+// given profile cover, and lets the user update the current savings and the target
+// number of months. Expenses are flagged as essential or discretionary in "Edit Expenses".
+func EmergencyFundMenu(selectedProfile string) error {
+	prompt := promptui.Select{
+		Label: "Select Emergency Fund Action",
+		Items: []string{"Show Coverage", "Set Current Savings", "Set Target Months"},
+	}
+	_, actionSelector, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+	profileData, err := config.ReadProfile(selectedProfile)
+	if err != nil {
+		return err
+	}
+	savings := profileData.Config.Savings
+	months := profileData.Config.EmergencyMonths
+	switch actionSelector {
+	case "Show Coverage":
+		expenses.ShowEmergencyFund(profileData)
+		return nil
+	case "Set Current Savings":
+		savings, err = promptAmount("Enter Current Savings", fmt.Sprintf("%.2f", savings))
+		if err != nil {
+			return err
+		}
+	case "Set Target Months":
+		target, err := promptAmount("Enter Target Months of Essential Expenses", fmt.Sprintf("%d", months))
+		if err != nil {
+			return err
+		}
+		months = int(target)
+	}
+	err = config.SetSavings(selectedProfile, savings, months)
+	if err != nil {
+		return err
+	}
+	profileData.Config.Savings = savings
+	profileData.Config.EmergencyMonths = months
+	expenses.ShowEmergencyFund(profileData)
+	return nil
+}
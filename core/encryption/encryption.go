@@ -7,6 +7,7 @@ import (
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/pem"
+	"wallkeiro/core/errors"
 
 	"golang.org/x/crypto/ssh"
 )
@@ -50,6 +51,9 @@ func decrypt(data, priv string) (string, error) {
 		return "", err
 	}
 	block, _ := pem.Decode([]byte(priv))
+	if block == nil {
+		return "", errors.ErrWrongEncryptionKey
+	}
 	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
 	if err != nil {
 		return "", err
@@ -0,0 +1,137 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	goerrors "errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+)
+
+// Folder is the folder inside the profiles folder where the keys encrypting the profiles are kept.
+const Folder string = "keys"
+
+// Keys are the keys encrypting the profiles: the public key in the format of an
+// authorized_keys line of ssh, and the private key in PEM.
+type Keys struct {
+	Public  string
+	Private string
+}
+
+// KeyPaths returns the paths of the files of the public and the private key.
+func KeyPaths() (string, string) {
+	folder := filepath.Join(config.ProfilesFolder, Folder)
+	return filepath.Join(folder, "profiles.pub"), filepath.Join(folder, "profiles.key")
+}
+
+// ReadKeys returns the keys, or errors.ErrNoEncryptionKey if they were not created or copied
+// to this computer yet.
+func ReadKeys() (Keys, error) {
+	publicPath, privatePath := KeyPaths()
+	public, err := os.ReadFile(publicPath)
+	if err == nil {
+		var private []byte
+		private, err = os.ReadFile(privatePath)
+		if err == nil {
+			return Keys{Public: string(public), Private: string(private)}, nil
+		}
+	}
+	if goerrors.Is(err, fs.ErrNotExist) {
+		return Keys{}, errors.ErrNoEncryptionKey
+	}
+	return Keys{}, err
+}
+
+// CreateKeys creates new keys and saves them. The private key is only readable by the user
+// running wallkeiro.
+func CreateKeys() (Keys, error) {
+	public, private, err := generateKey()
+	if err != nil {
+		return Keys{}, err
+	}
+	publicPath, privatePath := KeyPaths()
+	if err := os.MkdirAll(filepath.Dir(publicPath), 0700); err != nil {
+		return Keys{}, err
+	}
+	if err := os.WriteFile(privatePath, []byte(private), 0600); err != nil {
+		return Keys{}, err
+	}
+	if err := os.WriteFile(publicPath, []byte(public), 0644); err != nil {
+		return Keys{}, err
+	}
+	return Keys{Public: public, Private: private}, nil
+}
+
+// sealed is an encrypted profile. The profile is encrypted with AES-256-GCM using a random
+// key, which is encrypted with the public key, since RSA can only encrypt a few hundred bytes.
+type sealed struct {
+	Version int    `json:"version"`
+	Key     string `json:"key"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// Seal encrypts the data, usually the JSON of a profile, so only the private key of the
+// given public key can decrypt it.
+func Seal(data []byte, publicKey string) ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	encryptedKey, err := encrypt(base64.StdEncoding.EncodeToString(key), publicKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return json.Marshal(sealed{Version: 1, Key: encryptedKey, Nonce: nonce, Data: aead.Seal(nil, nonce, data, nil)})
+}
+
+// Open decrypts data encrypted by Seal with the private key.
+func Open(data []byte, privateKey string) ([]byte, error) {
+	var envelope sealed
+	if err := json.Unmarshal(data, &envelope); err != nil || envelope.Version != 1 {
+		return nil, errors.ErrNotEncrypted
+	}
+	encodedKey, err := decrypt(envelope.Key, privateKey)
+	if err != nil {
+		return nil, errors.ErrWrongEncryptionKey
+	}
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(envelope.Nonce) != aead.NonceSize() {
+		return nil, errors.ErrNotEncrypted
+	}
+	plain, err := aead.Open(nil, envelope.Nonce, envelope.Data, nil)
+	if err != nil {
+		return nil, errors.ErrWrongEncryptionKey
+	}
+	return plain, nil
+}
+
+// newAEAD returns AES-GCM with the given key.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
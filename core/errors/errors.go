@@ -6,3 +6,37 @@ var ErrSalaryRequired = errors.New("Salary is required to calculate the expenses
 var ErrExpensesMoreThanSalary = errors.New("Nothing to save, expenses are more than salary")
 var ErrWithdrawnAmountTooLow = errors.New("Sorry, for now it seems that your salary is too small to make additional savings.")
 var ErrLevelTooHigh = errors.New("invalid input: please enter a number between 1 and 4")
+var ErrNoDebts = errors.New("There are no debts to plan a payoff for")
+var ErrDebtNeverPaidOff = errors.New("The monthly payments are too small to ever pay off the debts")
+var ErrInvalidDueDay = errors.New("invalid input: please enter a day between 1 and 31")
+var ErrInvalidDueMonth = errors.New("invalid input: please enter a month between 1 and 12")
+var ErrInvalidDate = errors.New("invalid input: please enter a date as YYYY-MM-DD")
+var ErrUnknownEncoding = errors.New("unknown text encoding")
+var ErrUnknownColumn = errors.New("column not found in the statement")
+var ErrInvalidAmount = errors.New("invalid amount")
+var ErrInvalidStatementDate = errors.New("invalid date in statement")
+var ErrInvalidStatementLine = errors.New("invalid statement line")
+var ErrInvalidRule = errors.New("invalid rule: the payee is not a valid regular expression")
+var ErrEmptyRule = errors.New("invalid rule: a rule needs at least one condition and a category or tag")
+var ErrInvalidMonth = errors.New("invalid input: please enter a month as YYYY-MM")
+var ErrNameRequired = errors.New("invalid input: a name is required")
+var ErrInvalidName = errors.New("invalid input: names can not contain slashes or start with a dot")
+var ErrNotFound = errors.New("not found")
+var ErrAlreadyExists = errors.New("already exists")
+var ErrInvalidRequest = errors.New("invalid request")
+var ErrUnauthorized = errors.New("unauthorized: log in with a user name and password or an API token")
+var ErrForbidden = errors.New("forbidden: the profile is not shared with you for this")
+var ErrPasswordTooShort = errors.New("invalid input: passwords need at least 8 characters")
+var ErrInvalidPermission = errors.New("invalid input: please choose read or edit")
+var ErrSyncConflict = errors.New("the profile was changed on this device and on the sync server in different ways")
+var ErrOutdated = errors.New("the profile was changed on the sync server since it was last synced")
+var ErrProfileChanged = errors.New("the profile was changed elsewhere since it was read, please try again")
+var ErrNoSyncServer = errors.New("no sync server set up yet")
+var ErrNotLocalhost = errors.New("requests must be addressed to localhost")
+var ErrGitMissing = errors.New("git is not installed, please install it to keep the history of the profiles")
+var ErrHistoryDisabled = errors.New("the profiles have no history yet, start it with \"wallkeiro history init\"")
+var ErrPullFirst = errors.New("the remote has changes this computer does not have yet, please pull them first")
+var ErrNoEncryptionKey = errors.New("no encryption key on this computer yet, create one or copy it from the computer that created it")
+var ErrWrongEncryptionKey = errors.New("the profile was encrypted with another key than the one on this computer")
+var ErrNotEncrypted = errors.New("the profile is not encrypted by wallkeiro")
+var ErrOtherStorage = errors.New("the profiles are already stored elsewhere, turn that off first")
@@ -0,0 +1,116 @@
+package expenses
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/table"
+)
+
+// CashFlowDay is a single day of the cash-flow calendar, with the payments
+// made on that day and the account balance at the end of the day.
+type CashFlowDay struct {
+	Date     time.Time `json:"date"`
+	Payments []Payment `json:"payments"`
+	Balance  float64   `json:"balance"`
+}
+
+// NextPayday returns the first payday of the profile after the given payday.
+func NextPayday(ProfileData config.ProfileData, payday time.Time) time.Time {
+	next := time.Date(payday.Year(), payday.Month()+1, 1, 0, 0, 0, 0, payday.Location())
+	return dayOf(next.Year(), next.Month(), ProfileData.Config.Payday, payday.Location())
+}
+
+// CashFlow returns a day by day calendar from the given payday up to the day before the next payday.
+// The balance starts at zero, the salary arrives on the payday and the given withdrawal is moved
+// to savings right after it. Bills are paid on their due dates. Expenses without a due date and
+// the minimum payments of debts are paid on the payday, so the calendar never shows more money
+// than there really is. Yearly expenses without a due date are paid with their monthly share.
+func CashFlow(ProfileData config.ProfileData, payday time.Time, withdrawal float64) []CashFlowDay {
+	payday = midnight(payday)
+	next := NextPayday(ProfileData, payday)
+
+	var onPayday []Payment
+	for _, expense := range ProfileData.Expenses {
+		if expense.DueDay == 0 {
+			onPayday = append(onPayday, Payment{Date: payday, Name: expense.Name, Amount: -expense.MonthlyAmount()})
+		}
+	}
+	for _, debt := range ProfileData.Debts {
+		if debt.Balance > 0 {
+			onPayday = append(onPayday, Payment{Date: payday, Name: debt.Name, Amount: -math.Min(debt.MinimumPayment, debt.Balance)})
+		}
+	}
+
+	// the salary always comes first, then the withdrawal and the payments without a due date
+	if withdrawal > 0 {
+		onPayday = append([]Payment{{Date: payday, Name: "Savings Withdrawal", Amount: -withdrawal}}, onPayday...)
+	}
+	payments := Payments(ProfileData, payday, next, 0)
+	payments = append(payments[:1], append(onPayday, payments[1:]...)...)
+
+	var days []CashFlowDay
+	balance := 0.0
+	for day := payday; day.Before(next); day = day.AddDate(0, 0, 1) {
+		cashFlowDay := CashFlowDay{Date: day}
+		for len(payments) > 0 && payments[0].Date.Equal(day) {
+			balance += payments[0].Amount
+			payments[0].Balance = balance
+			cashFlowDay.Payments = append(cashFlowDay.Payments, payments[0])
+			payments = payments[1:]
+		}
+		cashFlowDay.Balance = balance
+		days = append(days, cashFlowDay)
+	}
+	return days
+}
+
+// LowestPoint returns the day of the given cash-flow calendar with the lowest balance.
+// If several days share the lowest balance, the first one is returned.
+func LowestPoint(days []CashFlowDay) CashFlowDay {
+	var lowest CashFlowDay
+	for i, day := range days {
+		if i == 0 || day.Balance < lowest.Balance {
+			lowest = day
+		}
+	}
+	return lowest
+}
+
+// ShowCashFlow prints the cash-flow calendar of the pay period containing the given day.
+// The suggested withdrawal of Calculate is moved to savings on the payday, every day shows
+// its payments and the balance at the end of the day, and the lowest point is highlighted.
+func ShowCashFlow(ProfileData config.ProfileData, day time.Time) {
+	result := ComputeAt(ProfileData, day)
+	payday := LastPayday(ProfileData, day)
+	days := CashFlow(ProfileData, payday, result.WithdrawnAmount)
+	lowest := LowestPoint(days)
+
+	fmt.Printf("\nCash flow from %s to %s:\n", payday.Format("2006-01-02"), NextPayday(ProfileData, payday).AddDate(0, 0, -1).Format("2006-01-02"))
+	columns := []string{"Date", "Payments", "Balance", "Note"}
+	var rows [][]string
+	for _, cashFlowDay := range days {
+		var payments []string
+		for _, payment := range cashFlowDay.Payments {
+			payments = append(payments, fmt.Sprintf("%s %+.2f€", payment.Name, payment.Amount))
+		}
+		note := ""
+		if cashFlowDay.Date.Equal(lowest.Date) {
+			note = ">>> lowest point"
+		}
+		if cashFlowDay.Balance < result.DesiredFinalBalance {
+			note = strings.TrimSpace(note + " below desired balance")
+		}
+		rows = append(rows, []string{
+			cashFlowDay.Date.Format("Mon 2006-01-02"),
+			strings.Join(payments, ", "),
+			fmt.Sprintf("%.2f€", cashFlowDay.Balance),
+			note,
+		})
+	}
+	table.Print(columns, rows)
+	fmt.Printf("Suggested Withdrawn Amount: %.2f€\n", result.WithdrawnAmount)
+	fmt.Printf("Lowest Balance: %.2f€ on %s\n", lowest.Balance, lowest.Date.Format("2006-01-02"))
+}
@@ -0,0 +1,44 @@
+package expenses
+
+import (
+	"fmt"
+	"wallkeiro/core/config"
+	"wallkeiro/core/table"
+)
+
+// Compare calculates both the base profile and the given scenario and prints
+// the results side by side, together with the difference between them.
+// The scenarioName is used as the header of the scenario column.
+// Neither of the given ProfileData structs is modified or saved.
+func Compare(base config.ProfileData, scenario config.ProfileData, scenarioName string) {
+	baseResult := Compute(base)
+	scenarioResult := Compute(scenario)
+
+	rows := []struct {
+		label    string
+		base     float64
+		scenario float64
+		format   string
+	}{
+		{"Salary", baseResult.Salary, scenarioResult.Salary, "%.2f€"},
+		{"Saving Level", float64(base.Config.SavingLevel), float64(scenario.Config.SavingLevel), "%.0f"},
+		{"Expenses Count", float64(len(base.Expenses)), float64(len(scenario.Expenses)), "%.0f"},
+		{"Total Expenses", baseResult.TotalExpenses, scenarioResult.TotalExpenses, "%.2f€"},
+		{"Desired Final Balance", baseResult.DesiredFinalBalance, scenarioResult.DesiredFinalBalance, "%.2f€"},
+		{"Remaining Amount", baseResult.RemainingAmount, scenarioResult.RemainingAmount, "%.2f€"},
+		{"Suggested Withdrawal", baseResult.WithdrawnAmount, scenarioResult.WithdrawnAmount, "%.2f€"},
+	}
+
+	columns := []string{"", "Profile", scenarioName, "Difference"}
+	var cells [][]string
+	for _, row := range rows {
+		cells = append(cells, []string{
+			row.label,
+			fmt.Sprintf(row.format, row.base),
+			fmt.Sprintf(row.format, row.scenario),
+			fmt.Sprintf("%+"+row.format[1:], row.scenario-row.base),
+		})
+	}
+	fmt.Println()
+	table.Print(columns, cells)
+}
@@ -0,0 +1,93 @@
+package expenses
+
+import (
+	"fmt"
+	"math"
+	"wallkeiro/core/config"
+)
+
+// EmergencyFund holds the coverage of a profile's savings and the recommended
+// contribution to the emergency fund, as calculated by CalculateEmergencyFund.
+type EmergencyFund struct {
+	Savings           float64 `json:"savings"`
+	EssentialExpenses float64 `json:"essential_expenses"`
+	MonthsCovered     float64 `json:"months_covered"`
+	TargetMonths      int     `json:"target_months"`
+	Target            float64 `json:"target"`
+	Gap               float64 `json:"gap"`
+	Contribution      float64 `json:"contribution"`
+	MonthsToTarget    int     `json:"months_to_target"`
+}
+
+// EssentialExpenses returns the monthly amount of the expenses that are not
+// flagged as discretionary, together with the minimum payments of the debts
+// that are not paid off yet.
+func EssentialExpenses(ProfileData config.ProfileData) float64 {
+	total := 0.0
+	for _, expense := range ProfileData.Expenses {
+		if !expense.Discretionary {
+			total += expense.MonthlyAmount()
+		}
+	}
+	for _, debt := range ProfileData.Debts {
+		if debt.Balance > 0 {
+			total += math.Min(debt.MinimumPayment, debt.Balance)
+		}
+	}
+	return total
+}
+
+// CalculateEmergencyFund calculates how many months of essential expenses the
+// current savings of the profile would cover, and how far they are from the
+// target number of months. Until the target is reached, the recommended
+// contribution is the part of Calculate's suggested withdrawal that should go
+// to the emergency fund, which is the whole withdrawal or the missing amount,
+// whichever is smaller.
+func CalculateEmergencyFund(ProfileData config.ProfileData) EmergencyFund {
+	targetMonths := ProfileData.Config.EmergencyMonths
+	if targetMonths <= 0 {
+		targetMonths = config.DefaultEmergencyFundMonths
+	}
+	fund := EmergencyFund{
+		Savings:           ProfileData.Config.Savings,
+		EssentialExpenses: EssentialExpenses(ProfileData),
+		TargetMonths:      targetMonths,
+	}
+	fund.Target = fund.EssentialExpenses * float64(targetMonths)
+	if fund.EssentialExpenses > 0 {
+		fund.MonthsCovered = fund.Savings / fund.EssentialExpenses
+	} else {
+		fund.MonthsCovered = math.Inf(1)
+	}
+	fund.Gap = math.Max(fund.Target-fund.Savings, 0)
+	withdrawn := Compute(ProfileData).WithdrawnAmount
+	fund.Contribution = math.Min(fund.Gap, withdrawn)
+	if fund.Gap > 0 && withdrawn > 0 {
+		fund.MonthsToTarget = int(math.Ceil(fund.Gap / withdrawn))
+	}
+	return fund
+}
+
+// ShowEmergencyFund calculates the emergency fund of the given ProfileData and prints
+// the savings, the monthly essential expenses, the months covered, the target, and
+// how much of the suggested withdrawal should go to the emergency fund.
+func ShowEmergencyFund(ProfileData config.ProfileData) {
+	fund := CalculateEmergencyFund(ProfileData)
+	fmt.Printf("Current Savings: %.2f€\n", fund.Savings)
+	fmt.Printf("Essential Monthly Expenses: %.2f€\n", fund.EssentialExpenses)
+	if math.IsInf(fund.MonthsCovered, 1) {
+		fmt.Println("Months Covered: no essential expenses")
+	} else {
+		fmt.Printf("Months Covered: %.1f\n", fund.MonthsCovered)
+	}
+	fmt.Printf("Target: %d months (%.2f€)\n", fund.TargetMonths, fund.Target)
+	if fund.Gap == 0 {
+		fmt.Println("The emergency fund target is reached, the whole suggested withdrawal can go to other savings.")
+		return
+	}
+	fmt.Printf("Missing Amount: %.2f€\n", fund.Gap)
+	fmt.Printf("Suggested Emergency Fund Contribution: %.2f€\n", fund.Contribution)
+	if fund.MonthsToTarget > 0 {
+		fmt.Printf("Months Until Target Is Reached: %d\n", fund.MonthsToTarget)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"time"
 	"wallkeiro/core/config"
 	"wallkeiro/core/errors"
 
@@ -28,7 +29,8 @@ func Add(ProfileData config.ProfileData, name string, amount float64) config.Pro
 
 // Edit allows the user to edit an existing expense in the given ProfileData.
 // It presents a prompt to select the expense to edit, and then presents
-// a prompt to select the action to take: change name, change value, or delete expense.
+// a prompt to select the action to take: change name, change value, change category,
+// change due date, flag the expense as essential or discretionary, or delete expense.
 // After the user selects an action and provides any required information, the function
 // updates the ProfileData accordingly and returns the updated ProfileData.
 func Edit(ProfileData config.ProfileData) config.ProfileData {
@@ -58,10 +60,14 @@ func Edit(ProfileData config.ProfileData) config.ProfileData {
 		}
 	}
 
-	// actions-change name, change value, delete
+	// actions-change name, change value, change category, change due date, toggle essential, delete
+	toggleAction := "Mark as Discretionary"
+	if selectedExpense.Discretionary {
+		toggleAction = "Mark as Essential"
+	}
 	actionPrompt := promptui.Select{
 		Label: "Select Action",
-		Items: []string{"Change Name", "Change Amount", "Delete Expense"},
+		Items: []string{"Change Name", "Change Amount", "Change Category", "Change Due Date", toggleAction, "Delete Expense"},
 	}
 	_, actionSelector, err := actionPrompt.Run()
 	if err != nil {
@@ -103,6 +109,50 @@ func Edit(ProfileData config.ProfileData) config.ProfileData {
 				break
 			}
 		}
+	case "Change Category":
+		categoryPrompt := promptui.Prompt{
+			Label: "Enter category",
+			Default: selectedExpense.Category,
+		}
+		newCategory, err := categoryPrompt.Run()
+		if err != nil {
+			panic(err)
+		}
+		for i, expense := range ProfileData.Expenses {
+			if expense.Name == selectedExpense.Name {
+				ProfileData.Expenses[i].Category = newCategory
+				break
+			}
+		}
+	case "Change Due Date":
+		frequencyPrompt := promptui.Select{
+			Label: "Select Frequency",
+			Items: []config.Frequency{config.Monthly, config.Yearly},
+		}
+		_, frequency, err := frequencyPrompt.Run()
+		if err != nil {
+			panic(err)
+		}
+		dueMonth := 0
+		if frequency == config.Yearly.String() {
+			dueMonth = promptNumber("Enter due month (1-12)", selectedExpense.DueMonth, 12, errors.ErrInvalidDueMonth)
+		}
+		dueDay := promptNumber("Enter due day (1-31)", selectedExpense.DueDay, 31, errors.ErrInvalidDueDay)
+		for i, expense := range ProfileData.Expenses {
+			if expense.Name == selectedExpense.Name {
+				ProfileData.Expenses[i].Frequency = config.Frequency(frequency)
+				ProfileData.Expenses[i].DueMonth = dueMonth
+				ProfileData.Expenses[i].DueDay = dueDay
+				break
+			}
+		}
+	case toggleAction:
+		for i, expense := range ProfileData.Expenses {
+			if expense.Name == selectedExpense.Name {
+				ProfileData.Expenses[i].Discretionary = !expense.Discretionary
+				break
+			}
+		}
 	case "Delete Expense":
 		for i, expense := range ProfileData.Expenses {
 			if expense.Name == selectedExpense.Name {
@@ -114,35 +164,119 @@ func Edit(ProfileData config.ProfileData) config.ProfileData {
 	return ProfileData
 }
 
-// Calculate takes a ProfileData struct as an argument, and calculates the total expenses of the profile.
-// It then calculates the remaining amount after expenses and desired final balance.
-// If the remaining amount is more than 10, it suggests a withdraw amount of the remaining amount rounded up to the nearest 5.
-// If the remaining amount is less than or equal to 10, it prints an error.
-// The function also prints out the salary, total expenses, desired final balance, remaining amount after expenses and desired balance, and the suggested withdraw amount.
-func Calculate(ProfileData config.ProfileData) {
-	config.SetLevel(ProfileData.Config.SavingLevel)
+// promptNumber asks the user for a whole number between 1 and max with the given label.
+// The current value is used as the default if it is set. If the input is not a number
+// in range, the prompt shows the given error and asks again.
+func promptNumber(label string, current int, max int, invalid error) int {
+	prompt := promptui.Prompt{
+		Label: label,
+		Validate: func(input string) error {
+			var number int
+			_, err := fmt.Sscanf(input, "%d", &number)
+			if err != nil || number < 1 || number > max {
+				return invalid
+			}
+			return nil
+		},
+	}
+	if current > 0 {
+		prompt.Default = fmt.Sprintf("%d", current)
+	}
+	numberStr, err := prompt.Run()
+	if err != nil {
+		panic(err)
+	}
+	var number int
+	fmt.Sscanf(numberStr, "%d", &number)
+	return number
+}
+
+// Calculation holds the figures produced by Compute for a single profile.
+// It is used by Calculate to print the result and by Compare to show
+// several profiles or scenarios side by side.
+type Calculation struct {
+	Salary              float64   `json:"salary"`
+	TotalExpenses       float64   `json:"total_expenses"`
+	DebtPayments        float64   `json:"debt_payments"`
+	DesiredFinalBalance float64   `json:"desired_final_balance"`
+	RemainingAmount     float64   `json:"remaining_amount"`
+	WithdrawnAmount     float64   `json:"withdrawn_amount"`
+	LowestBalance       float64   `json:"lowest_balance"`
+	LowestBalanceDate   time.Time `json:"lowest_balance_date"`
+}
+
+// Compute takes a ProfileData struct as an argument, and calculates the total expenses of the profile.
+// Yearly expenses are counted with their monthly share.
+// The minimum payments of debts that are not paid off yet are counted as expenses.
+// It then calculates the remaining amount after expenses and desired final balance,
+// and suggests a withdraw amount of the remaining amount rounded down to the nearest 5.
+// The withdrawal is limited by the cash-flow calendar of the current pay period, so that
+// the lowest balance before the next payday never drops below the desired final balance.
+// It does not print anything, so the result can be compared with other profiles.
+func Compute(ProfileData config.ProfileData) Calculation {
+	return ComputeAt(ProfileData, time.Now())
+}
+
+// ComputeAt works like Compute, but limits the withdrawal by the cash-flow calendar
+// of the pay period containing the given day instead of the current one.
+func ComputeAt(ProfileData config.ProfileData, day time.Time) Calculation {
 	salary := ProfileData.Config.Salary
 	expenses := ProfileData.Expenses
 
 	totalExpenses := 0.0
 	for _, expense := range expenses {
-		totalExpenses += expense.Amount
+		totalExpenses += expense.MonthlyAmount()
 	}
-	if totalExpenses > salary {
-		fmt.Errorf(errors.ErrExpensesMoreThanSalary.Error())
+	debtPayments := 0.0
+	for _, debt := range ProfileData.Debts {
+		if debt.Balance > 0 {
+			debtPayments += math.Min(debt.MinimumPayment, debt.Balance)
+		}
 	}
+	totalExpenses += debtPayments
 	bills := totalExpenses
-	desiredFinalBalance := config.MinimalBalanceAfterExpenses
+	desiredFinalBalance := config.LevelBalance(ProfileData.Config.SavingLevel)
 	remainingAmount := salary - bills - desiredFinalBalance
-	withdrawnAmount := math.Max(5*math.Floor(remainingAmount/5), 0)
-	if withdrawnAmount <= 10 {
-		fmt.Errorf(errors.ErrWithdrawnAmountTooLow.Error())
-	}
-	fmt.Printf("Salary: %.2f€\n", salary)
-	fmt.Printf("Total Expenses: %.2f€\n", totalExpenses)
-	fmt.Printf("Desired Final Balance: %.2f€\n", desiredFinalBalance)
-	fmt.Printf("Remaining Amount after Expenses and Desired Balance: %.2f€\n", remainingAmount)
-	fmt.Printf("Suggested Withdrawn Amount: %.2f€\n", withdrawnAmount)
+	lowest := LowestPoint(CashFlow(ProfileData, LastPayday(ProfileData, day), 0))
+	cashFlowLimit := lowest.Balance - desiredFinalBalance
+	withdrawnAmount := math.Max(5*math.Floor(math.Min(remainingAmount, cashFlowLimit)/5), 0)
+	return Calculation{
+		Salary:              salary,
+		TotalExpenses:       totalExpenses,
+		DebtPayments:        debtPayments,
+		DesiredFinalBalance: desiredFinalBalance,
+		RemainingAmount:     remainingAmount,
+		WithdrawnAmount:     withdrawnAmount,
+		LowestBalance:       lowest.Balance - withdrawnAmount,
+		LowestBalanceDate:   lowest.Date,
+	}
+}
+
+// Calculate takes a ProfileData struct as an argument, and calculates the total expenses of the profile.
+// It then calculates the remaining amount after expenses and desired final balance.
+// If the remaining amount is more than 10, it suggests a withdraw amount of the remaining amount rounded up to the nearest 5.
+// If the remaining amount is less than or equal to 10, it prints an error.
+// The function also prints out the salary, total expenses, desired final balance, remaining amount after expenses and desired balance, and the suggested withdraw amount.
+func Calculate(ProfileData config.ProfileData) {
+	result := Compute(ProfileData)
+	if result.TotalExpenses > result.Salary {
+		fmt.Println(errors.ErrExpensesMoreThanSalary.Error())
+	}
+	if result.WithdrawnAmount <= 10 {
+		fmt.Println(errors.ErrWithdrawnAmountTooLow.Error())
+	}
+	fmt.Printf("Salary: %.2f€\n", result.Salary)
+	fmt.Printf("Total Expenses: %.2f€\n", result.TotalExpenses)
+	if result.DebtPayments > 0 {
+		fmt.Printf("Minimum Debt Payments (included in expenses): %.2f€\n", result.DebtPayments)
+	}
+	fmt.Printf("Desired Final Balance: %.2f€\n", result.DesiredFinalBalance)
+	fmt.Printf("Remaining Amount after Expenses and Desired Balance: %.2f€\n", result.RemainingAmount)
+	fmt.Printf("Suggested Withdrawn Amount: %.2f€\n", result.WithdrawnAmount)
+	fmt.Printf("Lowest Balance before Next Payday: %.2f€ on %s\n", result.LowestBalance, result.LowestBalanceDate.Format("2006-01-02"))
+	if fund := CalculateEmergencyFund(ProfileData); fund.Contribution > 0 {
+		fmt.Printf("Of which to Emergency Fund: %.2f€\n", fund.Contribution)
+	}
 }
 
 // printFlexibleTable prints a table to the console with the given note and columns.
@@ -0,0 +1,34 @@
+package expenses
+
+import (
+	"sync"
+	"testing"
+	"time"
+	"wallkeiro/core/config"
+)
+
+// TestComputeInParallel computes profiles of different saving levels at the same time, as the
+// servers do, so run with -race it fails if computing one changes what the other computes with.
+func TestComputeInParallel(t *testing.T) {
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	profiles := []config.ProfileData{
+		{Config: config.ConfigStruct{Salary: 2000, SalaryType: config.Fixed, SavingLevel: 1, Payday: 1}},
+		{Config: config.ConfigStruct{Salary: 2000, SalaryType: config.Fixed, SavingLevel: 4, Payday: 1}},
+	}
+	want := map[int]float64{1: 190, 4: 100}
+	for round := 0; round < 100; round++ {
+		var wg sync.WaitGroup
+		for _, profile := range profiles {
+			profile := profile
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result := ComputeAt(profile, day)
+				if result.DesiredFinalBalance != want[profile.Config.SavingLevel] {
+					t.Errorf("level %d: desired final balance %v, want %v", profile.Config.SavingLevel, result.DesiredFinalBalance, want[profile.Config.SavingLevel])
+				}
+			}()
+		}
+		wg.Wait()
+	}
+}
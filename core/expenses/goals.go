@@ -0,0 +1,61 @@
+package expenses
+
+import (
+	"fmt"
+	"math"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/table"
+)
+
+// GoalProgress holds how far a savings goal is from its target,
+// and how much has to be saved every month to reach it by its deadline.
+type GoalProgress struct {
+	Goal          config.GoalStruct `json:"goal"`
+	Progress      float64           `json:"progress"`
+	Missing       float64           `json:"missing"`
+	MonthsLeft    int               `json:"months_left"`
+	MonthlyNeeded float64           `json:"monthly_needed"`
+}
+
+// CalculateGoal calculates the progress of the given goal on the given day.
+// Progress is the share of the target already saved, between 0 and 1. If the goal has
+// a deadline in the future, MonthlyNeeded is the missing amount spread over the months left.
+func CalculateGoal(goal config.GoalStruct, day time.Time) GoalProgress {
+	progress := GoalProgress{Goal: goal, Missing: math.Max(goal.Target-goal.Saved, 0)}
+	if goal.Target > 0 {
+		progress.Progress = math.Min(goal.Saved/goal.Target, 1)
+	}
+	if deadline, ok := goal.DeadlineDate(); ok && deadline.After(day) {
+		progress.MonthsLeft = (deadline.Year()-day.Year())*12 + int(deadline.Month()-day.Month())
+		if progress.MonthsLeft < 1 {
+			progress.MonthsLeft = 1
+		}
+		progress.MonthlyNeeded = progress.Missing / float64(progress.MonthsLeft)
+	}
+	return progress
+}
+
+// ShowGoals prints a table with the savings goals of the given ProfileData,
+// their progress, deadline and the amount to save every month to reach them in time.
+func ShowGoals(ProfileData config.ProfileData) {
+	columns := []string{"Goal", "Saved", "Target", "Progress", "Deadline", "Monthly Needed"}
+	var rows [][]string
+	for _, goal := range ProfileData.Goals {
+		progress := CalculateGoal(goal, time.Now())
+		monthly := "-"
+		if progress.MonthsLeft > 0 {
+			monthly = fmt.Sprintf("%.2f€", progress.MonthlyNeeded)
+		}
+		rows = append(rows, []string{
+			goal.Name,
+			fmt.Sprintf("%.2f€", goal.Saved),
+			fmt.Sprintf("%.2f€", goal.Target),
+			fmt.Sprintf("%.0f%%", progress.Progress*100),
+			goal.Deadline,
+			monthly,
+		})
+	}
+	fmt.Println()
+	table.Print(columns, rows)
+}
@@ -0,0 +1,57 @@
+package expenses
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"wallkeiro/core/config"
+	"wallkeiro/core/table"
+)
+
+// InflationRate returns the yearly inflation rate in percent that applies to the given expense.
+// The rate of the expense's category is used if the profile has one, otherwise the global rate.
+func InflationRate(ProfileData config.ProfileData, expense config.ExpensesStuct) float64 {
+	if rate, ok := ProfileData.Config.CategoryInflation[strings.ToLower(expense.Category)]; ok && expense.Category != "" {
+		return rate
+	}
+	return ProfileData.Config.Inflation
+}
+
+// Project returns a copy of the given ProfileData with every expense raised by its
+// inflation rate for the given number of years. The salary and the minimum payments
+// of debts are fixed amounts and stay the same. The given ProfileData is not modified.
+func Project(ProfileData config.ProfileData, years int) config.ProfileData {
+	projected := ProfileData
+	projected.Expenses = make([]config.ExpensesStuct, len(ProfileData.Expenses))
+	for i, expense := range ProfileData.Expenses {
+		factor := math.Pow(1+InflationRate(ProfileData, expense)/100, float64(years))
+		expense.Amount = math.Round(expense.Amount*factor*100) / 100
+		projected.Expenses[i] = expense
+	}
+	return projected
+}
+
+// ShowProjection prints every expense of the given ProfileData with its inflation rate,
+// its amount today and its expected amount next year, followed by the calculation of
+// both years side by side, so the change in suggested savings can be planned ahead.
+func ShowProjection(ProfileData config.ProfileData) {
+	nextYear := Project(ProfileData, 1)
+	columns := []string{"Name", "Category", "Inflation", "This Year", "Next Year"}
+	var rows [][]string
+	total, nextTotal := 0.0, 0.0
+	for i, expense := range ProfileData.Expenses {
+		rows = append(rows, []string{
+			expense.Name,
+			expense.Category,
+			fmt.Sprintf("%.1f%%", InflationRate(ProfileData, expense)),
+			fmt.Sprintf("%.2f€", expense.Amount),
+			fmt.Sprintf("%.2f€", nextYear.Expenses[i].Amount),
+		})
+		total += expense.MonthlyAmount()
+		nextTotal += nextYear.Expenses[i].MonthlyAmount()
+	}
+	rows = append(rows, []string{"Monthly Total", "", "", fmt.Sprintf("%.2f€", total), fmt.Sprintf("%.2f€", nextTotal)})
+	fmt.Println()
+	table.Print(columns, rows)
+	Compare(ProfileData, nextYear, "Next Year")
+}
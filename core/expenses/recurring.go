@@ -0,0 +1,252 @@
+package expenses
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"wallkeiro/core/config"
+	"wallkeiro/core/table"
+)
+
+// MinRecurringOccurrences is the number of monthly payments needed before they are
+// taken as recurring. Yearly payments are taken as recurring after two payments.
+const MinRecurringOccurrences int = 3
+
+// RecurringPayment is an expense proposed from a series of imported transactions.
+// Existing is the index of the profile's expense it updates, or -1 for a new expense.
+type RecurringPayment struct {
+	Expense     config.ExpensesStuct `json:"expense"`
+	Existing    int                  `json:"existing"`
+	Occurrences int                  `json:"occurrences"`
+	LastDate    string               `json:"last_date"`
+}
+
+// DetectRecurring looks for recurring outgoing payments in the transactions of the given profile:
+// payments to the same payee, with a similar amount, at a regular monthly or yearly interval.
+// Every series is proposed as an expense with its frequency, amount and due day. Series that
+// match an existing expense by name (see samePayee), at most one for each expense, are proposed
+// as an update if the amount or due date changed, and left out if nothing changed, so
+// accepting every proposal keeps the expense list in sync.
+func DetectRecurring(ProfileData config.ProfileData) []RecurringPayment {
+	groups := map[string][]config.TransactionStruct{}
+	var keys []string
+	for _, transaction := range ProfileData.Transactions {
+		if transaction.Amount >= 0 {
+			continue
+		}
+		key := normalizePayee(transaction.Payee)
+		if key == "" {
+			key = transaction.IBAN
+		}
+		if key == "" {
+			continue
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], transaction)
+	}
+	sort.Strings(keys)
+
+	var proposals []RecurringPayment
+	var payees []string
+	for _, key := range keys {
+		for _, series := range splitByAmount(groups[key]) {
+			expense, ok := recurringExpense(series)
+			if !ok {
+				continue
+			}
+			proposals = append(proposals, RecurringPayment{Expense: expense, Existing: -1, Occurrences: len(series), LastDate: series[len(series)-1].Date})
+			payees = append(payees, key)
+		}
+	}
+	// every expense is matched by one series at most, and payees of the same name as an
+	// expense are matched before payees merely containing its name
+	taken := map[int]bool{}
+	for _, wholeWords := range []bool{false, true} {
+		for i := range proposals {
+			if proposals[i].Existing >= 0 {
+				continue
+			}
+			for j, existing := range ProfileData.Expenses {
+				if taken[j] || !samePayee(existing.Name, payees[i], wholeWords) {
+					continue
+				}
+				taken[j] = true
+				proposals[i].Existing = j
+				proposals[i].Expense.Name = existing.Name
+				proposals[i].Expense.Discretionary = existing.Discretionary
+				if proposals[i].Expense.Category == "" {
+					proposals[i].Expense.Category = existing.Category
+				}
+				break
+			}
+		}
+	}
+	var changed []RecurringPayment
+	for _, proposal := range proposals {
+		if proposal.Existing >= 0 {
+			existing := ProfileData.Expenses[proposal.Existing]
+			expense := proposal.Expense
+			if math.Abs(existing.Amount-expense.Amount) < 0.01 && existing.DueDay == expense.DueDay && existing.EffectiveFrequency() == expense.Frequency {
+				continue
+			}
+		}
+		changed = append(changed, proposal)
+	}
+	return changed
+}
+
+// samePayee reports whether the expense of the given name is paid to the normalized payee:
+// if its normalized name is the payee, or with wholeWords, if it is made of whole words of
+// the payee, so the expense "Netflix" matches "NETFLIX.COM" but "Rent" does not match "Car Rental".
+func samePayee(name, payee string, wholeWords bool) bool {
+	name = normalizePayee(name)
+	if name == "" {
+		return false
+	}
+	return name == payee || wholeWords && strings.Contains(" "+payee+" ", " "+name+" ")
+}
+
+// ShowRecurring prints a table of the proposed recurring expenses.
+func ShowRecurring(ProfileData config.ProfileData, proposals []RecurringPayment) {
+	columns := []string{"Name", "Amount", "Frequency", "Due", "Payments", "Last Paid", "Proposal"}
+	var rows [][]string
+	for _, proposal := range proposals {
+		due := fmt.Sprintf("day %d", proposal.Expense.DueDay)
+		if proposal.Expense.Frequency == config.Yearly {
+			due = fmt.Sprintf("%s %d", time.Month(proposal.Expense.DueMonth).String()[:3], proposal.Expense.DueDay)
+		}
+		action := "new expense"
+		if proposal.Existing >= 0 {
+			action = fmt.Sprintf("update from %.2f€", ProfileData.Expenses[proposal.Existing].Amount)
+		}
+		rows = append(rows, []string{
+			proposal.Expense.Name,
+			fmt.Sprintf("%.2f€", proposal.Expense.Amount),
+			proposal.Expense.Frequency.String(),
+			due,
+			fmt.Sprintf("%d", proposal.Occurrences),
+			proposal.LastDate,
+			action,
+		})
+	}
+	fmt.Println()
+	table.Print(columns, rows)
+}
+
+// ApplyRecurring adds or updates the expense of the given proposal in the ProfileData.
+func ApplyRecurring(ProfileData config.ProfileData, proposal RecurringPayment) config.ProfileData {
+	if proposal.Existing >= 0 && proposal.Existing < len(ProfileData.Expenses) {
+		proposal.Expense.ID = ProfileData.Expenses[proposal.Existing].ID
+		ProfileData.Expenses[proposal.Existing] = proposal.Expense
+		return ProfileData
+	}
+	ProfileData.Expenses = append(ProfileData.Expenses, proposal.Expense)
+	return ProfileData
+}
+
+// normalizePayee returns the payee in lower case with digits, punctuation and repeated
+// spaces removed, so "NETFLIX.COM 4411" and "Netflix.com 9921" are the same payee.
+func normalizePayee(payee string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, payee)
+	return strings.Join(strings.Fields(cleaned), " ")
+}
+
+// splitByAmount splits the payments to a single payee into series of similar amounts, so a
+// subscription and an occasional purchase from the same shop are not mixed up. Amounts within
+// 10% or 2€ of the latest payment of a series belong to it. Every series is sorted by date.
+func splitByAmount(transactions []config.TransactionStruct) [][]config.TransactionStruct {
+	sorted := append([]config.TransactionStruct{}, transactions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date < sorted[j].Date
+	})
+	var series [][]config.TransactionStruct
+	for _, transaction := range sorted {
+		placed := false
+		for i := range series {
+			reference := -series[i][len(series[i])-1].Amount
+			if math.Abs(-transaction.Amount-reference) <= math.Max(reference*0.1, 2) {
+				series[i] = append(series[i], transaction)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			series = append(series, []config.TransactionStruct{transaction})
+		}
+	}
+	return series
+}
+
+// recurringExpense returns the expense described by a series of payments, if they were paid
+// at a regular interval. Most of the intervals between the payments have to be about a month,
+// or about a year, apart. The amount is the latest payment and the due day the most common day.
+func recurringExpense(series []config.TransactionStruct) (config.ExpensesStuct, bool) {
+	if len(series) < 2 {
+		return config.ExpensesStuct{}, false
+	}
+	var dates []time.Time
+	for _, transaction := range series {
+		date, err := time.Parse(config.DateFormat, transaction.Date)
+		if err != nil {
+			return config.ExpensesStuct{}, false
+		}
+		dates = append(dates, date)
+	}
+	monthly, yearly := 0, 0
+	for i := 1; i < len(dates); i++ {
+		days := dates[i].Sub(dates[i-1]).Hours() / 24
+		switch {
+		case days >= 25 && days <= 36:
+			monthly++
+		case days >= 350 && days <= 380:
+			yearly++
+		}
+	}
+	intervals := len(dates) - 1
+	last := series[len(series)-1]
+	expense := config.ExpensesStuct{
+		Name:     strings.TrimSpace(last.Payee),
+		Amount:   -last.Amount,
+		Category: last.Category,
+		DueDay:   commonDay(dates),
+	}
+	if expense.Name == "" {
+		expense.Name = last.IBAN
+	}
+	switch {
+	case len(series) >= MinRecurringOccurrences && float64(monthly) >= 0.75*float64(intervals):
+		expense.Frequency = config.Monthly
+	case float64(yearly) >= 0.75*float64(intervals):
+		expense.Frequency = config.Yearly
+		expense.DueMonth = int(dates[len(dates)-1].Month())
+	default:
+		return config.ExpensesStuct{}, false
+	}
+	return expense, true
+}
+
+// commonDay returns the day of the month that appears most often among the given dates.
+// If several days are equally common, the latest payment's day is used.
+func commonDay(dates []time.Time) int {
+	counts := map[int]int{}
+	for _, date := range dates {
+		counts[date.Day()]++
+	}
+	day := dates[len(dates)-1].Day()
+	for i := len(dates) - 1; i >= 0; i-- {
+		if counts[dates[i].Day()] > counts[day] {
+			day = dates[i].Day()
+		}
+	}
+	return day
+}
@@ -0,0 +1,85 @@
+package expenses
+
+import (
+	"fmt"
+	"testing"
+	"wallkeiro/core/config"
+)
+
+// monthlyPayments returns three monthly payments of the amount to the payee, on the given day.
+func monthlyPayments(payee string, amount float64, day int) []config.TransactionStruct {
+	var transactions []config.TransactionStruct
+	for month := 1; month <= 3; month++ {
+		transactions = append(transactions, config.TransactionStruct{
+			Date:   fmt.Sprintf("2026-%02d-%02d", month, day),
+			Payee:  payee,
+			Amount: -amount,
+		})
+	}
+	return transactions
+}
+
+func TestDetectRecurringMatchesExpenses(t *testing.T) {
+	tests := []struct {
+		name         string
+		expenses     []config.ExpensesStuct
+		transactions [][]config.TransactionStruct
+		// want maps the name of every proposal to the index of the expense it updates, -1 for a new one
+		want map[string]int
+	}{
+		{
+			name:     "a word inside another word is another payee",
+			expenses: []config.ExpensesStuct{{Name: "Rent", Amount: 850, DueDay: 1}},
+			transactions: [][]config.TransactionStruct{
+				monthlyPayments("CAR RENTAL 4411", 300, 15),
+				monthlyPayments("Rent", 900, 1),
+			},
+			want: map[string]int{"CAR RENTAL 4411": -1, "Rent": 0},
+		},
+		{
+			name:     "whole words of the payee match",
+			expenses: []config.ExpensesStuct{{Name: "Netflix", Amount: 9.99, DueDay: 5}},
+			transactions: [][]config.TransactionStruct{
+				monthlyPayments("NETFLIX.COM 9921", 12.99, 5),
+			},
+			want: map[string]int{"Netflix": 0},
+		},
+		{
+			name:     "an expense is updated by one series only, the same name first",
+			expenses: []config.ExpensesStuct{{Name: "Netflix", Amount: 9.99, DueDay: 5}},
+			transactions: [][]config.TransactionStruct{
+				monthlyPayments("Netflix Com", 15.99, 20),
+				monthlyPayments("Netflix", 12.99, 5),
+			},
+			want: map[string]int{"Netflix Com": -1, "Netflix": 0},
+		},
+		{
+			name: "unchanged expenses are left out",
+			expenses: []config.ExpensesStuct{
+				{Name: "Rent", Amount: 900, DueDay: 1, Frequency: config.Monthly},
+				{Name: "Car Rental", Amount: 250, DueDay: 15},
+			},
+			transactions: [][]config.TransactionStruct{
+				monthlyPayments("CAR RENTAL 4411", 300, 15),
+				monthlyPayments("Rent", 900, 1),
+			},
+			want: map[string]int{"Car Rental": 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			profile := config.ProfileData{Expenses: test.expenses}
+			for _, transactions := range test.transactions {
+				profile.Transactions = append(profile.Transactions, transactions...)
+			}
+			proposals := DetectRecurring(profile)
+			got := map[string]int{}
+			for _, proposal := range proposals {
+				got[proposal.Expense.Name] = proposal.Existing
+			}
+			if fmt.Sprint(got) != fmt.Sprint(test.want) {
+				t.Fatalf("got proposals %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package expenses
+
+import (
+	"fmt"
+	"sort"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/table"
+)
+
+// Payment is a single income or bill on a given day. Bills have a negative
+// Amount and the salary a positive one. Balance is the account balance after
+// the payment, counted from the last payday.
+type Payment struct {
+	Date    time.Time `json:"date"`
+	Name    string    `json:"name"`
+	Amount  float64   `json:"amount"`
+	Balance float64   `json:"balance"`
+}
+
+// dayOf returns the given day of the given month at midnight. Days past the end
+// of the month are moved to the last day of the month, so a bill due on the 31st
+// is due on the 28th or 29th in February.
+func dayOf(year int, month time.Month, day int, loc *time.Location) time.Time {
+	if day < 1 {
+		day = 1
+	}
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// midnight returns the start of the day of the given time.
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// LastPayday returns the most recent payday of the profile on or before the given day.
+func LastPayday(ProfileData config.ProfileData, day time.Time) time.Time {
+	day = midnight(day)
+	payday := dayOf(day.Year(), day.Month(), ProfileData.Config.Payday, day.Location())
+	if payday.After(day) {
+		previous := day.AddDate(0, 0, -day.Day())
+		payday = dayOf(previous.Year(), previous.Month(), ProfileData.Config.Payday, day.Location())
+	}
+	return payday
+}
+
+// DueDates returns the days the given expense is due on, from the day from up to,
+// but not including, the day to. Expenses without a due day have no due dates.
+func DueDates(expense config.ExpensesStuct, from, to time.Time) []time.Time {
+	var dates []time.Time
+	if expense.DueDay == 0 {
+		return dates
+	}
+	from, to = midnight(from), midnight(to)
+	for month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, from.Location()); month.Before(to); month = month.AddDate(0, 1, 0) {
+		if expense.Frequency == config.Yearly && month.Month() != time.Month(expense.DueMonth) {
+			continue
+		}
+		date := dayOf(month.Year(), month.Month(), expense.DueDay, from.Location())
+		if !date.Before(from) && date.Before(to) {
+			dates = append(dates, date)
+		}
+	}
+	return dates
+}
+
+// Payments returns the salary and every bill with a due date from the day from up to,
+// but not including, the day to, sorted by date. On the same day the salary comes
+// before the bills. The balances are counted from the given starting balance.
+func Payments(ProfileData config.ProfileData, from, to time.Time, balance float64) []Payment {
+	var payments []Payment
+	for _, expense := range ProfileData.Expenses {
+		for _, date := range DueDates(expense, from, to) {
+			payments = append(payments, Payment{Date: date, Name: expense.Name, Amount: -expense.Amount})
+		}
+	}
+	payday := config.ExpensesStuct{Name: "Salary", DueDay: ProfileData.Config.Payday, Frequency: config.Monthly}
+	if payday.DueDay == 0 {
+		payday.DueDay = 1
+	}
+	for _, date := range DueDates(payday, from, to) {
+		payments = append(payments, Payment{Date: date, Name: payday.Name, Amount: ProfileData.Config.Salary})
+	}
+	sort.SliceStable(payments, func(i, j int) bool {
+		if payments[i].Date.Equal(payments[j].Date) {
+			return payments[i].Amount > payments[j].Amount
+		}
+		return payments[i].Date.Before(payments[j].Date)
+	})
+	for i := range payments {
+		balance += payments[i].Amount
+		payments[i].Balance = balance
+	}
+	return payments
+}
+
+// Upcoming returns the payments due in the given number of days starting with the
+// given day. The running balance starts with the salary of the last payday, minus
+// the bills that were already due between the last payday and the given day.
+func Upcoming(ProfileData config.ProfileData, from time.Time, days int) []Payment {
+	from = midnight(from)
+	payday := LastPayday(ProfileData, from)
+	balance := 0.0
+	if paid := Payments(ProfileData, payday, from, 0); len(paid) > 0 {
+		balance = paid[len(paid)-1].Balance
+	}
+	return Payments(ProfileData, from, from.AddDate(0, 0, days), balance)
+}
+
+// ShowUpcoming prints the payments due in the given number of days starting with the given day,
+// with the running balance after every payment. Payments that push the balance below the
+// desired final balance of the profile's saving level are marked, so it is known before
+// the next salary arrives whether the account will dip too low.
+func ShowUpcoming(ProfileData config.ProfileData, from time.Time, days int) {
+	desiredFinalBalance := config.LevelBalance(ProfileData.Config.SavingLevel)
+	payments := Upcoming(ProfileData, from, days)
+	fmt.Printf("\nPayments due in the next %d days (last payday %s):\n", days, LastPayday(ProfileData, from).Format("2006-01-02"))
+	columns := []string{"Date", "Payment", "Amount", "Balance", "Note"}
+	var rows [][]string
+	dips := 0
+	for _, payment := range payments {
+		warning := ""
+		if payment.Balance < desiredFinalBalance {
+			warning = "below desired balance"
+			dips++
+		}
+		rows = append(rows, []string{
+			payment.Date.Format("2006-01-02"),
+			payment.Name,
+			fmt.Sprintf("%+.2f€", payment.Amount),
+			fmt.Sprintf("%.2f€", payment.Balance),
+			warning,
+		})
+	}
+	table.Print(columns, rows)
+
+	undated := 0
+	for _, expense := range ProfileData.Expenses {
+		if expense.DueDay == 0 {
+			undated++
+		}
+	}
+	if undated > 0 {
+		fmt.Printf("%d expenses have no due date and are not shown.\n", undated)
+	}
+	if dips > 0 {
+		fmt.Printf("Warning: the balance drops below the desired final balance of %.2f€ %d times.\n", desiredFinalBalance, dips)
+	}
+}
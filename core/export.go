@@ -0,0 +1,87 @@
+package core
+
+import (
+	"wallkeiro/core/config"
+	"wallkeiro/core/export"
+
+	"github.com/manifoldco/promptui"
+
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ExportMenu lets the user export the given profile to a file.
+// The user selects the format and the path of the file, which defaults to
+// the profile name with the extension of the format in the current folder.
+func ExportMenu(selectedProfile string) error {
+	prompt := promptui.Select{
+		Label: "Select Export Format",
+		Items: []string{"iCalendar (.ics)", "ledger-cli (.ledger)", "hledger (.journal)", "beancount (.beancount)", "Excel (.xlsx)", "OpenDocument (.ods)"},
+	}
+	_, formatSelector, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+	profileData, err := config.ReadProfile(selectedProfile)
+	if err != nil {
+		return err
+	}
+	var extension string
+	var write func(file *os.File) error
+	switch formatSelector {
+	case "iCalendar (.ics)":
+		extension = ".ics"
+		write = func(file *os.File) error {
+			return export.ICS(file, selectedProfile, profileData)
+		}
+	case "ledger-cli (.ledger)":
+		extension = ".ledger"
+		write = func(file *os.File) error {
+			return export.Ledger(file, selectedProfile, profileData)
+		}
+	case "hledger (.journal)":
+		extension = ".journal"
+		write = func(file *os.File) error {
+			return export.HLedger(file, selectedProfile, profileData)
+		}
+	case "beancount (.beancount)":
+		extension = ".beancount"
+		write = func(file *os.File) error {
+			return export.Beancount(file, selectedProfile, profileData)
+		}
+	case "Excel (.xlsx)":
+		extension = ".xlsx"
+		write = func(file *os.File) error {
+			return export.XLSX(file, profileData)
+		}
+	case "OpenDocument (.ods)":
+		extension = ".ods"
+		write = func(file *os.File) error {
+			return export.ODS(file, profileData)
+		}
+	}
+	pathPrompt := promptui.Prompt{
+		Label: "Enter File Path",
+		Default: strings.ToLower(selectedProfile) + extension,
+	}
+	path, err := pathPrompt.Run()
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = write(file)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Profile %s exported to %s.\n", selectedProfile, path)
+	return nil
+}
@@ -0,0 +1,142 @@
+package export
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/expenses"
+)
+
+// icsDateFormat is the layout of all-day dates in iCalendar files.
+const icsDateFormat string = "20060102"
+
+// ICS writes the recurring expenses with a due date, the salary payday and the savings
+// goal deadlines of the given profile to w as an RFC 5545 calendar.
+// Every event has a UID derived from the profile name and the name of the expense or goal,
+// so exporting the same profile again updates the events instead of duplicating them.
+func ICS(w io.Writer, profileName string, ProfileData config.ProfileData) error {
+	now := time.Now()
+	stamp := now.UTC().Format("20060102T150405Z")
+	var lines []string
+	lines = append(lines,
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//wallkeiro//wallkeiro//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:"+icsEscape("Wallkeiro "+profileName),
+	)
+	event := func(uid, summary, description string, start time.Time, rrule string) {
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+uid,
+			"DTSTAMP:"+stamp,
+			"DTSTART;VALUE=DATE:"+start.Format(icsDateFormat),
+			"DTEND;VALUE=DATE:"+start.AddDate(0, 0, 1).Format(icsDateFormat),
+			"SUMMARY:"+icsEscape(summary),
+			"DESCRIPTION:"+icsEscape(description),
+			"TRANSP:TRANSPARENT",
+		)
+		if rrule != "" {
+			lines = append(lines, "RRULE:"+rrule)
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	salary := config.ExpensesStuct{Name: "Salary", Frequency: config.Monthly, DueDay: ProfileData.Config.Payday}
+	if salary.DueDay == 0 {
+		salary.DueDay = 1
+	}
+	if dates := expenses.DueDates(salary, start, start.AddDate(1, 0, 0)); len(dates) > 0 {
+		event(icsUID(profileName, "payday", salary.Name), "Payday: "+salary.Name,
+			fmt.Sprintf("Salary of %.2f€ arrives.", ProfileData.Config.Salary), dates[0], icsRRule(salary))
+	}
+	for _, expense := range ProfileData.Expenses {
+		dates := expenses.DueDates(expense, start, start.AddDate(1, 0, 0))
+		if len(dates) == 0 {
+			continue
+		}
+		description := fmt.Sprintf("Monthly bill of %.2f€ is due.", expense.Amount)
+		if expense.EffectiveFrequency() == config.Yearly {
+			description = fmt.Sprintf("Yearly bill of %.2f€ is due.", expense.Amount)
+		}
+		if expense.Category != "" {
+			description += " Category: " + expense.Category + "."
+		}
+		// the ID keeps the event the same when the expense is renamed, and apart from another
+		// expense of the same name
+		key := expense.ID
+		if key == "" {
+			key = expense.Name
+		}
+		event(icsUID(profileName, "expense", key), expense.Name+" "+fmt.Sprintf("%.2f€", expense.Amount),
+			description, dates[0], icsRRule(expense))
+	}
+	for _, goal := range ProfileData.Goals {
+		deadline, ok := goal.DeadlineDate()
+		if !ok {
+			continue
+		}
+		event(icsUID(profileName, "goal", goal.Name), "Savings goal: "+goal.Name,
+			fmt.Sprintf("Target of %.2f€, %.2f€ saved so far.", goal.Target, goal.Saved), deadline, "")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		if _, err := io.WriteString(w, icsFold(line)+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// icsRRule returns the recurrence rule of the given expense.
+// Days past the 28th fall back to the last day of shorter months, the same way
+// the due dates of wallkeiro do, by picking the last of the possible days.
+func icsRRule(expense config.ExpensesStuct) string {
+	byMonthDay := fmt.Sprintf("BYMONTHDAY=%d", expense.DueDay)
+	if expense.DueDay > 28 {
+		var days []string
+		for day := 28; day <= expense.DueDay; day++ {
+			days = append(days, fmt.Sprintf("%d", day))
+		}
+		byMonthDay = "BYMONTHDAY=" + strings.Join(days, ",") + ";BYSETPOS=-1"
+	}
+	if expense.EffectiveFrequency() == config.Yearly {
+		return fmt.Sprintf("FREQ=YEARLY;BYMONTH=%d;%s", expense.DueMonth, byMonthDay)
+	}
+	return "FREQ=MONTHLY;" + byMonthDay
+}
+
+// icsUID returns a stable unique identifier for an event of the given kind in the given profile,
+// told apart from the other events of its kind by key, an ID or a name.
+func icsUID(profileName, kind, key string) string {
+	sum := sha1.Sum([]byte(strings.ToLower(profileName) + "\x00" + kind + "\x00" + strings.ToLower(key)))
+	return fmt.Sprintf("%x@wallkeiro", sum[:12])
+}
+
+// icsEscape escapes the characters that have a special meaning in iCalendar text values.
+func icsEscape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(text)
+}
+
+// icsFold folds the given content line into lines of at most 75 octets,
+// without splitting multi-byte characters, as required by RFC 5545.
+func icsFold(line string) string {
+	var folded strings.Builder
+	length := 0
+	for _, r := range line {
+		size := len(string(r))
+		if length+size > 75 {
+			folded.WriteString("\r\n ")
+			length = 1
+		}
+		folded.WriteRune(r)
+		length += size
+	}
+	return folded.String()
+}
@@ -0,0 +1,320 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"wallkeiro/core/config"
+)
+
+// Account roots and the accounts used for the salary and the bank account in journal exports.
+const (
+	ExpensesAccount      string = "Expenses"
+	IncomeAccount        string = "Income"
+	SalaryAccount        string = "Income:Salary"
+	CheckingAccount      string = "Assets:Checking"
+	UncategorizedAccount string = "Uncategorized"
+	JournalCommodity     string = "EUR"
+)
+
+// Ledger writes the given profile to w as a ledger-cli journal.
+// The salary and the expense list become periodic transactions, which ledger uses
+// for budget and forecast reports, and the imported transactions become regular
+// transactions between the checking account and the account of their category.
+func Ledger(w io.Writer, profileName string, ProfileData config.ProfileData) error {
+	return journal(w, profileName, ProfileData, false)
+}
+
+// HLedger writes the given profile to w as an hledger journal. It is the same as the
+// ledger-cli journal, except for the tags, which hledger writes as "name:" comments.
+func HLedger(w io.Writer, profileName string, ProfileData config.ProfileData) error {
+	return journal(w, profileName, ProfileData, true)
+}
+
+// journal writes a ledger-cli or hledger journal of the given profile to w.
+func journal(w io.Writer, profileName string, ProfileData config.ProfileData, hledger bool) error {
+	var lines []string
+	lines = append(lines, "; Wallkeiro profile "+profileName, "")
+
+	var accounts []string
+	accounts = append(accounts, CheckingAccount)
+	if ProfileData.Config.Salary > 0 {
+		accounts = append(accounts, SalaryAccount)
+	}
+	for _, expense := range ProfileData.Expenses {
+		accounts = append(accounts, expenseAccount(expense))
+	}
+	for _, transaction := range ProfileData.Transactions {
+		accounts = append(accounts, transactionAccount(transaction))
+	}
+	for _, account := range uniqueSorted(accounts) {
+		lines = append(lines, "account "+account)
+	}
+	lines = append(lines, "")
+
+	if ProfileData.Config.Salary > 0 {
+		lines = append(lines,
+			"~ monthly  ; Salary",
+			journalPosting(CheckingAccount, ProfileData.Config.Salary),
+			journalPosting(SalaryAccount, -ProfileData.Config.Salary),
+			"",
+		)
+	}
+	for _, expense := range ProfileData.Expenses {
+		period := "monthly"
+		if expense.EffectiveFrequency() == config.Yearly {
+			period = "yearly"
+		}
+		lines = append(lines,
+			fmt.Sprintf("~ %s  ; %s", period, singleLine(expense.Name)),
+			journalPosting(expenseAccount(expense), expense.Amount),
+			journalPosting(CheckingAccount, -expense.Amount),
+			"",
+		)
+	}
+
+	for _, transaction := range sortedTransactions(ProfileData.Transactions) {
+		header := transaction.Date
+		if transaction.ValueDate != "" && transaction.ValueDate != transaction.Date {
+			header += "=" + transaction.ValueDate
+		}
+		header += " * " + singleLine(transactionPayee(transaction))
+		lines = append(lines, header)
+		if transaction.Memo != "" {
+			lines = append(lines, "    ; "+singleLine(transaction.Memo))
+		}
+		var tags []string
+		for _, tag := range transaction.Tags {
+			if name := tagName(tag); name != "" {
+				tags = append(tags, name)
+			}
+		}
+		if len(tags) > 0 && hledger {
+			lines = append(lines, "    ; "+strings.Join(tags, ":, ")+":")
+		} else if len(tags) > 0 {
+			lines = append(lines, "    ; :"+strings.Join(tags, ":")+":")
+		}
+		if transaction.ID != "" {
+			lines = append(lines, "    ; id: "+transaction.ID)
+		}
+		lines = append(lines,
+			journalPosting(transactionAccount(transaction), -transaction.Amount),
+			journalPosting(CheckingAccount, transaction.Amount),
+			"",
+		)
+	}
+	return writeLines(w, lines, "\n")
+}
+
+// Beancount writes the given profile to w as a beancount file.
+// Every account is opened on the day of the first transaction, the salary and the expense
+// list become "budget" custom directives as read by Fava, summed up for every account, and
+// the imported transactions become transactions between the checking account and the account
+// of their category, with their tags and the bank's ID as metadata.
+func Beancount(w io.Writer, profileName string, ProfileData config.ProfileData) error {
+	now := time.Now()
+	budgetDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local).Format(config.DateFormat)
+	openDate := budgetDate
+	transactions := sortedTransactions(ProfileData.Transactions)
+	if len(transactions) > 0 && transactions[0].Date < openDate {
+		openDate = transactions[0].Date
+	}
+
+	budgets := map[string]float64{}
+	var accounts []string
+	accounts = append(accounts, CheckingAccount)
+	if ProfileData.Config.Salary > 0 {
+		accounts = append(accounts, SalaryAccount)
+		budgets[SalaryAccount] = -ProfileData.Config.Salary
+	}
+	for _, expense := range ProfileData.Expenses {
+		account := expenseAccount(expense)
+		accounts = append(accounts, account)
+		budgets[account] += expense.MonthlyAmount()
+	}
+	for _, transaction := range transactions {
+		accounts = append(accounts, transactionAccount(transaction))
+	}
+	accounts = uniqueSorted(accounts)
+
+	var lines []string
+	lines = append(lines,
+		"option \"title\" "+beancountString("Wallkeiro "+profileName),
+		"option \"operating_currency\" \""+JournalCommodity+"\"",
+		"",
+	)
+	for _, account := range accounts {
+		lines = append(lines, fmt.Sprintf("%s open %s %s", openDate, account, JournalCommodity))
+	}
+	lines = append(lines, "")
+	for _, account := range accounts {
+		if amount, ok := budgets[account]; ok {
+			lines = append(lines, fmt.Sprintf("%s custom \"budget\" %s \"monthly\" %.2f %s", budgetDate, account, amount, JournalCommodity))
+		}
+	}
+	lines = append(lines, "")
+
+	for _, transaction := range transactions {
+		header := fmt.Sprintf("%s * %s %s", transaction.Date, beancountString(transactionPayee(transaction)), beancountString(singleLine(transaction.Memo)))
+		for _, tag := range transaction.Tags {
+			if name := tagName(tag); name != "" {
+				header += " #" + name
+			}
+		}
+		lines = append(lines, header)
+		if transaction.ID != "" {
+			lines = append(lines, "  id: "+beancountString(transaction.ID))
+		}
+		if transaction.ValueDate != "" && transaction.ValueDate != transaction.Date {
+			lines = append(lines, "  value-date: "+transaction.ValueDate)
+		}
+		if transaction.IBAN != "" {
+			lines = append(lines, "  iban: "+beancountString(transaction.IBAN))
+		}
+		lines = append(lines,
+			fmt.Sprintf("  %s  %.2f %s", transactionAccount(transaction), -transaction.Amount, JournalCommodity),
+			fmt.Sprintf("  %s  %.2f %s", CheckingAccount, transaction.Amount, JournalCommodity),
+			"",
+		)
+	}
+	return writeLines(w, lines, "\n")
+}
+
+// AccountName returns the account of the given category under the given root account.
+// Categories can be nested with ":", "/" or ">", like "Food/Groceries". Every part of the
+// name starts with an upper case letter and only keeps letters, digits and dashes, so the
+// account is valid in ledger-cli, hledger and beancount alike. An empty category gives
+// the "Uncategorized" account.
+func AccountName(root, category string) string {
+	parts := strings.FieldsFunc(category, func(r rune) bool {
+		return r == ':' || r == '/' || r == '>'
+	})
+	account := root
+	for _, part := range parts {
+		if name := accountPart(part); name != "" {
+			account += ":" + name
+		}
+	}
+	if account == root {
+		account += ":" + UncategorizedAccount
+	}
+	return account
+}
+
+// accountPart returns a single part of an account name, like "Eating-Out" for "eating out".
+func accountPart(part string) string {
+	var name strings.Builder
+	dash := false
+	for _, r := range strings.TrimSpace(part) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if dash && name.Len() > 0 {
+				name.WriteRune('-')
+			}
+			if name.Len() == 0 || dash {
+				r = unicode.ToUpper(r)
+			}
+			name.WriteRune(r)
+			dash = false
+		default:
+			dash = true
+		}
+	}
+	return name.String()
+}
+
+// expenseAccount returns the account of an expense of the expense list. Expenses without
+// a category get an account of their own name, since they are known to be a single bill.
+func expenseAccount(expense config.ExpensesStuct) string {
+	if expense.Category != "" {
+		return AccountName(ExpensesAccount, expense.Category)
+	}
+	return AccountName(ExpensesAccount, expense.Name)
+}
+
+// transactionAccount returns the account of the category of an imported transaction,
+// under the income account for money received and the expenses account otherwise.
+func transactionAccount(transaction config.TransactionStruct) string {
+	if transaction.Amount > 0 {
+		return AccountName(IncomeAccount, transaction.Category)
+	}
+	return AccountName(ExpensesAccount, transaction.Category)
+}
+
+// transactionPayee returns the payee of the transaction, or its IBAN if it has no payee.
+func transactionPayee(transaction config.TransactionStruct) string {
+	if transaction.Payee != "" {
+		return transaction.Payee
+	}
+	if transaction.IBAN != "" {
+		return transaction.IBAN
+	}
+	return "Unknown"
+}
+
+// journalPosting returns a ledger-cli posting of the amount to the account. Two spaces
+// separate the account from the amount, since account names may contain single spaces.
+func journalPosting(account string, amount float64) string {
+	return fmt.Sprintf("    %-40s  %.2f %s", account, amount, JournalCommodity)
+}
+
+// beancountString returns the text as a quoted beancount string.
+func beancountString(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(singleLine(text)) + `"`
+}
+
+// tagName returns the tag with every character that is not allowed in beancount tags,
+// which only allow ASCII letters and digits besides a few signs, replaced by a dash.
+// Tags without any ASCII letter or digit give an empty name and are left out.
+func tagName(tag string) string {
+	if strings.IndexFunc(tag, func(r rune) bool {
+		return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))
+	}) < 0 {
+		return ""
+	}
+	return strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) || r == '-' || r == '_' || r == '/' || r == '.' {
+			return r
+		}
+		return '-'
+	}, strings.TrimSpace(tag))
+}
+
+// singleLine returns the text with line breaks replaced by spaces.
+func singleLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// sortedTransactions returns the transactions sorted by date, keeping the order of the statement within a day.
+func sortedTransactions(transactions []config.TransactionStruct) []config.TransactionStruct {
+	sorted := append([]config.TransactionStruct{}, transactions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date < sorted[j].Date
+	})
+	return sorted
+}
+
+// uniqueSorted returns the values sorted and without duplicates.
+func uniqueSorted(values []string) []string {
+	sort.Strings(values)
+	var unique []string
+	for i, value := range values {
+		if i == 0 || value != values[i-1] {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+// writeLines writes every line to w followed by the given line ending.
+func writeLines(w io.Writer, lines []string, ending string) error {
+	for _, line := range lines {
+		if _, err := io.WriteString(w, line+ending); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"regexp"
+	"strings"
+	"wallkeiro/core/config"
+)
+
+// odsMimeType is the media type of OpenDocument spreadsheets, which has to be the first,
+// uncompressed file of the archive.
+const odsMimeType string = "application/vnd.oasis.opendocument.spreadsheet"
+
+// odsStyles maps the cell styles to the automatic styles of the content.
+var odsStyles = map[CellStyle]string{
+	TextStyle:     "Default",
+	HeaderStyle:   "ceHeader",
+	CurrencyStyle: "ceCurrency",
+	TotalStyle:    "ceTotal",
+	CountStyle:    "ceCount",
+}
+
+// odsReference matches the cell references and ranges of formulas written like in Excel.
+var odsReference = regexp.MustCompile(`\b([A-Z]{1,3}[0-9]+)(?::([A-Z]{1,3}[0-9]+))?\b`)
+
+// ODS writes the spreadsheet of the given profile to w as an OpenDocument spreadsheet.
+// Amounts are written as euro currency values, and formulas in the OpenFormula syntax.
+func ODS(w io.Writer, ProfileData config.ProfileData) error {
+	archive := zip.NewWriter(w)
+	// written raw, so the archive starts with the media type without a data descriptor
+	mimeType, err := archive.CreateRaw(&zip.FileHeader{
+		Name:               "mimetype",
+		Method:             zip.Store,
+		CRC32:              crc32.ChecksumIEEE([]byte(odsMimeType)),
+		CompressedSize64:   uint64(len(odsMimeType)),
+		UncompressedSize64: uint64(len(odsMimeType)),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(mimeType, odsMimeType); err != nil {
+		return err
+	}
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"META-INF/manifest.xml", `<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">` +
+			`<manifest:file-entry manifest:full-path="/" manifest:version="1.2" manifest:media-type="` + odsMimeType + `"/>` +
+			`<manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"/>` +
+			`</manifest:manifest>`},
+		{"content.xml", odsContent(Spreadsheet(ProfileData))},
+	}
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(file, xml.Header+part.content); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// odsContent returns the content part with the automatic styles and the tables of the given sheets.
+func odsContent(sheets []Sheet) string {
+	var content strings.Builder
+	content.WriteString(`<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"` +
+		` xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0"` +
+		` xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0"` +
+		` xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"` +
+		` xmlns:number="urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0"` +
+		` xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0"` +
+		` xmlns:of="urn:oasis:names:tc:opendocument:xmlns:of:1.2" office:version="1.2">`)
+	content.WriteString(`<office:automatic-styles>` +
+		`<number:currency-style style:name="nCurrency"><number:number number:decimal-places="2" number:min-decimal-places="2" number:min-integer-digits="1" number:grouping="true"/>` +
+		`<number:text> </number:text><number:currency-symbol>` + CurrencySymbol + `</number:currency-symbol></number:currency-style>` +
+		`<number:number-style style:name="nCount"><number:number number:decimal-places="0" number:min-integer-digits="1"/></number:number-style>` +
+		`<style:style style:name="ceHeader" style:family="table-cell"><style:text-properties fo:font-weight="bold"/></style:style>` +
+		`<style:style style:name="ceCurrency" style:family="table-cell" style:data-style-name="nCurrency"/>` +
+		`<style:style style:name="ceTotal" style:family="table-cell" style:data-style-name="nCurrency"><style:text-properties fo:font-weight="bold"/></style:style>` +
+		`<style:style style:name="ceCount" style:family="table-cell" style:data-style-name="nCount"/>`)
+	for i, sheet := range sheets {
+		for j, width := range sheet.Widths {
+			// a character is about 0.2 centimetres wide in the default font
+			fmt.Fprintf(&content, `<style:style style:name="co%d_%d" style:family="table-column"><style:table-column-properties style:column-width="%.2fcm"/></style:style>`, i, j, float64(width)*0.2)
+		}
+	}
+	content.WriteString(`</office:automatic-styles><office:body><office:spreadsheet>`)
+	for i, sheet := range sheets {
+		fmt.Fprintf(&content, `<table:table table:name="%s">`, xmlEscape(sheet.Name))
+		for j := range sheet.Widths {
+			fmt.Fprintf(&content, `<table:table-column table:style-name="co%d_%d"/>`, i, j)
+		}
+		for _, row := range sheet.Rows {
+			content.WriteString("<table:table-row>")
+			for _, cell := range row {
+				content.WriteString(odsCell(cell))
+			}
+			content.WriteString("</table:table-row>")
+		}
+		content.WriteString("</table:table>")
+	}
+	content.WriteString(`</office:spreadsheet></office:body></office:document-content>`)
+	return content.String()
+}
+
+// odsCell returns the table cell of the given cell.
+func odsCell(cell Cell) string {
+	style := odsStyles[cell.Style]
+	if !cell.IsNumber() {
+		if cell.Text == "" {
+			return "<table:table-cell/>"
+		}
+		return fmt.Sprintf(`<table:table-cell table:style-name="%s" office:value-type="string"><text:p>%s</text:p></table:table-cell>`, style, xmlEscape(cell.Text))
+	}
+	formula := ""
+	if cell.Formula != "" {
+		formula = fmt.Sprintf(` table:formula="%s"`, xmlEscape(odsFormula(cell.Formula)))
+	}
+	value := formatNumber(cell.Number)
+	if cell.Style == CountStyle {
+		return fmt.Sprintf(`<table:table-cell table:style-name="%s"%s office:value-type="float" office:value="%s"><text:p>%s</text:p></table:table-cell>`, style, formula, value, value)
+	}
+	return fmt.Sprintf(`<table:table-cell table:style-name="%s"%s office:value-type="currency" office:currency="%s" office:value="%s"><text:p>%.2f %s</text:p></table:table-cell>`,
+		style, formula, JournalCommodity, value, cell.Number, CurrencySymbol)
+}
+
+// odsFormula converts a formula written like in Excel to OpenFormula, like "SUM(B2:B5)" to "of:=SUM([.B2:.B5])".
+func odsFormula(formula string) string {
+	return "of:=" + odsReference.ReplaceAllStringFunc(formula, func(reference string) string {
+		match := odsReference.FindStringSubmatch(reference)
+		if match[2] != "" {
+			return "[." + match[1] + ":." + match[2] + "]"
+		}
+		return "[." + match[1] + "]"
+	})
+}
@@ -0,0 +1,196 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"wallkeiro/core/config"
+	"wallkeiro/core/expenses"
+)
+
+// CurrencySymbol is the currency symbol shown in number formats of spreadsheet exports.
+const CurrencySymbol string = "€"
+
+// CellStyle is the number format and font of a spreadsheet cell.
+type CellStyle int
+
+const (
+	TextStyle CellStyle = iota
+	HeaderStyle
+	CurrencyStyle
+	TotalStyle
+	CountStyle
+)
+
+// Cell is a single cell of a spreadsheet. Cells with a Formula also hold its
+// value in Number, so spreadsheets that do not recalculate on load still show it.
+// Formulas are written like in Excel, with A1 style references within the same sheet.
+type Cell struct {
+	Text    string
+	Number  float64
+	Formula string
+	Style   CellStyle
+}
+
+// IsNumber reports whether the cell holds a number rather than text.
+func (c Cell) IsNumber() bool {
+	return c.Style == CurrencyStyle || c.Style == TotalStyle || c.Style == CountStyle
+}
+
+// Sheet is a named sheet of a spreadsheet with the widths of its columns in characters.
+type Sheet struct {
+	Name   string
+	Widths []int
+	Rows   [][]Cell
+}
+
+// Spreadsheet returns the sheets exported for the given profile: the expense list as shown
+// by Show, the breakdown of Calculate, and the imported transactions summed up by month.
+// Totals and the amounts derived from other cells are formulas, so changing an amount in the
+// spreadsheet updates them.
+func Spreadsheet(ProfileData config.ProfileData) []Sheet {
+	return []Sheet{expensesSheet(ProfileData), calculationSheet(ProfileData), historySheet(ProfileData)}
+}
+
+// expensesSheet returns the expense list with the monthly amount of every expense and the totals.
+func expensesSheet(ProfileData config.ProfileData) Sheet {
+	sheet := Sheet{
+		Name:   "Expenses",
+		Widths: []int{30, 20, 12, 14, 16},
+		Rows:   [][]Cell{headerRow("Name", "Category", "Frequency", "Amount", "Monthly Amount")},
+	}
+	total, monthlyTotal := 0.0, 0.0
+	for _, expense := range ProfileData.Expenses {
+		row := len(sheet.Rows) + 1
+		monthly := fmt.Sprintf("D%d", row)
+		if expense.EffectiveFrequency() == config.Yearly {
+			monthly = fmt.Sprintf("D%d/12", row)
+		}
+		sheet.Rows = append(sheet.Rows, []Cell{
+			{Text: expense.Name},
+			{Text: expense.Category},
+			{Text: expense.EffectiveFrequency().String()},
+			{Number: expense.Amount, Style: CurrencyStyle},
+			{Number: expense.MonthlyAmount(), Formula: monthly, Style: CurrencyStyle},
+		})
+		total += expense.Amount
+		monthlyTotal += expense.MonthlyAmount()
+	}
+	sheet.Rows = append(sheet.Rows, []Cell{
+		{Text: "Total", Style: HeaderStyle},
+		{},
+		{},
+		sumCell("D", len(sheet.Rows), total),
+		sumCell("E", len(sheet.Rows), monthlyTotal),
+	})
+	return sheet
+}
+
+// calculationSheet returns the breakdown of Calculate. The total expenses and the remaining
+// amount are formulas, while the suggested withdrawal also depends on the cash-flow calendar
+// and is written as a value.
+func calculationSheet(ProfileData config.ProfileData) Sheet {
+	result := expenses.Compute(ProfileData)
+	return Sheet{
+		Name:   "Calculation",
+		Widths: []int{50, 16},
+		Rows: [][]Cell{
+			headerRow("Item", "Amount"),
+			{{Text: "Salary"}, {Number: result.Salary, Style: CurrencyStyle}},
+			{{Text: "Expenses"}, {Number: result.TotalExpenses - result.DebtPayments, Style: CurrencyStyle}},
+			{{Text: "Minimum Debt Payments"}, {Number: result.DebtPayments, Style: CurrencyStyle}},
+			{{Text: "Total Expenses", Style: HeaderStyle}, {Number: result.TotalExpenses, Formula: "B3+B4", Style: TotalStyle}},
+			{{Text: "Desired Final Balance"}, {Number: result.DesiredFinalBalance, Style: CurrencyStyle}},
+			{{Text: "Remaining Amount after Expenses and Desired Balance", Style: HeaderStyle}, {Number: result.RemainingAmount, Formula: "B2-B5-B6", Style: TotalStyle}},
+			{{Text: "Suggested Withdrawn Amount"}, {Number: result.WithdrawnAmount, Style: CurrencyStyle}},
+			{{Text: "Lowest Balance before Next Payday"}, {Number: result.LowestBalance, Style: CurrencyStyle}},
+		},
+	}
+}
+
+// historySheet returns the income, the spending and the balance of every month
+// with imported transactions, with the totals over all months.
+func historySheet(ProfileData config.ProfileData) Sheet {
+	sheet := Sheet{
+		Name:   "Monthly History",
+		Widths: []int{12, 16, 16, 16, 14},
+		Rows:   [][]Cell{headerRow("Month", "Income", "Spending", "Net", "Transactions")},
+	}
+	type month struct {
+		income, spending float64
+		count            int
+	}
+	months := map[string]*month{}
+	var keys []string
+	for _, transaction := range ProfileData.Transactions {
+		if len(transaction.Date) < 7 {
+			continue
+		}
+		key := transaction.Date[:7]
+		if _, ok := months[key]; !ok {
+			months[key] = &month{}
+			keys = append(keys, key)
+		}
+		if transaction.Amount > 0 {
+			months[key].income += transaction.Amount
+		} else {
+			months[key].spending -= transaction.Amount
+		}
+		months[key].count++
+	}
+	sort.Strings(keys)
+	var income, spending float64
+	count := 0
+	for _, key := range keys {
+		row := len(sheet.Rows) + 1
+		sheet.Rows = append(sheet.Rows, []Cell{
+			{Text: key},
+			{Number: months[key].income, Style: CurrencyStyle},
+			{Number: months[key].spending, Style: CurrencyStyle},
+			{Number: months[key].income - months[key].spending, Formula: fmt.Sprintf("B%d-C%d", row, row), Style: CurrencyStyle},
+			{Number: float64(months[key].count), Style: CountStyle},
+		})
+		income += months[key].income
+		spending += months[key].spending
+		count += months[key].count
+	}
+	last := len(sheet.Rows)
+	countTotal := sumCell("E", last, float64(count))
+	countTotal.Style = CountStyle
+	sheet.Rows = append(sheet.Rows, []Cell{
+		{Text: "Total", Style: HeaderStyle},
+		sumCell("B", last, income),
+		sumCell("C", last, spending),
+		{Number: income - spending, Formula: fmt.Sprintf("B%d-C%d", last+1, last+1), Style: TotalStyle},
+		countTotal,
+	})
+	return sheet
+}
+
+// headerRow returns a row of bold text cells.
+func headerRow(titles ...string) []Cell {
+	var row []Cell
+	for _, title := range titles {
+		row = append(row, Cell{Text: title, Style: HeaderStyle})
+	}
+	return row
+}
+
+// sumCell returns a total cell that sums the given column from the second row
+// to the given last row. Without any rows to sum, the total is written as a value.
+func sumCell(column string, last int, total float64) Cell {
+	cell := Cell{Number: total, Style: TotalStyle}
+	if last >= 2 {
+		cell.Formula = fmt.Sprintf("SUM(%s2:%s%d)", column, column, last)
+	}
+	return cell
+}
+
+// columnName returns the letters of the column with the given zero based index, like "AB" for 27.
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
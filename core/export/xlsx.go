@@ -0,0 +1,128 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"wallkeiro/core/config"
+)
+
+// xlsxStyles maps the cell styles to the cellXfs entries of the styles part.
+var xlsxStyles = map[CellStyle]int{
+	TextStyle:     0,
+	HeaderStyle:   1,
+	CurrencyStyle: 2,
+	TotalStyle:    3,
+	CountStyle:    4,
+}
+
+// XLSX writes the spreadsheet of the given profile to w as an Office Open XML workbook.
+// Amounts are formatted as euros, and the workbook asks to be recalculated on load, so
+// the formulas are up to date even though their values are also written.
+func XLSX(w io.Writer, ProfileData config.ProfileData) error {
+	sheets := Spreadsheet(ProfileData)
+	archive := zip.NewWriter(w)
+
+	var overrides, workbookSheets, relationships strings.Builder
+	for i, sheet := range sheets {
+		fmt.Fprintf(&overrides, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+		fmt.Fprintf(&workbookSheets, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(sheet.Name), i+1, i+1)
+		fmt.Fprintf(&relationships, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	fmt.Fprintf(&relationships, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(sheets)+1)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+			overrides.String() + `</Types>`},
+		{"_rels/.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets>` + workbookSheets.String() + `</sheets><calcPr fullCalcOnLoad="1"/></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			relationships.String() + `</Relationships>`},
+		{"xl/styles.xml", `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<numFmts count="1"><numFmt numFmtId="164" formatCode="#,##0.00\ &quot;` + CurrencySymbol + `&quot;;\-#,##0.00\ &quot;` + CurrencySymbol + `&quot;"/></numFmts>` +
+			`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+			`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+			`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+			`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+			`<cellXfs count="5">` +
+			`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+			`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+			`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+			`<xf numFmtId="164" fontId="1" fillId="0" borderId="0" xfId="0" applyNumberFormat="1" applyFont="1"/>` +
+			`<xf numFmtId="1" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+			`</cellXfs><cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles></styleSheet>`},
+	}
+	for i, sheet := range sheets {
+		parts = append(parts, struct {
+			name    string
+			content string
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), xlsxSheet(sheet)})
+	}
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(file, xml.Header+part.content); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// xlsxSheet returns the worksheet part of the given sheet. Text is written as inline strings.
+func xlsxSheet(sheet Sheet) string {
+	var content strings.Builder
+	content.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if len(sheet.Widths) > 0 {
+		content.WriteString("<cols>")
+		for i, width := range sheet.Widths {
+			fmt.Fprintf(&content, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, width)
+		}
+		content.WriteString("</cols>")
+	}
+	content.WriteString("<sheetData>")
+	for i, row := range sheet.Rows {
+		fmt.Fprintf(&content, `<row r="%d">`, i+1)
+		for j, cell := range row {
+			reference := fmt.Sprintf("%s%d", columnName(j), i+1)
+			style := xlsxStyles[cell.Style]
+			switch {
+			case cell.IsNumber() && cell.Formula != "":
+				fmt.Fprintf(&content, `<c r="%s" s="%d"><f>%s</f><v>%s</v></c>`, reference, style, xmlEscape(cell.Formula), formatNumber(cell.Number))
+			case cell.IsNumber():
+				fmt.Fprintf(&content, `<c r="%s" s="%d"><v>%s</v></c>`, reference, style, formatNumber(cell.Number))
+			case cell.Text != "":
+				fmt.Fprintf(&content, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, reference, style, xmlEscape(cell.Text))
+			}
+		}
+		content.WriteString("</row>")
+	}
+	content.WriteString("</sheetData></worksheet>")
+	return content.String()
+}
+
+// formatNumber returns the number as written in spreadsheet files, without a thousands
+// separator and rounded to cents.
+func formatNumber(number float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", number), "0"), ".")
+}
+
+// xmlEscape escapes the text for use in XML content and attribute values.
+func xmlEscape(text string) string {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(text))
+	return escaped.String()
+}
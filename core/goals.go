@@ -0,0 +1,107 @@
+package core
+
+import (
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/expenses"
+
+	"github.com/manifoldco/promptui"
+
+	"fmt"
+	"time"
+)
+
+// GoalMenu lets the user manage the savings goals of the given profile.
+// Goals can be added with a target and an optional deadline, have their saved
+// amount updated or be deleted, and their progress is shown after every change.
+func GoalMenu(selectedProfile string) error {
+	prompt := promptui.Select{
+		Label: "Select Goal Action",
+		Items: []string{"Show Goals", "Add Goal", "Update Saved Amount", "Delete Goal"},
+	}
+	_, actionSelector, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+	profileData, err := config.ReadProfile(selectedProfile)
+	if err != nil {
+		return err
+	}
+	switch actionSelector {
+	case "Show Goals":
+	case "Add Goal":
+		var goal config.GoalStruct
+		namePrompt := promptui.Prompt{
+			Label: "Enter Goal Name",
+		}
+		goal.Name, err = namePrompt.Run()
+		if err != nil {
+			return err
+		}
+		goal.Target, err = promptAmount("Enter Target Amount", "")
+		if err != nil {
+			return err
+		}
+		goal.Saved, err = promptAmount("Enter Amount Saved So Far", "0")
+		if err != nil {
+			return err
+		}
+		goal.Deadline, err = promptDate("Enter Deadline (YYYY-MM-DD, empty for none)")
+		if err != nil {
+			return err
+		}
+		profileData.Goals = append(profileData.Goals, goal)
+	case "Update Saved Amount", "Delete Goal":
+		if len(profileData.Goals) == 0 {
+			fmt.Println("No goals to edit.")
+			return nil
+		}
+		var goalNames []string
+		for _, goal := range profileData.Goals {
+			goalNames = append(goalNames, goal.Name)
+		}
+		prompt := promptui.Select{
+			Label: "Select Goal",
+			Items: goalNames,
+		}
+		goalIndex, _, err := prompt.Run()
+		if err != nil {
+			return err
+		}
+		if actionSelector == "Delete Goal" {
+			profileData.Goals = append(profileData.Goals[:goalIndex], profileData.Goals[goalIndex+1:]...)
+			break
+		}
+		saved, err := promptAmount("Enter Amount Saved", fmt.Sprintf("%.2f", profileData.Goals[goalIndex].Saved))
+		if err != nil {
+			return err
+		}
+		profileData.Goals[goalIndex].Saved = saved
+	}
+	if actionSelector != "Show Goals" {
+		err = config.UpdateProfile(selectedProfile, &profileData)
+		if err != nil {
+			return err
+		}
+	}
+	expenses.ShowGoals(profileData)
+	return nil
+}
+
+// promptDate asks the user for a date in the format YYYY-MM-DD with the given label.
+// An empty input is allowed and returned as an empty string.
+func promptDate(label string) (string, error) {
+	prompt := promptui.Prompt{
+		Label: label,
+		Validate: func(input string) error {
+			if input == "" {
+				return nil
+			}
+			if _, err := time.Parse(config.DateFormat, input); err != nil {
+				return errors.ErrInvalidDate
+			}
+			return nil
+		},
+	}
+	return prompt.Run()
+}
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"wallkeiro/core/config"
+	"wallkeiro/core/history"
+	"wallkeiro/core/syncer"
+	"wallkeiro/core/table"
+
+	"github.com/manifoldco/promptui"
+
+	"fmt"
+	"strings"
+)
+
+// History runs "wallkeiro history" with the given arguments:
+//
+//	wallkeiro history                       shows the changes to a profile, asking for it
+//	wallkeiro history show <profile>        shows the changes to the profile
+//	wallkeiro history all                   shows the changes to every profile
+//	wallkeiro history init                  keeps the history of the profiles from now on
+//	wallkeiro history remote <url>          sets the git remote to push to and pull from
+//	wallkeiro history push                  pushes the history to the remote
+//	wallkeiro history pull                  pulls the history from the remote and merges it
+//
+// The profile is given after "show", so a profile may be named like any of the subcommands.
+func History(args []string) error {
+	if len(args) == 0 {
+		profiles := config.GetProfiles()
+		if len(profiles) == 0 {
+			fmt.Println("No profiles yet.")
+			return nil
+		}
+		prompt := promptui.Select{
+			Label: "Select Profile",
+			Items: profiles,
+		}
+		_, profile, err := prompt.Run()
+		if err != nil {
+			return err
+		}
+		return ShowHistory(profile)
+	}
+	switch args[0] {
+	case "show":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: wallkeiro history show <profile>")
+		}
+		return ShowHistory(args[1])
+	case "init":
+		if err := history.Init(); err != nil {
+			return err
+		}
+		fmt.Printf("The history of the profiles is kept in %s from now on.\n", config.ProfilesFolder)
+	case "all":
+		return ShowHistory("")
+	case "remote":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: wallkeiro history remote <url>")
+		}
+		if err := history.SetRemote(args[1]); err != nil {
+			return err
+		}
+		fmt.Printf("Pushing to and pulling from %s.\n", args[1])
+	case "push":
+		if err := history.Push(); err != nil {
+			return err
+		}
+		fmt.Println("History pushed.")
+	case "pull":
+		changed, err := history.Pull(func(path string, conflict syncer.Conflict) (bool, error) {
+			fmt.Printf("In %s:\n", path)
+			return resolveConflict(conflict)
+		})
+		if err != nil {
+			return err
+		}
+		if len(changed) == 0 {
+			fmt.Println("Already up to date.")
+			return nil
+		}
+		fmt.Printf("Pulled changes to %s.\n", strings.Join(changed, ", "))
+	default:
+		return fmt.Errorf("usage: wallkeiro history [show <profile> | all | init | remote <url> | push | pull]")
+	}
+	return nil
+}
+
+// ShowHistory prints a table of the changes to the given profile and its scenarios, newest
+// first, or of the changes to every profile if the profile is empty.
+func ShowHistory(profile string) error {
+	commits, err := history.Log(profile)
+	if err != nil {
+		return err
+	}
+	if len(commits) == 0 {
+		fmt.Println("No changes recorded yet.")
+		return nil
+	}
+	var rows [][]string
+	for _, commit := range commits {
+		rows = append(rows, []string{commit.Time.Local().Format("2006-01-02 15:04"), commit.Hash[:8], commit.Author, strings.ReplaceAll(commit.Message, "\n", " ")})
+	}
+	table.Print([]string{"Time", "Commit", "Author", "Change"}, rows)
+	return nil
+}
@@ -0,0 +1,220 @@
+// Package history keeps the profiles folder as a git repository, so every change to a profile
+// is a commit telling what changed, the history of a profile can be shown, and the profiles
+// can be synced between computers by pushing to and pulling from any git remote, including
+// a bare repository on a USB stick or a network share.
+// The repository is created with "wallkeiro history init"; from then on every profile,
+// scenario and bank mapping written by wallkeiro is committed right away. The user accounts,
+// the sync state, the data of the sync server, the storage settings and the encryption keys
+// are never committed, since they contain password hashes, tokens and keys.
+package history
+
+import (
+	"bytes"
+	goerrors "errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+)
+
+// Branch is the branch the profiles are committed to, pushed and pulled.
+const Branch string = "main"
+
+// Remote is the name of the git remote set with SetRemote.
+const Remote string = "origin"
+
+// ignored are the files and folders of the profiles folder that are never committed.
+var ignored = []string{"users/", "sync/", "sync-server/", "webdav/", "s3/", "keys/", "*.tmp"}
+
+// mu makes git run one command sequence at a time, since git locks the index while it works.
+var mu sync.Mutex
+
+// Commit is a commit of the history of the profiles.
+type Commit struct {
+	Hash    string
+	Time    time.Time
+	Author  string
+	Message string
+}
+
+// git runs git in the profiles folder and returns what it printed.
+func git(args ...string) (string, error) {
+	command := exec.Command("git", args...)
+	command.Dir = config.ProfilesFolder
+	var stdout, stderr bytes.Buffer
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	if err := command.Run(); err != nil {
+		if goerrors.Is(err, exec.ErrNotFound) {
+			return "", errors.ErrGitMissing
+		}
+		return stdout.String(), fmt.Errorf("git %s: %v: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// Enabled reports whether the profiles folder is a git repository created by Init.
+func Enabled() bool {
+	info, err := os.Stat(filepath.Join(config.ProfilesFolder, ".git"))
+	return err == nil && info.IsDir()
+}
+
+// Watch makes every change written by the config package be committed, if history is enabled.
+func Watch() {
+	if Enabled() {
+		config.OnChange = Record
+	}
+}
+
+// Init makes the profiles folder a git repository and commits the profiles already in it.
+// Commits are made as "wallkeiro on" the name of this computer, unless git has a user name set.
+func Init() error {
+	mu.Lock()
+	defer mu.Unlock()
+	if Enabled() {
+		return errors.ErrAlreadyExists
+	}
+	if _, err := git("init", "--quiet", "--initial-branch", Branch); err != nil {
+		return err
+	}
+	if name, _ := git("config", "user.name"); strings.TrimSpace(name) == "" {
+		host, _ := os.Hostname()
+		if _, err := git("config", "user.name", "wallkeiro on "+host); err != nil {
+			return err
+		}
+		if _, err := git("config", "user.email", "wallkeiro@"+host); err != nil {
+			return err
+		}
+	}
+	err := os.WriteFile(filepath.Join(config.ProfilesFolder, ".gitignore"), []byte(strings.Join(ignored, "\n")+"\n"), 0644)
+	if err != nil {
+		return err
+	}
+	return commit("history started", ".")
+}
+
+// Record commits the given paths with the message, if they changed. The paths are the ones
+// config passes to OnChange, so they start with the profiles folder.
+func Record(message string, paths ...string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	var relative []string
+	for _, path := range paths {
+		path, err := filepath.Rel(config.ProfilesFolder, path)
+		if err != nil {
+			return err
+		}
+		relative = append(relative, path)
+	}
+	return commit(message, relative...)
+}
+
+// commit stages the given paths, including deleted ones, and commits them if anything changed.
+func commit(message string, paths ...string) error {
+	for _, path := range paths {
+		// a path that neither exists nor was ever committed makes git add fail, and has nothing to stage
+		git("add", "--all", "--", path)
+	}
+	if _, err := git("diff", "--cached", "--quiet"); err == nil {
+		return nil
+	}
+	_, err := git("commit", "--quiet", "--message", message)
+	return err
+}
+
+// logFormat makes git log print the fields of every commit separated by unit separators,
+// and the commits separated by record separators, since messages may have several lines.
+const logFormat string = "--format=%H%x1f%aI%x1f%an%x1f%B%x1e"
+
+// Log returns the commits changing the given profile or its scenarios, newest first,
+// or all commits if the profile is empty. Commits before the profile was renamed to its
+// current name are included.
+func Log(profile string) ([]Commit, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if !Enabled() {
+		return nil, errors.ErrHistoryDisabled
+	}
+	if _, err := git("rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		return []Commit{}, nil
+	}
+	if profile == "" {
+		return log()
+	}
+	commits, err := log("--follow", "--", strings.ToLower(profile)+".json")
+	if err != nil {
+		return nil, err
+	}
+	scenarios, err := log("--", filepath.Join(config.ScenariosFolder, strings.ToLower(profile)))
+	if err != nil {
+		return nil, err
+	}
+	return mergeLogs(commits, scenarios), nil
+}
+
+// log runs git log with the given arguments and returns the commits it printed.
+func log(args ...string) ([]Commit, error) {
+	output, err := git(append([]string{"log", logFormat}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	commits := []Commit{}
+	for _, record := range strings.Split(output, "\x1e") {
+		fields := strings.Split(strings.TrimSpace(record), "\x1f")
+		if len(fields) != 4 {
+			continue
+		}
+		committed, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, Commit{Hash: fields[0], Time: committed, Author: fields[2], Message: strings.TrimSpace(fields[3])})
+	}
+	return commits, nil
+}
+
+// mergeLogs merges two logs sorted newest first into one, dropping commits that are in both.
+func mergeLogs(a, b []Commit) []Commit {
+	merged := []Commit{}
+	seen := map[string]bool{}
+	for len(a) > 0 || len(b) > 0 {
+		var next Commit
+		if len(b) == 0 || (len(a) > 0 && !a[0].Time.Before(b[0].Time)) {
+			next, a = a[0], a[1:]
+		} else {
+			next, b = b[0], b[1:]
+		}
+		if !seen[next.Hash] {
+			seen[next.Hash] = true
+			merged = append(merged, next)
+		}
+	}
+	return merged
+}
+
+// SetRemote sets the git remote the profiles are pushed to and pulled from,
+// which may be any URL git understands, or the path of a bare repository.
+func SetRemote(url string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if !Enabled() {
+		return errors.ErrHistoryDisabled
+	}
+	// paths of local repositories are made absolute, since git runs inside the profiles folder
+	if _, err := os.Stat(url); err == nil {
+		if absolute, err := filepath.Abs(url); err == nil {
+			url = absolute
+		}
+	}
+	if _, err := git("remote", "get-url", Remote); err == nil {
+		_, err = git("remote", "set-url", Remote, url)
+		return err
+	}
+	_, err := git("remote", "add", Remote, url)
+	return err
+}
@@ -0,0 +1,160 @@
+package history
+
+import (
+	goerrors "errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/syncer"
+)
+
+// testComputers returns a bare repository to push to and pull from, and the folders of two
+// computers with a profiles folder each. useComputer switches between the computers.
+func testComputers(t *testing.T) (string, string, string) {
+	t.Helper()
+	// the git configuration of the user running the tests does not apply
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.Chdir(workingDirectory)
+		config.OnChange = nil
+	})
+	remote := t.TempDir()
+	if output, err := exec.Command("git", "init", "--quiet", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("%v: %s", err, output)
+	}
+	var computers []string
+	for i := 0; i < 2; i++ {
+		folder := t.TempDir()
+		if err := os.Mkdir(filepath.Join(folder, config.ProfilesFolder), 0755); err != nil {
+			t.Fatal(err)
+		}
+		computers = append(computers, folder)
+	}
+	return remote, computers[0], computers[1]
+}
+
+// useComputer makes the profiles folder of the computer the one config and git use, and
+// commits every change written from now on.
+func useComputer(t *testing.T, computer string) {
+	t.Helper()
+	if err := os.Chdir(computer); err != nil {
+		t.Fatal(err)
+	}
+	config.OnChange = nil
+	Watch()
+}
+
+// edit reads the profile, changes it and writes it.
+func edit(t *testing.T, name string, change func(p *config.ProfileData)) {
+	t.Helper()
+	profile, err := config.ReadProfile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	change(&profile)
+	if err := config.UpdateProfile(name, &profile); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// mustPull pulls and fails the test unless exactly the expected files changed.
+func mustPull(t *testing.T, expected ...string) {
+	t.Helper()
+	changed, err := Pull(func(path string, conflict syncer.Conflict) (bool, error) {
+		t.Fatalf("unexpected conflict in %s: %s", path, conflict.Describe())
+		return false, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(changed, ",") != strings.Join(expected, ",") {
+		t.Fatalf("pull changed %v, want %v", changed, expected)
+	}
+}
+
+func TestPushAndPull(t *testing.T) {
+	remote, a, b := testComputers(t)
+
+	// the profiles there are already are committed by Init, and every change written after it
+	useComputer(t, a)
+	if err := config.CreateNewProfile("home"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	Watch()
+	edit(t, "home", func(p *config.ProfileData) {
+		p.Expenses = append(p.Expenses, config.ExpensesStuct{Name: "Rent", Amount: 900})
+	})
+	commits, err := Log("home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 2 || commits[1].Message != "history started" || !strings.Contains(commits[0].Message, "Rent") {
+		t.Fatalf("the history of home is %+v, want the start and the rent added", commits)
+	}
+	if err := SetRemote(remote); err != nil {
+		t.Fatal(err)
+	}
+	if err := Push(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the second computer started its own history, which is merged with the one pulled
+	useComputer(t, b)
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetRemote(remote); err != nil {
+		t.Fatal(err)
+	}
+	if err := Push(); !goerrors.Is(err, errors.ErrPullFirst) {
+		t.Fatalf("pushing an unrelated history: got %v, want %v", err, errors.ErrPullFirst)
+	}
+	mustPull(t, ".gitignore", "home.json")
+	if err := Push(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the first computer has nothing new, so it fast-forwards to the merge
+	useComputer(t, a)
+	mustPull(t)
+	pulled, err := revision("HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	useComputer(t, b)
+	if merge, _ := revision("HEAD"); pulled != merge {
+		t.Fatalf("after a fast-forward pull HEAD is %s, want the merge %s", pulled, merge)
+	}
+
+	// changes to different fields of the profile on both computers are merged
+	useComputer(t, a)
+	edit(t, "home", func(p *config.ProfileData) { p.Config.Salary = 3000 })
+	if err := Push(); err != nil {
+		t.Fatal(err)
+	}
+	useComputer(t, b)
+	edit(t, "home", func(p *config.ProfileData) { p.Expenses[0].Amount = 950 })
+	mustPull(t, "home.json")
+	profile, err := config.ReadProfile("home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.Config.Salary != 3000 || len(profile.Expenses) != 1 || profile.Expenses[0].Amount != 950 {
+		t.Fatalf("merged profile has the salary %v and the expenses %+v, want 3000 and a rent of 950", profile.Config.Salary, profile.Expenses)
+	}
+	if output, err := git("status", "--porcelain"); err != nil || output != "" {
+		t.Fatalf("the merge left uncommitted changes: %q %v", output, err)
+	}
+}
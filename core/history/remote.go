@@ -0,0 +1,231 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/syncer"
+)
+
+// Push pushes the history of the profiles to the remote. If the remote has commits this
+// computer does not have yet, it fails with errors.ErrPullFirst.
+func Push() error {
+	mu.Lock()
+	defer mu.Unlock()
+	if !Enabled() {
+		return errors.ErrHistoryDisabled
+	}
+	if err := commit("changes made outside of wallkeiro", "."); err != nil {
+		return err
+	}
+	_, err := git("push", "--quiet", Remote, "HEAD:refs/heads/"+Branch)
+	if err != nil && (strings.Contains(err.Error(), "rejected") || strings.Contains(err.Error(), "fetch first")) {
+		return errors.ErrPullFirst
+	}
+	return err
+}
+
+// Pull pulls the history of the profiles from the remote and merges it with the history of
+// this computer. Files changed only on the remote are taken over. Profiles and scenarios
+// changed on both are merged field by field like the sync server does (see syncer.Merge),
+// and resolve is asked which version of every conflicting field to keep; other files changed
+// on both keep the version of this computer. It returns the files changed by the pull.
+func Pull(resolve func(path string, conflict syncer.Conflict) (bool, error)) ([]string, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if !Enabled() {
+		return nil, errors.ErrHistoryDisabled
+	}
+	if err := commit("changes made outside of wallkeiro", "."); err != nil {
+		return nil, err
+	}
+	if _, err := git("fetch", "--quiet", Remote, Branch); err != nil {
+		// a new remote has no branch yet, so there is nothing to pull
+		if strings.Contains(err.Error(), "couldn't find remote ref") {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+	remoteHead, err := revision("FETCH_HEAD")
+	if err != nil {
+		return nil, err
+	}
+	head, err := revision("HEAD")
+	if err != nil {
+		return nil, err
+	}
+	// histories started on two computers have no common commit, then every file counts as added on both
+	base := ""
+	if output, err := git("merge-base", "HEAD", "FETCH_HEAD"); err == nil {
+		base = strings.TrimSpace(output)
+	}
+	switch base {
+	case remoteHead:
+		return []string{}, nil
+	case head:
+		changed, err := changedFiles(head, remoteHead)
+		if err != nil {
+			return nil, err
+		}
+		_, err = git("merge", "--quiet", "--ff-only", "FETCH_HEAD")
+		return changed, err
+	}
+	remoteChanged, err := changedFiles(base, remoteHead)
+	if err != nil {
+		return nil, err
+	}
+	localChanged, err := changedFiles(base, head)
+	if err != nil {
+		return nil, err
+	}
+	changedHere := map[string]bool{}
+	for _, path := range localChanged {
+		changedHere[path] = true
+	}
+	// records the merge without changing any file, the files are merged below
+	if _, err := git("merge", "--quiet", "--no-commit", "--strategy", "ours", "--allow-unrelated-histories", "FETCH_HEAD"); err != nil {
+		return nil, err
+	}
+	if err := mergeFiles(base, head, remoteHead, remoteChanged, changedHere, resolve); err != nil {
+		git("merge", "--abort")
+		return nil, err
+	}
+	// the merge is committed even if it kept every file of this computer, so the remote history is part of it
+	if _, err := git("add", "--all", "--", "."); err != nil {
+		return nil, err
+	}
+	if _, err := git("commit", "--quiet", "--message", fmt.Sprintf("merged changes from %s: %s", Remote, strings.Join(remoteChanged, ", "))); err != nil {
+		return nil, err
+	}
+	return remoteChanged, nil
+}
+
+// mergeFiles writes the merged version of every file changed on the remote.
+func mergeFiles(base, head, remoteHead string, remoteChanged []string, changedHere map[string]bool, resolve func(path string, conflict syncer.Conflict) (bool, error)) error {
+	for _, path := range remoteChanged {
+		remote, inRemote := show(remoteHead, path)
+		if !changedHere[path] {
+			if err := write(path, remote, inRemote); err != nil {
+				return err
+			}
+			continue
+		}
+		if !strings.HasSuffix(path, ".json") || strings.HasPrefix(path, config.BanksFolder+"/") {
+			continue
+		}
+		local, inLocal := show(head, path)
+		if !inLocal || !inRemote {
+			// a profile deleted on one computer but changed on the other is kept
+			if !inLocal {
+				if err := write(path, remote, true); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		baseVersion, _ := show(base, path)
+		merged, err := merge(path, baseVersion, local, remote, resolve)
+		if err != nil {
+			return err
+		}
+		if err := write(path, merged, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// merge merges the versions of a profile or scenario file, asking resolve about every conflict.
+func merge(path string, base, local, remote []byte, resolve func(path string, conflict syncer.Conflict) (bool, error)) ([]byte, error) {
+	var baseData, localData, remoteData config.ProfileData
+	if base != nil {
+		if err := json.Unmarshal(base, &baseData); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if err := json.Unmarshal(local, &localData); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := json.Unmarshal(remote, &remoteData); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for _, data := range []*config.ProfileData{&baseData, &localData, &remoteData} {
+		config.AssignIDs(data)
+	}
+	merged, conflicts, err := syncer.Merge(baseData, localData, remoteData)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for _, conflict := range conflicts {
+		keepLocal, err := resolve(path, conflict)
+		if err != nil {
+			return nil, err
+		}
+		if keepLocal {
+			if err := conflict.KeepLocal(&merged); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return json.Marshal(merged)
+}
+
+// revision returns the commit hash the given name of a commit stands for.
+func revision(name string) (string, error) {
+	output, err := git("rev-parse", "--verify", "--quiet", name)
+	return strings.TrimSpace(output), err
+}
+
+// changedFiles returns the files that differ between two commits. If from is empty, which
+// stands for no common commit, it returns every file of the second commit.
+func changedFiles(from, to string) ([]string, error) {
+	var output string
+	var err error
+	if from == "" {
+		output, err = git("ls-tree", "-r", "-z", "--name-only", to)
+	} else {
+		output, err = git("diff", "-z", "--name-only", "--no-renames", from, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+	files := []string{}
+	for _, file := range strings.Split(output, "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// show returns the content of the file in the given commit, and whether it is in it.
+func show(commit, path string) ([]byte, bool) {
+	if commit == "" {
+		return nil, false
+	}
+	output, err := git("show", commit+":"+path)
+	if err != nil {
+		return nil, false
+	}
+	return []byte(output), true
+}
+
+// write writes the content to the file of the profiles folder, or removes it if it does not exist.
+func write(path string, content []byte, exists bool) error {
+	fullPath := filepath.Join(config.ProfilesFolder, path)
+	if !exists {
+		err := os.Remove(fullPath)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(fullPath, content, 0644)
+}
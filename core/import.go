@@ -0,0 +1,204 @@
+package core
+
+import (
+	"wallkeiro/core/config"
+	"wallkeiro/core/importer"
+	"wallkeiro/core/rules"
+
+	"github.com/manifoldco/promptui"
+
+	"fmt"
+	"os"
+)
+
+const CreateNewBankMapping string = "create new bank mapping"
+
+// ImportMenu imports a bank statement into the transactions of the given profile.
+// The user selects the format and the file, the parsed transactions are previewed with
+// duplicates marked, and nothing is written to the profile until the user confirms.
+func ImportMenu(selectedProfile string) error {
+	prompt := promptui.Select{
+		Label: "Select Statement Format",
+		Items: []string{"CSV", "OFX/QFX", "QIF", "camt.053", "MT940"},
+	}
+	_, formatSelector, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+	var parse func(file *os.File) ([]config.TransactionStruct, error)
+	switch formatSelector {
+	case "CSV":
+		mapping, err := selectBankMapping()
+		if err != nil {
+			return err
+		}
+		parse = func(file *os.File) ([]config.TransactionStruct, error) {
+			return importer.ParseCSV(file, mapping)
+		}
+	case "OFX/QFX":
+		parse = func(file *os.File) ([]config.TransactionStruct, error) {
+			return importer.ParseOFX(file)
+		}
+	case "QIF":
+		dateFormatPrompt := promptui.Prompt{
+			Label: "Enter Date Format (Go layout)",
+			Default: importer.DefaultQIFDateFormat,
+			AllowEdit: true,
+		}
+		dateFormat, err := dateFormatPrompt.Run()
+		if err != nil {
+			return err
+		}
+		separatorPrompt := promptui.Select{
+			Label: "Select Decimal Separator",
+			Items: []string{".", ","},
+		}
+		_, decimalSeparator, err := separatorPrompt.Run()
+		if err != nil {
+			return err
+		}
+		parse = func(file *os.File) ([]config.TransactionStruct, error) {
+			return importer.ParseQIF(file, dateFormat, decimalSeparator)
+		}
+	case "camt.053":
+		parse = func(file *os.File) ([]config.TransactionStruct, error) {
+			return importer.ParseCamt053(file)
+		}
+	case "MT940":
+		encodingPrompt := promptui.Prompt{
+			Label: "Enter Encoding (utf-8, windows-1252, iso-8859-1)",
+			Default: "iso-8859-1",
+			AllowEdit: true,
+		}
+		encoding, err := encodingPrompt.Run()
+		if err != nil {
+			return err
+		}
+		parse = func(file *os.File) ([]config.TransactionStruct, error) {
+			return importer.ParseMT940(file, encoding)
+		}
+	}
+	pathPrompt := promptui.Prompt{
+		Label: "Enter Statement File Path",
+	}
+	path, err := pathPrompt.Run()
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	transactions, err := parse(file)
+	file.Close()
+	if err != nil {
+		return err
+	}
+	profileData, err := config.ReadProfile(selectedProfile)
+	if err != nil {
+		return err
+	}
+	_, err = rules.Apply(profileData.Rules, transactions, false)
+	if err != nil {
+		return err
+	}
+	importer.Categorize(profileData, transactions)
+	importer.ShowPreview(profileData.Transactions, transactions)
+	fresh, _ := importer.Deduplicate(profileData.Transactions, transactions)
+	if len(fresh) == 0 {
+		fmt.Println("Nothing new to import.")
+		return nil
+	}
+	confirmPrompt := promptui.Select{
+		Label: fmt.Sprintf("Import %d new transactions?", len(fresh)),
+		Items: []string{"Yes", "No"},
+	}
+	_, confirm, err := confirmPrompt.Run()
+	if err != nil {
+		return err
+	}
+	if confirm != "Yes" {
+		fmt.Println("Import cancelled.")
+		return nil
+	}
+	profileData.Transactions = append(profileData.Transactions, fresh...)
+	err = config.UpdateProfile(selectedProfile, &profileData)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d transactions imported.\n", len(fresh))
+	return RecurringMenu(selectedProfile)
+}
+
+// selectBankMapping lets the user select a saved bank mapping, or create and save a new one.
+func selectBankMapping() (config.BankMapping, error) {
+	prompt := promptui.Select{
+		Label: "Select Bank",
+		Items: append(config.GetBankMappings(), CreateNewBankMapping),
+	}
+	_, bankSelector, err := prompt.Run()
+	if err != nil {
+		return config.BankMapping{}, err
+	}
+	if bankSelector != CreateNewBankMapping {
+		return config.ReadBankMapping(bankSelector)
+	}
+
+	var mapping config.BankMapping
+	fields := []struct {
+		label        string
+		defaultValue string
+		value        *string
+	}{
+		{"Enter Bank Name", "", &mapping.Name},
+		{"Enter Delimiter (, ; or \\t)", ",", &mapping.Delimiter},
+		{"Enter Encoding (utf-8, windows-1252, iso-8859-1, utf-16)", "utf-8", &mapping.Encoding},
+		{"Enter Date Column (header name or number)", "Date", &mapping.DateColumn},
+		{"Enter Date Format (Go layout)", "02.01.2006", &mapping.DateFormat},
+		{"Enter Amount Column (empty if split in debit and credit)", "Amount", &mapping.AmountColumn},
+		{"Enter Debit Column (empty if not split)", "", &mapping.DebitColumn},
+		{"Enter Credit Column (empty if not split)", "", &mapping.CreditColumn},
+		{"Enter Payee Column", "Payee", &mapping.PayeeColumn},
+		{"Enter Memo Column", "", &mapping.MemoColumn},
+		{"Enter Decimal Separator (. or ,)", ",", &mapping.DecimalSeparator},
+	}
+	for _, field := range fields {
+		fieldPrompt := promptui.Prompt{
+			Label: field.label,
+			Default: field.defaultValue,
+			AllowEdit: true,
+		}
+		*field.value, err = fieldPrompt.Run()
+		if err != nil {
+			return mapping, err
+		}
+	}
+	skipRows, err := promptAmount("Enter Number of Rows Before the Header", "0")
+	if err != nil {
+		return mapping, err
+	}
+	mapping.SkipRows = int(skipRows)
+	headerPrompt := promptui.Select{
+		Label: "Does the statement have a header row?",
+		Items: []string{"Yes", "No"},
+	}
+	_, hasHeader, err := headerPrompt.Run()
+	if err != nil {
+		return mapping, err
+	}
+	mapping.HasHeader = hasHeader == "Yes"
+	signPrompt := promptui.Select{
+		Label: "How are outgoing payments written?",
+		Items: []string{config.NegativeDebit, config.PositiveDebit},
+	}
+	_, mapping.SignConvention, err = signPrompt.Run()
+	if err != nil {
+		return mapping, err
+	}
+	err = config.SaveBankMapping(&mapping)
+	if err != nil {
+		return mapping, err
+	}
+	fmt.Printf("Bank mapping %s saved.\n", mapping.Name)
+	return mapping, nil
+}
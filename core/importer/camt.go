@@ -0,0 +1,166 @@
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+)
+
+// camtDate is a date element of camt.053 that holds either a date or a date and time.
+type camtDate struct {
+	Dt   string `xml:"Dt"`
+	DtTm string `xml:"DtTm"`
+}
+
+// camtParty is a debtor or creditor. Older versions of camt.053 put the name
+// directly in the party, newer ones in a nested Pty element.
+type camtParty struct {
+	Nm    string `xml:"Nm"`
+	PtyNm string `xml:"Pty>Nm"`
+}
+
+// camtAmount is an amount with its currency.
+type camtAmount struct {
+	Value string `xml:",chardata"`
+	Ccy   string `xml:"Ccy,attr"`
+}
+
+type camtTransactionDetails struct {
+	Amt         camtAmount `xml:"Amt"`
+	TxAmt       camtAmount `xml:"AmtDtls>TxAmt>Amt"`
+	AcctSvcrRef string     `xml:"Refs>AcctSvcrRef"`
+	EndToEndId  string     `xml:"Refs>EndToEndId"`
+	Dbtr        camtParty  `xml:"RltdPties>Dbtr"`
+	DbtrIBAN    string     `xml:"RltdPties>DbtrAcct>Id>IBAN"`
+	Cdtr        camtParty  `xml:"RltdPties>Cdtr"`
+	CdtrIBAN    string     `xml:"RltdPties>CdtrAcct>Id>IBAN"`
+	Ustrd       []string   `xml:"RmtInf>Ustrd"`
+	AddtlTxInf  string     `xml:"AddtlTxInf"`
+}
+
+type camtEntry struct {
+	Amt          camtAmount               `xml:"Amt"`
+	CdtDbtInd    string                   `xml:"CdtDbtInd"`
+	BookgDt      camtDate                 `xml:"BookgDt"`
+	ValDt        camtDate                 `xml:"ValDt"`
+	AcctSvcrRef  string                   `xml:"AcctSvcrRef"`
+	TxDtls       []camtTransactionDetails `xml:"NtryDtls>TxDtls"`
+	AddtlNtryInf string                   `xml:"AddtlNtryInf"`
+}
+
+type camtStatement struct {
+	Id      string      `xml:"Id"`
+	IBAN    string      `xml:"Acct>Id>IBAN"`
+	Entries []camtEntry `xml:"Ntry"`
+}
+
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+// ParseCamt053 reads an ISO 20022 camt.053 bank statement and returns its transactions.
+// Every version of the message is read, since the elements are matched without their namespace.
+// Entries that batch several transactions produce one transaction for each of them. The booking
+// and value dates are kept, the counterparty is the creditor of outgoing and the debtor of incoming
+// payments, and the unstructured remittance information becomes the memo. The ID is built from the
+// account and the reference given by the bank, or from the contents if the bank gives none.
+func ParseCamt053(r io.Reader) ([]config.TransactionStruct, error) {
+	var document camtDocument
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return Decode(input, charset)
+	}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	var transactions []config.TransactionStruct
+	for _, statement := range document.Statements {
+		for _, entry := range statement.Entries {
+			bookingDate, err := parseCamtDate(entry.BookgDt)
+			if err != nil {
+				return nil, err
+			}
+			valueDate, err := parseCamtDate(entry.ValDt)
+			if err != nil && (entry.ValDt.Dt != "" || entry.ValDt.DtTm != "") {
+				return nil, err
+			}
+			details := entry.TxDtls
+			if len(details) == 0 {
+				details = []camtTransactionDetails{{}}
+			}
+			for i, detail := range details {
+				amountText := entry.Amt.Value
+				if len(details) > 1 {
+					if detail.Amt.Value != "" {
+						amountText = detail.Amt.Value
+					} else if detail.TxAmt.Value != "" {
+						amountText = detail.TxAmt.Value
+					}
+				}
+				amount, err := ParseAmount(amountText, ".")
+				if err != nil {
+					return nil, err
+				}
+				transaction := config.TransactionStruct{
+					Date:   bookingDate.Format(config.DateFormat),
+					Amount: amount,
+					Source: "camt.053",
+				}
+				if !valueDate.IsZero() {
+					transaction.ValueDate = valueDate.Format(config.DateFormat)
+				}
+				counterparty, iban := detail.Dbtr, detail.DbtrIBAN
+				if entry.CdtDbtInd == "DBIT" {
+					transaction.Amount = -amount
+					counterparty, iban = detail.Cdtr, detail.CdtrIBAN
+				}
+				transaction.Payee = counterparty.Nm
+				if transaction.Payee == "" {
+					transaction.Payee = counterparty.PtyNm
+				}
+				transaction.IBAN = strings.ReplaceAll(iban, " ", "")
+				transaction.Memo = strings.TrimSpace(strings.Join(detail.Ustrd, " "))
+				if transaction.Memo == "" {
+					transaction.Memo = strings.TrimSpace(detail.AddtlTxInf)
+				}
+				if transaction.Memo == "" {
+					transaction.Memo = strings.TrimSpace(entry.AddtlNtryInf)
+				}
+				if reference := firstNonEmpty(detail.AcctSvcrRef, entry.AcctSvcrRef); reference != "" && reference != "NONREF" {
+					transaction.ID = fmt.Sprintf("camt:%s:%s:%d", firstNonEmpty(statement.IBAN, statement.Id), reference, i)
+				}
+				transactions = append(transactions, transaction)
+			}
+		}
+	}
+	AssignIDs(transactions)
+	return transactions, nil
+}
+
+// parseCamtDate returns the day of the given camt.053 date element.
+func parseCamtDate(date camtDate) (time.Time, error) {
+	value := date.Dt
+	if value == "" && len(date.DtTm) >= 10 {
+		value = date.DtTm[:10]
+	}
+	parsed, err := time.Parse(config.DateFormat, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", errors.ErrInvalidStatementDate, value)
+	}
+	return parsed, nil
+}
+
+// firstNonEmpty returns the first of the given values that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,151 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+)
+
+// ParseCSV reads a bank's CSV export with the given mapping and returns its transactions.
+// The dates are stored in config.DateFormat, outgoing payments get a negative amount whatever
+// the sign convention of the bank is, and every transaction gets an ID from AssignIDs.
+// Empty rows are skipped. If a row can not be parsed, the error names the row number.
+func ParseCSV(r io.Reader, mapping config.BankMapping) ([]config.TransactionStruct, error) {
+	decoded, err := Decode(r, mapping.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	// rows before the header are skipped as plain lines, they are often not valid CSV
+	buffered := bufio.NewReader(decoded)
+	for i := 0; i < mapping.SkipRows; i++ {
+		if _, err := buffered.ReadString('\n'); err != nil {
+			return nil, nil
+		}
+	}
+	reader := csv.NewReader(buffered)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	if mapping.Delimiter != "" {
+		reader.Comma, _ = utf8.DecodeRuneInString(strings.ReplaceAll(mapping.Delimiter, `\t`, "\t"))
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	rowOffset := mapping.SkipRows + 1
+
+	header := map[string]int{}
+	if mapping.HasHeader && len(records) > 0 {
+		for i, name := range records[0] {
+			header[strings.ToLower(strings.TrimSpace(name))] = i
+		}
+		records = records[1:]
+		rowOffset++
+	}
+	column := func(name string) (int, error) {
+		if name == "" {
+			return -1, nil
+		}
+		if index, err := strconv.Atoi(name); err == nil && index > 0 {
+			return index - 1, nil
+		}
+		if index, ok := header[strings.ToLower(strings.TrimSpace(name))]; ok {
+			return index, nil
+		}
+		return -1, fmt.Errorf("%w: %s", errors.ErrUnknownColumn, name)
+	}
+	dateColumn, err := column(mapping.DateColumn)
+	if err != nil {
+		return nil, err
+	}
+	amountColumn, err := column(mapping.AmountColumn)
+	if err != nil {
+		return nil, err
+	}
+	debitColumn, err := column(mapping.DebitColumn)
+	if err != nil {
+		return nil, err
+	}
+	creditColumn, err := column(mapping.CreditColumn)
+	if err != nil {
+		return nil, err
+	}
+	payeeColumn, err := column(mapping.PayeeColumn)
+	if err != nil {
+		return nil, err
+	}
+	memoColumn, err := column(mapping.MemoColumn)
+	if err != nil {
+		return nil, err
+	}
+	dateFormat := mapping.DateFormat
+	if dateFormat == "" {
+		dateFormat = config.DateFormat
+	}
+
+	var transactions []config.TransactionStruct
+	for i, record := range records {
+		row := i + rowOffset
+		field := func(index int) string {
+			if index < 0 || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		date, err := time.Parse(dateFormat, field(dateColumn))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		var amount float64
+		if debitColumn >= 0 || creditColumn >= 0 {
+			var debit, credit float64
+			if text := field(debitColumn); text != "" {
+				if debit, err = ParseAmount(text, mapping.DecimalSeparator); err != nil {
+					return nil, fmt.Errorf("row %d: %w", row, err)
+				}
+			}
+			if text := field(creditColumn); text != "" {
+				if credit, err = ParseAmount(text, mapping.DecimalSeparator); err != nil {
+					return nil, fmt.Errorf("row %d: %w", row, err)
+				}
+			}
+			amount = abs(credit) - abs(debit)
+		} else {
+			amount, err = ParseAmount(field(amountColumn), mapping.DecimalSeparator)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", row, err)
+			}
+			if mapping.SignConvention == config.PositiveDebit {
+				amount = -amount
+			}
+		}
+		transactions = append(transactions, config.TransactionStruct{
+			Date:   date.Format(config.DateFormat),
+			Amount: amount,
+			Payee:  field(payeeColumn),
+			Memo:   field(memoColumn),
+			Source: "csv:" + strings.ToLower(mapping.Name),
+		})
+	}
+	AssignIDs(transactions)
+	return transactions, nil
+}
+
+// abs returns the absolute value of the given amount.
+func abs(amount float64) float64 {
+	if amount < 0 {
+		return -amount
+	}
+	return amount
+}
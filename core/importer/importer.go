@@ -0,0 +1,181 @@
+package importer
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/table"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// Decode returns a reader that converts the given reader from the named text encoding to UTF-8.
+// An empty name means UTF-8. A UTF-8 byte order mark at the start of the text is removed.
+// If the encoding is not known, it returns ErrUnknownEncoding.
+func Decode(r io.Reader, name string) (io.Reader, error) {
+	var enc encoding.Encoding
+	switch strings.ToLower(strings.ReplaceAll(name, "_", "-")) {
+	case "", "utf-8", "utf8":
+		buffered := bufio.NewReader(r)
+		if bom, err := buffered.Peek(3); err == nil && string(bom) == "\xef\xbb\xbf" {
+			buffered.Discard(3)
+		}
+		return buffered, nil
+	case "windows-1252", "cp1252":
+		enc = charmap.Windows1252
+	case "iso-8859-1", "latin1", "latin-1":
+		enc = charmap.ISO8859_1
+	case "iso-8859-15", "latin9", "latin-9":
+		enc = charmap.ISO8859_15
+	case "windows-1257", "cp1257":
+		enc = charmap.Windows1257
+	case "iso-8859-13":
+		enc = charmap.ISO8859_13
+	case "utf-16", "utf-16le":
+		enc = unicode.UTF16(unicode.LittleEndian, unicode.UseBOM)
+	case "utf-16be":
+		enc = unicode.UTF16(unicode.BigEndian, unicode.UseBOM)
+	default:
+		return nil, fmt.Errorf("%w: %s", errors.ErrUnknownEncoding, name)
+	}
+	return enc.NewDecoder().Reader(r), nil
+}
+
+// ParseAmount parses an amount as written in bank statements with the given decimal separator.
+// Spaces, currency symbols and thousands separators are ignored, and a trailing minus sign or
+// parentheses around the amount make it negative, so "1.234,50 €", "-12.5" and "(7,00)" are all valid.
+func ParseAmount(text string, decimalSeparator string) (float64, error) {
+	if decimalSeparator == "" {
+		decimalSeparator = "."
+	}
+	thousandsSeparator := ","
+	if decimalSeparator == "," {
+		thousandsSeparator = "."
+	}
+	cleaned := strings.Map(func(r rune) rune {
+		switch {
+		case r >= '0' && r <= '9', r == '-', r == '+', r == '(', r == ')':
+			return r
+		case string(r) == decimalSeparator:
+			return '.'
+		}
+		return -1
+	}, strings.ReplaceAll(text, thousandsSeparator, ""))
+	negative := false
+	if strings.HasPrefix(cleaned, "(") && strings.HasSuffix(cleaned, ")") {
+		negative = true
+		cleaned = strings.Trim(cleaned, "()")
+	}
+	if strings.HasSuffix(cleaned, "-") {
+		negative = !negative
+		cleaned = strings.TrimSuffix(cleaned, "-")
+	}
+	var amount float64
+	if _, err := fmt.Sscanf(cleaned, "%g", &amount); err != nil || cleaned == "" {
+		return 0, fmt.Errorf("%w: %q", errors.ErrInvalidAmount, text)
+	}
+	if negative {
+		amount = -amount
+	}
+	return math.Round(amount*100) / 100, nil
+}
+
+// AssignIDs sets the ID of every transaction without one to a hash of its date, amount, payee and memo.
+// Identical transactions on the same day, like two coffees, are told apart by counting them,
+// so the same statement always produces the same IDs and importing it twice is safe.
+func AssignIDs(transactions []config.TransactionStruct) {
+	seen := map[string]int{}
+	for i, transaction := range transactions {
+		if transaction.ID != "" {
+			continue
+		}
+		key := fmt.Sprintf("%s|%.2f|%s|%s", transaction.Date, transaction.Amount, strings.ToLower(strings.TrimSpace(transaction.Payee)), strings.ToLower(strings.TrimSpace(transaction.Memo)))
+		seen[key]++
+		sum := sha1.Sum([]byte(fmt.Sprintf("%s|%d", key, seen[key])))
+		transactions[i].ID = fmt.Sprintf("%x", sum[:10])
+	}
+}
+
+// Deduplicate splits the incoming transactions into the ones that are new and the ones
+// that are already among the existing transactions or appear earlier in the incoming ones.
+func Deduplicate(existing, incoming []config.TransactionStruct) (fresh, duplicates []config.TransactionStruct) {
+	known := map[string]bool{}
+	for _, transaction := range existing {
+		known[transaction.ID] = true
+	}
+	for _, transaction := range incoming {
+		if known[transaction.ID] {
+			duplicates = append(duplicates, transaction)
+			continue
+		}
+		known[transaction.ID] = true
+		fresh = append(fresh, transaction)
+	}
+	return fresh, duplicates
+}
+
+// Categorize sets the category of every transaction that has none yet to the category of
+// the profile's expense whose name appears in the payee or memo of the transaction, so a
+// payment to "Netflix International" gets the category of the expense named "Netflix".
+func Categorize(ProfileData config.ProfileData, transactions []config.TransactionStruct) {
+	for i, transaction := range transactions {
+		if transaction.Category != "" {
+			continue
+		}
+		text := strings.ToLower(transaction.Payee + " " + transaction.Memo)
+		for _, expense := range ProfileData.Expenses {
+			if expense.Category != "" && expense.Name != "" && strings.Contains(text, strings.ToLower(expense.Name)) {
+				transactions[i].Category = expense.Category
+				break
+			}
+		}
+	}
+}
+
+// ShowPreview prints the parsed transactions of a statement before they are imported.
+// Transactions that are already among the existing transactions of the profile, or that
+// appear twice in the statement, are marked as duplicates and will not be imported.
+func ShowPreview(existing, incoming []config.TransactionStruct) {
+	known := map[string]bool{}
+	for _, transaction := range existing {
+		known[transaction.ID] = true
+	}
+	columns := []string{"Date", "Amount", "Payee", "Memo", "Category", "Status"}
+	var rows [][]string
+	duplicates := 0
+	for _, transaction := range incoming {
+		status := "new"
+		if known[transaction.ID] {
+			status = "duplicate"
+			duplicates++
+		}
+		known[transaction.ID] = true
+		rows = append(rows, []string{
+			transaction.Date,
+			fmt.Sprintf("%.2f€", transaction.Amount),
+			transaction.Payee,
+			shorten(transaction.Memo, 40),
+			transaction.Category,
+			status,
+		})
+	}
+	fmt.Println()
+	table.Print(columns, rows)
+	fmt.Printf("%d transactions parsed, %d new, %d duplicates.\n", len(incoming), len(incoming)-duplicates, duplicates)
+}
+
+// shorten cuts the given text to at most the given number of characters.
+func shorten(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length-1]) + "…"
+}
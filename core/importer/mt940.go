@@ -0,0 +1,169 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+)
+
+// mt940StatementLine matches the :61: field of an MT940 statement: value date, optional booking
+// date, debit or credit mark (with R for reversals), optional funds code, amount, transaction
+// type and the references of the customer and the bank.
+var mt940StatementLine = regexp.MustCompile(`^(\d{6})(\d{4})?(R?[DC])([A-Z])?([\d,]+)([A-Z][A-Z0-9]{3})?([^/]*)(?://(.*))?`)
+
+// mt940SubField matches the numbered sub fields like "?20" of structured :86: fields.
+var mt940SubField = regexp.MustCompile(`\?(\d{2})`)
+
+// ParseMT940 reads a SWIFT MT940 statement and returns its transactions.
+// Every :61: statement line becomes a transaction with its value date and its booking date,
+// and the following :86: field is read for the counterparty and the remittance text. Structured
+// :86: fields with "?20" style sub fields, as used by German banks, and "/NAME/" style keywords
+// are both understood, anything else is kept as the memo. The IDs are hashes of the contents.
+func ParseMT940(r io.Reader, encoding string) ([]config.TransactionStruct, error) {
+	decoded, err := Decode(r, encoding)
+	if err != nil {
+		return nil, err
+	}
+	var fields [][2]string
+	scanner := bufio.NewScanner(decoded)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		line = strings.TrimPrefix(line, "{4:")
+		if line == "" || line == "-" || line == "-}" || strings.HasPrefix(line, "{") {
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			if end := strings.Index(line[1:], ":"); end > 0 {
+				fields = append(fields, [2]string{line[1 : end+1], line[end+2:]})
+				continue
+			}
+		}
+		if len(fields) > 0 {
+			// continuation of a field over several lines
+			fields[len(fields)-1][1] += "\n" + line
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var transactions []config.TransactionStruct
+	var account string
+	for _, field := range fields {
+		switch field[0] {
+		case "25":
+			account = strings.TrimSpace(field[1])
+		case "61":
+			transaction, err := parseMT940StatementLine(strings.SplitN(field[1], "\n", 2)[0])
+			if err != nil {
+				return nil, err
+			}
+			transaction.Source = "mt940:" + account
+			transactions = append(transactions, transaction)
+		case "86":
+			if len(transactions) > 0 {
+				applyMT940Information(&transactions[len(transactions)-1], field[1])
+			}
+		}
+	}
+	AssignIDs(transactions)
+	return transactions, nil
+}
+
+// parseMT940StatementLine parses the :61: field of an MT940 statement.
+func parseMT940StatementLine(line string) (config.TransactionStruct, error) {
+	match := mt940StatementLine.FindStringSubmatch(strings.TrimSpace(line))
+	if match == nil {
+		return config.TransactionStruct{}, fmt.Errorf("%w: %q", errors.ErrInvalidStatementLine, line)
+	}
+	valueDate, err := time.Parse("060102", match[1])
+	if err != nil {
+		return config.TransactionStruct{}, fmt.Errorf("%w: %q", errors.ErrInvalidStatementDate, match[1])
+	}
+	bookingDate := valueDate
+	if match[2] != "" {
+		bookingDate, err = time.Parse("20060102", fmt.Sprintf("%d%s", valueDate.Year(), match[2]))
+		if err != nil {
+			return config.TransactionStruct{}, fmt.Errorf("%w: %q", errors.ErrInvalidStatementDate, match[2])
+		}
+		// a booking in January can belong to a value date in December and the other way around
+		if bookingDate.Sub(valueDate) > 180*24*time.Hour {
+			bookingDate = bookingDate.AddDate(-1, 0, 0)
+		} else if valueDate.Sub(bookingDate) > 180*24*time.Hour {
+			bookingDate = bookingDate.AddDate(1, 0, 0)
+		}
+	}
+	amount, err := ParseAmount(match[5], ",")
+	if err != nil {
+		return config.TransactionStruct{}, err
+	}
+	// debits and reversed credits take money from the account
+	if match[3] == "D" || match[3] == "RC" {
+		amount = -amount
+	}
+	return config.TransactionStruct{
+		Date:      bookingDate.Format(config.DateFormat),
+		ValueDate: valueDate.Format(config.DateFormat),
+		Amount:    amount,
+	}, nil
+}
+
+// applyMT940Information sets the counterparty, IBAN and memo of the transaction from an :86: field.
+func applyMT940Information(transaction *config.TransactionStruct, information string) {
+	information = strings.ReplaceAll(information, "\n", "")
+	if mt940SubField.MatchString(information) {
+		subFields := map[int]string{}
+		matches := mt940SubField.FindAllStringSubmatchIndex(information, -1)
+		for i, match := range matches {
+			end := len(information)
+			if i+1 < len(matches) {
+				end = matches[i+1][0]
+			}
+			var code int
+			fmt.Sscanf(information[match[2]:match[3]], "%d", &code)
+			subFields[code] += information[match[1]:end]
+		}
+		var codes []int
+		for code := range subFields {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+		var remittance, name []string
+		for _, code := range codes {
+			switch {
+			case code >= 20 && code <= 29, code >= 60 && code <= 63:
+				remittance = append(remittance, subFields[code])
+			case code == 32 || code == 33:
+				name = append(name, subFields[code])
+			case code == 31:
+				transaction.IBAN = strings.TrimSpace(subFields[code])
+			}
+		}
+		transaction.Payee = strings.TrimSpace(strings.Join(name, ""))
+		transaction.Memo = strings.TrimSpace(strings.Join(remittance, ""))
+		return
+	}
+	if strings.HasPrefix(information, "/") {
+		parts := strings.Split(information, "/")
+		for i := 1; i+1 < len(parts); i += 2 {
+			switch parts[i] {
+			case "NAME":
+				transaction.Payee = strings.TrimSpace(parts[i+1])
+			case "IBAN":
+				transaction.IBAN = strings.TrimSpace(parts[i+1])
+			case "REMI":
+				transaction.Memo = strings.TrimSpace(parts[i+1])
+			}
+		}
+		if transaction.Memo != "" || transaction.Payee != "" {
+			return
+		}
+	}
+	transaction.Memo = strings.TrimSpace(information)
+}
@@ -0,0 +1,128 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+)
+
+// ParseOFX reads an OFX or QFX statement and returns its transactions.
+// Both the SGML based OFX 1.x and the XML based OFX 2.x are read, since the
+// elements are only told apart by their tags. The ID of every transaction is
+// built from the account and the FITID given by the bank, so importing an
+// overlapping statement twice does not add the same transaction again.
+func ParseOFX(r io.Reader) ([]config.TransactionStruct, error) {
+	data, err := io.ReadAll(bufio.NewReader(r))
+	if err != nil {
+		return nil, err
+	}
+	text := string(data)
+	if start := strings.Index(strings.ToUpper(text), "<OFX>"); start >= 0 {
+		// OFX 1.x headers name the character set of the file
+		header := strings.ToUpper(text[:start])
+		encoding := ""
+		if strings.Contains(header, "CHARSET:1252") {
+			encoding = "windows-1252"
+		} else if strings.Contains(header, "CHARSET:ISO-8859-1") {
+			encoding = "iso-8859-1"
+		}
+		text = text[start:]
+		if encoding != "" {
+			decoded, err := Decode(strings.NewReader(text), encoding)
+			if err != nil {
+				return nil, err
+			}
+			data, err := io.ReadAll(decoded)
+			if err != nil {
+				return nil, err
+			}
+			text = string(data)
+		}
+	}
+
+	var transactions []config.TransactionStruct
+	var current map[string]string
+	var account string
+	for len(text) > 0 {
+		open := strings.IndexByte(text, '<')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(text[open:], '>')
+		if end < 0 {
+			break
+		}
+		tag := strings.ToUpper(strings.TrimSpace(text[open+1 : open+end]))
+		text = text[open+end+1:]
+		next := strings.IndexByte(text, '<')
+		if next < 0 {
+			next = len(text)
+		}
+		value := strings.TrimSpace(html.UnescapeString(text[:next]))
+
+		switch {
+		case tag == "STMTTRN":
+			current = map[string]string{}
+		case tag == "/STMTTRN":
+			if current != nil {
+				transaction, err := ofxTransaction(current, account)
+				if err != nil {
+					return nil, err
+				}
+				transactions = append(transactions, transaction)
+			}
+			current = nil
+		case tag == "ACCTID" && current == nil:
+			account = value
+		case current != nil && !strings.HasPrefix(tag, "/") && value != "":
+			current[tag] = value
+		}
+	}
+	AssignIDs(transactions)
+	return transactions, nil
+}
+
+// ofxTransaction converts the elements of a single STMTTRN aggregate to a transaction.
+func ofxTransaction(fields map[string]string, account string) (config.TransactionStruct, error) {
+	date, err := parseOFXDate(fields["DTPOSTED"])
+	if err != nil {
+		return config.TransactionStruct{}, err
+	}
+	decimalSeparator := "."
+	if strings.Contains(fields["TRNAMT"], ",") && !strings.Contains(fields["TRNAMT"], ".") {
+		decimalSeparator = ","
+	}
+	amount, err := ParseAmount(fields["TRNAMT"], decimalSeparator)
+	if err != nil {
+		return config.TransactionStruct{}, err
+	}
+	payee := fields["NAME"]
+	if payee == "" {
+		payee = fields["PAYEE"]
+	}
+	transaction := config.TransactionStruct{
+		Date:   date.Format(config.DateFormat),
+		Amount: amount,
+		Payee:  payee,
+		Memo:   fields["MEMO"],
+		Source: "ofx",
+	}
+	if fitid := fields["FITID"]; fitid != "" {
+		transaction.ID = "ofx:" + account + ":" + fitid
+	}
+	return transaction, nil
+}
+
+// parseOFXDate parses an OFX date like "20261019", "20261019120000" or
+// "20261019120000.000[-5:EST]". Only the day is kept.
+func parseOFXDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("%w: %q", errors.ErrInvalidStatementDate, value)
+	}
+	return time.Parse("20060102", value[:8])
+}
@@ -0,0 +1,91 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+)
+
+// DefaultQIFDateFormat is the date layout used by most QIF exports, month first.
+const DefaultQIFDateFormat string = "1/2/2006"
+
+// ParseQIF reads a QIF statement and returns its transactions.
+// Dates are read with the given Go layout, or DefaultQIFDateFormat if it is empty.
+// The apostrophe some programs write before the year, as in "1/31'26", and two digit
+// years are both accepted. The category of a transaction ("L" line) is kept, with the
+// brackets of transfers to other accounts removed. QIF has no transaction identifiers,
+// so the IDs are hashes of the transactions' contents from AssignIDs.
+func ParseQIF(r io.Reader, dateFormat string, decimalSeparator string) ([]config.TransactionStruct, error) {
+	if dateFormat == "" {
+		dateFormat = DefaultQIFDateFormat
+	}
+	var transactions []config.TransactionStruct
+	current := config.TransactionStruct{Source: "qif"}
+	hasFields := false
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		code, value := line[0], strings.TrimSpace(line[1:])
+		switch code {
+		case '!':
+			// type and option headers
+		case 'D':
+			date, err := parseQIFDate(value, dateFormat)
+			if err != nil {
+				return nil, err
+			}
+			current.Date = date.Format(config.DateFormat)
+			hasFields = true
+		case 'T', 'U':
+			amount, err := ParseAmount(value, decimalSeparator)
+			if err != nil {
+				return nil, err
+			}
+			current.Amount = amount
+			hasFields = true
+		case 'P':
+			current.Payee = value
+			hasFields = true
+		case 'M':
+			current.Memo = value
+			hasFields = true
+		case 'L':
+			current.Category = strings.Trim(value, "[]")
+			hasFields = true
+		case '^':
+			if hasFields {
+				transactions = append(transactions, current)
+			}
+			current = config.TransactionStruct{Source: "qif"}
+			hasFields = false
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if hasFields {
+		transactions = append(transactions, current)
+	}
+	AssignIDs(transactions)
+	return transactions, nil
+}
+
+// parseQIFDate parses a QIF date with the given layout, accepting an apostrophe
+// before the year, spaces used as padding and two digit years.
+func parseQIFDate(value string, dateFormat string) (time.Time, error) {
+	normalized := strings.ReplaceAll(strings.ReplaceAll(value, " ", ""), "'", "/")
+	if date, err := time.Parse(dateFormat, normalized); err == nil {
+		return date, nil
+	}
+	if date, err := time.Parse(strings.Replace(dateFormat, "2006", "06", 1), normalized); err == nil {
+		return date, nil
+	}
+	return time.Time{}, fmt.Errorf("%w: %q", errors.ErrInvalidStatementDate, value)
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"wallkeiro/core/config"
+	"wallkeiro/core/expenses"
+
+	"github.com/manifoldco/promptui"
+)
+
+// InflationMenu lets the user set the global and per-category inflation rates of the
+// given profile, and shows the expenses and calculation projected to next year.
+// Expenses are assigned to a category in "Edit Expenses".
+func InflationMenu(selectedProfile string) error {
+	prompt := promptui.Select{
+		Label: "Select Inflation Action",
+		Items: []string{"Show Next Year Projection", "Set Global Inflation Rate", "Set Category Inflation Rate"},
+	}
+	_, actionSelector, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+	var category string
+	switch actionSelector {
+	case "Show Next Year Projection":
+		profileData, err := config.ReadProfile(selectedProfile)
+		if err != nil {
+			return err
+		}
+		expenses.ShowProjection(profileData)
+		return nil
+	case "Set Category Inflation Rate":
+		categoryPrompt := promptui.Prompt{
+			Label: "Enter Category",
+		}
+		category, err = categoryPrompt.Run()
+		if err != nil {
+			return err
+		}
+	}
+	rate, err := promptAmount("Enter Yearly Inflation Rate (%)", "")
+	if err != nil {
+		return err
+	}
+	return config.SetInflation(selectedProfile, category, rate)
+}
+
+// hasInflation reports whether the given profile has a global or any category inflation rate set.
+func hasInflation(profileData config.ProfileData) bool {
+	return profileData.Config.Inflation != 0 || len(profileData.Config.CategoryInflation) > 0
+}
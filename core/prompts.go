@@ -0,0 +1,137 @@
+package core
+
+import (
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+	"github.com/manifoldco/promptui"
+
+	"fmt"
+)
+
+// promptSalary asks the user for the salary type and the salary amount.
+// It returns the entered amount, the selected salary type, and an error if
+// there was an error running the prompts or parsing the amount.
+func promptSalary() (float64, config.SalaryType, error) {
+	prompt := promptui.Select{
+		Label: "Select Salary Type",
+		Items: []config.SalaryType{config.Fixed, config.Hourly},
+	}
+	_, salaryType, err := prompt.Run()
+	if err != nil {
+		return 0, "", err
+	}
+	var salaryValuePrompt promptui.Prompt
+	if salaryType == config.Fixed.String() {
+		salaryValuePrompt = promptui.Prompt{
+			Label: "Enter Fixed Salary Amount",
+		}
+	} else {
+		salaryValuePrompt = promptui.Prompt{
+			Label: "Enter Hourly Wage Amount",
+		}
+	}
+	salaryValueStr, err := salaryValuePrompt.Run()
+	if err != nil {
+		return 0, "", err
+	}
+	var salaryValue float64
+	_, err = fmt.Sscanf(salaryValueStr, "%f", &salaryValue)
+	if err != nil {
+		return 0, "", err
+	}
+	return salaryValue, config.SalaryType(salaryType), nil
+}
+
+// promptSavingLevel asks the user for a saving level between 1 and 4.
+// It returns the entered level, and an error if there was an error running
+// the prompt or parsing the level.
+func promptSavingLevel() (int, error) {
+	prompt := promptui.Prompt{
+		Label: "Enter New Saving Level (1-4)",
+		Default: "1",
+		Validate: func(input string) error {
+			var level int
+			_, err := fmt.Sscanf(input, "%d", &level)
+			if err != nil || (level < 1 || level > 4) {
+				return errors.ErrLevelTooHigh
+			}
+			return nil
+		},
+	}
+	levelStr, err := prompt.Run()
+	if err != nil {
+		return 0, err
+	}
+	var level int
+	_, err = fmt.Sscanf(levelStr, "%d", &level)
+	if err != nil {
+		return 0, err
+	}
+	return level, nil
+}
+
+// promptExpense asks the user for the name and the amount of a new expense.
+// It returns the entered name and amount, and an error if there was an error
+// running the prompts or parsing the amount.
+func promptExpense() (string, float64, error) {
+	promptName := promptui.Prompt{
+		Label: "Enter Expense Name",
+	}
+	expenseName, err := promptName.Run()
+	if err != nil {
+		return "", 0, err
+	}
+	expenseAmount, err := promptAmount("Enter Expense Amount", "")
+	if err != nil {
+		return "", 0, err
+	}
+	return expenseName, expenseAmount, nil
+}
+
+// promptAmount asks the user for a number with the given label and default value.
+// It returns the entered number, and an error if there was an error running
+// the prompt or parsing the number.
+func promptAmount(label, defaultValue string) (float64, error) {
+	prompt := promptui.Prompt{
+		Label: label,
+		Default: defaultValue,
+	}
+	amountStr, err := prompt.Run()
+	if err != nil {
+		return 0, err
+	}
+	var amount float64
+	_, err = fmt.Sscanf(amountStr, "%f", &amount)
+	if err != nil {
+		return 0, err
+	}
+	return amount, nil
+}
+
+// promptDay asks the user for a day of the month with the given label and default value,
+// asking again until the day is between 1 and 31.
+// It returns the entered day, and an error if there was an error running the prompt.
+func promptDay(label, defaultValue string) (int, error) {
+	prompt := promptui.Prompt{
+		Label: label,
+		Default: defaultValue,
+		Validate: func(input string) error {
+			var day int
+			_, err := fmt.Sscanf(input, "%d", &day)
+			if err != nil || day < 1 || day > 31 {
+				return errors.ErrInvalidDueDay
+			}
+			return nil
+		},
+	}
+	dayStr, err := prompt.Run()
+	if err != nil {
+		return 0, err
+	}
+	var day int
+	_, err = fmt.Sscanf(dayStr, "%d", &day)
+	if err != nil {
+		return 0, err
+	}
+	return day, nil
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"wallkeiro/core/config"
+	"wallkeiro/core/expenses"
+
+	"github.com/manifoldco/promptui"
+
+	"fmt"
+)
+
+// RecurringMenu detects recurring payments in the imported transactions of the given profile
+// and proposes them as new expenses, or as updates of existing ones. The user can accept every
+// proposal at once or review them one by one, and nothing is saved before that.
+func RecurringMenu(selectedProfile string) error {
+	profileData, err := config.ReadProfile(selectedProfile)
+	if err != nil {
+		return err
+	}
+	proposals := expenses.DetectRecurring(profileData)
+	if len(proposals) == 0 {
+		fmt.Println("No new recurring payments found.")
+		return nil
+	}
+	expenses.ShowRecurring(profileData, proposals)
+	prompt := promptui.Select{
+		Label: fmt.Sprintf("%d recurring payments found", len(proposals)),
+		Items: []string{"Accept All", "Review One by One", "Cancel"},
+	}
+	_, actionSelector, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+	accepted := 0
+	for _, proposal := range proposals {
+		if actionSelector == "Cancel" {
+			return nil
+		}
+		if actionSelector == "Review One by One" {
+			reviewPrompt := promptui.Select{
+				Label: fmt.Sprintf("%s %.2f€ %s on day %d", proposal.Expense.Name, proposal.Expense.Amount, proposal.Expense.Frequency, proposal.Expense.DueDay),
+				Items: []string{"Accept", "Skip"},
+			}
+			_, review, err := reviewPrompt.Run()
+			if err != nil {
+				return err
+			}
+			if review != "Accept" {
+				continue
+			}
+		}
+		profileData = expenses.ApplyRecurring(profileData, proposal)
+		accepted++
+	}
+	err = config.UpdateProfile(selectedProfile, &profileData)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d recurring expenses added or updated.\n", accepted)
+	return nil
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/report"
+
+	"github.com/manifoldco/promptui"
+
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// ReportMenu writes the monthly report of the given profile to an HTML or PDF file, or both.
+// The user selects the month, which defaults to the current one, and the path of the files,
+// which defaults to the profile name and the month in the current folder.
+func ReportMenu(selectedProfile string) error {
+	monthPrompt := promptui.Prompt{
+		Label: "Enter Month (YYYY-MM)",
+		Default: time.Now().Format(report.MonthFormat),
+		AllowEdit: true,
+		Validate: func(input string) error {
+			if _, err := time.ParseInLocation(report.MonthFormat, input, time.Local); err != nil {
+				return errors.ErrInvalidMonth
+			}
+			return nil
+		},
+	}
+	monthStr, err := monthPrompt.Run()
+	if err != nil {
+		return err
+	}
+	month, err := time.ParseInLocation(report.MonthFormat, monthStr, time.Local)
+	if err != nil {
+		return errors.ErrInvalidMonth
+	}
+	prompt := promptui.Select{
+		Label: "Select Report Format",
+		Items: []string{"HTML", "PDF", "HTML and PDF"},
+	}
+	_, formatSelector, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+	profileData, err := config.ReadProfile(selectedProfile)
+	if err != nil {
+		return err
+	}
+	monthlyReport := report.Build(selectedProfile, profileData, month)
+	pathPrompt := promptui.Prompt{
+		Label: "Enter File Path (without extension)",
+		Default: fmt.Sprintf("%s-%s", strings.ToLower(selectedProfile), monthStr),
+	}
+	path, err := pathPrompt.Run()
+	if err != nil {
+		return err
+	}
+	writers := map[string]func(w io.Writer, monthlyReport report.Report) error{
+		".html": report.HTML,
+		".pdf":  report.PDF,
+	}
+	for _, extension := range []string{".html", ".pdf"} {
+		if !strings.Contains(formatSelector, strings.ToUpper(extension[1:])) {
+			continue
+		}
+		file, err := os.Create(path + extension)
+		if err != nil {
+			return err
+		}
+		err = writers[extension](file, monthlyReport)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		err = file.Close()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Report for %s written to %s.\n", month.Format("January 2006"), path+extension)
+	}
+	return nil
+}
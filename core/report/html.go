@@ -0,0 +1,174 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"io"
+	"math"
+	"strings"
+)
+
+// chartColors are the colors of the series of a chart, in order.
+var chartColors = []string{"#3b6ea5", "#e08e45", "#5a9e6f", "#b5485d"}
+
+// chartRow is a labelled group of bars of a chart, one bar for every series.
+type chartRow struct {
+	Label  string
+	Values []float64
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"money": money,
+	"month": func(report Report) string {
+		return report.Month.Format("January 2006")
+	},
+	"percent": func(value float64) string {
+		return fmt.Sprintf("%.0f%%", value*100)
+	},
+	"change": func(change Change) string {
+		percent, ok := change.Percent()
+		if !ok {
+			return "new"
+		}
+		return fmt.Sprintf("%+.0f%%", percent)
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Wallkeiro report {{.Report.Profile}} {{month .Report}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; color: #222; max-width: 820px; margin: 2em auto; padding: 0 1em; }
+h1 { margin-bottom: 0; }
+h2 { border-bottom: 2px solid #3b6ea5; padding-bottom: 0.2em; margin-top: 2em; }
+.generated { color: #777; margin-top: 0.2em; }
+table { border-collapse: collapse; width: 100%; margin: 1em 0; }
+th, td { padding: 0.35em 0.6em; border-bottom: 1px solid #ddd; text-align: left; }
+td.amount, th.amount { text-align: right; font-variant-numeric: tabular-nums; }
+tr.total td { font-weight: bold; border-top: 2px solid #222; }
+.up { color: #b5485d; }
+.down { color: #5a9e6f; }
+svg { display: block; margin: 1em 0; }
+@media print { body { margin: 0; } h2 { break-after: avoid; } }
+</style>
+</head>
+<body>
+<h1>{{.Report.Profile}}: {{month .Report}}</h1>
+<p class="generated">Generated on {{.Report.Generated.Format "2006-01-02 15:04"}}</p>
+
+<h2>Income</h2>
+{{.IncomeChart}}
+<table>
+<tr><th>Source</th><th class="amount">Amount</th></tr>
+{{range .Report.Income}}<tr><td>{{.Label}}</td><td class="amount">{{money .Value}}</td></tr>
+{{end}}<tr class="total"><td>Total</td><td class="amount">{{money .Report.TotalIncome}}</td></tr>
+</table>
+
+<h2>Expenses by Category</h2>
+{{.ExpensesChart}}
+<table>
+<tr><th>Category</th><th class="amount">Planned</th><th class="amount">Spent</th></tr>
+{{range .Report.Expenses}}<tr><td>{{.Category}}</td><td class="amount">{{money .Planned}}</td><td class="amount">{{money .Spent}}</td></tr>
+{{end}}<tr class="total"><td>Total</td><td class="amount">{{money .Report.TotalPlanned}}</td><td class="amount">{{money .Report.TotalSpent}}</td></tr>
+</table>
+
+<h2>Calculation</h2>
+<table>
+<tr><td>Salary</td><td class="amount">{{money .Report.Calculation.Salary}}</td></tr>
+<tr><td>Total Expenses</td><td class="amount">{{money .Report.Calculation.TotalExpenses}}</td></tr>
+{{if .Report.Calculation.DebtPayments}}<tr><td>Minimum Debt Payments (included in expenses)</td><td class="amount">{{money .Report.Calculation.DebtPayments}}</td></tr>
+{{end}}<tr><td>Desired Final Balance</td><td class="amount">{{money .Report.Calculation.DesiredFinalBalance}}</td></tr>
+<tr><td>Remaining Amount after Expenses and Desired Balance</td><td class="amount">{{money .Report.Calculation.RemainingAmount}}</td></tr>
+<tr class="total"><td>Suggested Withdrawn Amount</td><td class="amount">{{money .Report.Calculation.WithdrawnAmount}}</td></tr>
+</table>
+
+{{if .Report.Goals}}<h2>Savings Goals</h2>
+{{.GoalsChart}}
+<table>
+<tr><th>Goal</th><th class="amount">Saved</th><th class="amount">Target</th><th class="amount">Progress</th><th>Deadline</th><th class="amount">Monthly Needed</th></tr>
+{{range .Report.Goals}}<tr><td>{{.Goal.Name}}</td><td class="amount">{{money .Goal.Saved}}</td><td class="amount">{{money .Goal.Target}}</td><td class="amount">{{percent .Progress}}</td><td>{{.Goal.Deadline}}</td><td class="amount">{{if .MonthsLeft}}{{money .MonthlyNeeded}}{{else}}-{{end}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if .Report.Changes}}<h2>Changes from Last Month</h2>
+{{.ChangesChart}}
+<table>
+<tr><th>Category</th><th class="amount">Last Month</th><th class="amount">This Month</th><th class="amount">Change</th></tr>
+{{range .Report.Changes}}<tr><td>{{.Category}}</td><td class="amount">{{money .Previous}}</td><td class="amount">{{money .Current}}</td><td class="amount {{if gt .Difference 0.0}}up{{else if lt .Difference 0.0}}down{{end}}">{{change .}}</td></tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+`))
+
+// HTML writes the report to w as a single HTML file. The charts are inline SVG
+// and the styles are embedded, so the file can be archived or mailed on its own.
+func HTML(w io.Writer, report Report) error {
+	var income, expenses, goals, changes []chartRow
+	for _, amount := range report.Income {
+		income = append(income, chartRow{Label: amount.Label, Values: []float64{amount.Value}})
+	}
+	for _, expense := range report.Expenses {
+		expenses = append(expenses, chartRow{Label: expense.Category, Values: []float64{expense.Planned, expense.Spent}})
+	}
+	for _, goal := range report.Goals {
+		goals = append(goals, chartRow{Label: goal.Goal.Name, Values: []float64{goal.Progress * 100}})
+	}
+	for _, change := range report.Changes {
+		changes = append(changes, chartRow{Label: change.Category, Values: []float64{change.Previous, change.Current}})
+	}
+	return htmlTemplate.Execute(w, map[string]interface{}{
+		"Report":        report,
+		"IncomeChart":   barChart(income, []string{"Income"}, "€", 0),
+		"ExpensesChart": barChart(expenses, []string{"Planned", "Spent"}, "€", 0),
+		"GoalsChart":    barChart(goals, []string{"Progress"}, "%", 100),
+		"ChangesChart":  barChart(changes, []string{"Last Month", "This Month"}, "€", 0),
+	})
+}
+
+// barChart returns an SVG chart of horizontal bars with a bar for every series in every row,
+// and a legend if there is more than one series. The bars are scaled to the largest value,
+// or to max if it is set.
+func barChart(rows []chartRow, series []string, unit string, max float64) template.HTML {
+	if len(rows) == 0 {
+		return ""
+	}
+	const width, labelWidth, barHeight, gap = 780.0, 170.0, 14.0, 10.0
+	if max == 0 {
+		for _, row := range rows {
+			for _, value := range row.Values {
+				max = math.Max(max, value)
+			}
+		}
+	}
+	if max <= 0 {
+		max = 1
+	}
+	legend := 0.0
+	if len(series) > 1 {
+		legend = 24
+	}
+	groupHeight := float64(len(series))*barHeight + gap
+	height := legend + float64(len(rows))*groupHeight
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="Helvetica, Arial, sans-serif" font-size="12">`, width, height, width, height)
+	if len(series) > 1 {
+		for i, name := range series {
+			x := labelWidth + float64(i)*120
+			fmt.Fprintf(&svg, `<rect x="%.0f" y="4" width="12" height="12" fill="%s"/><text x="%.0f" y="14">%s</text>`, x, chartColors[i%len(chartColors)], x+16, html.EscapeString(name))
+		}
+	}
+	barSpace := width - labelWidth - 90
+	for i, row := range rows {
+		y := legend + float64(i)*groupHeight
+		fmt.Fprintf(&svg, `<text x="%.0f" y="%.1f" text-anchor="end">%s</text>`, labelWidth-8, y+float64(len(series))*barHeight/2+4, html.EscapeString(row.Label))
+		for j, value := range row.Values {
+			barWidth := math.Max(value, 0) / max * barSpace
+			barY := y + float64(j)*barHeight
+			fmt.Fprintf(&svg, `<rect x="%.0f" y="%.1f" width="%.1f" height="%.0f" fill="%s"/>`, labelWidth, barY, barWidth, barHeight-2, chartColors[j%len(chartColors)])
+			fmt.Fprintf(&svg, `<text x="%.1f" y="%.1f" fill="#555">%.0f%s</text>`, labelWidth+barWidth+4, barY+barHeight-4, value, unit)
+		}
+	}
+	svg.WriteString(`</svg>`)
+	return template.HTML(svg.String())
+}
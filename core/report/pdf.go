@@ -0,0 +1,273 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Page size and margins of the PDF report in points, A4 portrait.
+const (
+	pdfPageWidth  float64 = 595
+	pdfPageHeight float64 = 842
+	pdfMargin     float64 = 50
+)
+
+// pdfWidths are the widths of the characters of Helvetica in thousandths of the font size,
+// for the characters numbers are written with. Other characters count as an average width.
+var pdfWidths = map[rune]float64{
+	' ': 278, '.': 278, ',': 278, '-': 333, '+': 584, '%': 889, '€': 556,
+	'0': 556, '1': 556, '2': 556, '3': 556, '4': 556, '5': 556, '6': 556, '7': 556, '8': 556, '9': 556,
+}
+
+// pdfDocument builds the pages of a PDF from text and rectangles, top to bottom.
+type pdfDocument struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+// PDF writes the report to w as a PDF document. It shows the same tables as the HTML
+// report, with the amounts drawn as bars, in the standard Helvetica fonts so that no
+// fonts have to be embedded. Text is encoded as Windows-1252, which includes the euro sign.
+func PDF(w io.Writer, report Report) error {
+	document := &pdfDocument{}
+	document.newPage()
+	document.text(pdfMargin, 20, true, fmt.Sprintf("%s: %s", report.Profile, report.Month.Format("January 2006")))
+	document.text(pdfMargin, 10, false, "Generated on "+report.Generated.Format("2006-01-02 15:04"))
+
+	var rows [][]string
+	var bars [][]float64
+	for _, income := range report.Income {
+		rows = append(rows, []string{income.Label, money(income.Value)})
+		bars = append(bars, []float64{income.Value})
+	}
+	rows = append(rows, []string{"Total", money(report.TotalIncome())})
+	document.section("Income", []string{"Source", "Amount"}, rows, bars)
+
+	rows, bars = nil, nil
+	for _, expense := range report.Expenses {
+		rows = append(rows, []string{expense.Category, money(expense.Planned), money(expense.Spent)})
+		bars = append(bars, []float64{expense.Planned, expense.Spent})
+	}
+	rows = append(rows, []string{"Total", money(report.TotalPlanned()), money(report.TotalSpent())})
+	document.section("Expenses by Category", []string{"Category", "Planned", "Spent"}, rows, bars)
+
+	calculation := report.Calculation
+	rows = [][]string{
+		{"Salary", money(calculation.Salary)},
+		{"Total Expenses", money(calculation.TotalExpenses)},
+		{"Desired Final Balance", money(calculation.DesiredFinalBalance)},
+		{"Remaining Amount after Expenses and Desired Balance", money(calculation.RemainingAmount)},
+		{"Suggested Withdrawn Amount", money(calculation.WithdrawnAmount)},
+	}
+	document.section("Calculation", []string{"Item", "Amount"}, rows, nil)
+
+	if len(report.Goals) > 0 {
+		rows, bars = nil, nil
+		for _, goal := range report.Goals {
+			monthly := "-"
+			if goal.MonthsLeft > 0 {
+				monthly = money(goal.MonthlyNeeded)
+			}
+			rows = append(rows, []string{goal.Goal.Name, money(goal.Goal.Saved), money(goal.Goal.Target), fmt.Sprintf("%.0f%%", goal.Progress*100), monthly})
+			bars = append(bars, []float64{goal.Progress * 100})
+		}
+		document.section("Savings Goals", []string{"Goal", "Saved", "Target", "Progress", "Monthly Needed"}, rows, bars)
+	}
+
+	if len(report.Changes) > 0 {
+		rows, bars = nil, nil
+		for _, change := range report.Changes {
+			difference := "new"
+			if percent, ok := change.Percent(); ok {
+				difference = fmt.Sprintf("%+.0f%%", percent)
+			}
+			rows = append(rows, []string{change.Category, money(change.Previous), money(change.Current), difference})
+			bars = append(bars, []float64{change.Previous, change.Current})
+		}
+		document.section("Changes from Last Month", []string{"Category", "Last Month", "This Month", "Change"}, rows, bars)
+	}
+	return document.write(w)
+}
+
+// section draws a section title and a table, with the first column left and the others right
+// aligned. If bars are given, every row is followed by a bar for each of its values, scaled to
+// the largest value of the section, and a last row without bars is drawn as the total.
+func (d *pdfDocument) section(title string, columns []string, rows [][]string, bars [][]float64) {
+	const rowHeight, barHeight = 16.0, 5.0
+	d.space(60)
+	d.y -= 14
+	d.text(pdfMargin, 14, true, title)
+	d.y -= 4
+	max := 0.0
+	for _, values := range bars {
+		for _, value := range values {
+			max = math.Max(max, value)
+		}
+	}
+	columnX := func(i int) float64 {
+		if i == 0 {
+			return pdfMargin
+		}
+		// the first column takes half the width, the others share the rest
+		return pdfMargin + (pdfPageWidth-2*pdfMargin)*(0.5+0.5*float64(i)/float64(len(columns)-1))
+	}
+	drawRow := func(cells []string, bold bool) {
+		d.space(rowHeight)
+		d.y -= rowHeight
+		for i, cell := range cells {
+			if i == 0 {
+				d.textAt(columnX(0), d.y, 10, bold, cell)
+			} else {
+				d.textAt(columnX(i)-textWidth(cell, 10), d.y, 10, bold, cell)
+			}
+		}
+	}
+	drawRow(columns, true)
+	d.line(d.y - 4)
+	for i, cells := range rows {
+		total := bars != nil && i >= len(bars)
+		if total {
+			d.line(d.y - 4)
+		}
+		drawRow(cells, total)
+		if total || max <= 0 {
+			continue
+		}
+		for j, value := range bars[i] {
+			d.space(barHeight + 1)
+			d.y -= barHeight + 1
+			width := math.Max(value, 0) / max * (pdfPageWidth - 2*pdfMargin)
+			color := chartColors[j%len(chartColors)]
+			d.rect(pdfMargin, d.y, width, barHeight, color)
+		}
+		d.y -= 2
+	}
+	d.y -= 10
+}
+
+// newPage starts a new page with the cursor at its top margin.
+func (d *pdfDocument) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pdfPageHeight - pdfMargin
+}
+
+// space starts a new page if less than the given height is left on the current one.
+func (d *pdfDocument) space(height float64) {
+	if d.y-height < pdfMargin {
+		d.newPage()
+	}
+}
+
+// text moves the cursor down a line of the given font size and draws the text at x.
+func (d *pdfDocument) text(x, size float64, bold bool, text string) {
+	d.space(size * 1.4)
+	d.y -= size * 1.4
+	d.textAt(x, d.y, size, bold, text)
+}
+
+// textAt draws the text with its baseline at the given position.
+func (d *pdfDocument) textAt(x, y, size float64, bold bool, text string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.pages[len(d.pages)-1], "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, d.escape(text))
+}
+
+// rect draws a filled rectangle in the given "#rrggbb" color.
+func (d *pdfDocument) rect(x, y, width, height float64, color string) {
+	var r, g, b int
+	fmt.Sscanf(color, "#%02x%02x%02x", &r, &g, &b)
+	fmt.Fprintf(d.pages[len(d.pages)-1], "%.3f %.3f %.3f rg %.2f %.2f %.2f %.2f re f 0 g\n",
+		float64(r)/255, float64(g)/255, float64(b)/255, x, y, width, height)
+}
+
+// line draws a thin horizontal line across the page at the given height.
+func (d *pdfDocument) line(y float64) {
+	fmt.Fprintf(d.pages[len(d.pages)-1], "0.5 w %.2f %.2f m %.2f %.2f l S\n", pdfMargin, y, pdfPageWidth-pdfMargin, y)
+}
+
+// escape encodes the text as Windows-1252, with a question mark for the characters
+// it does not have, and escapes it for a PDF string.
+func (d *pdfDocument) escape(text string) string {
+	var encoded strings.Builder
+	for _, r := range text {
+		b, ok := charmap.Windows1252.EncodeRune(r)
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			encoded.WriteByte('\\')
+			encoded.WriteByte(b)
+		case r == '\r' || r == '\n':
+			encoded.WriteByte(' ')
+		case ok:
+			encoded.WriteByte(b)
+		default:
+			encoded.WriteByte('?')
+		}
+	}
+	return encoded.String()
+}
+
+// write writes the document with its catalog, page tree, fonts and cross-reference table.
+func (d *pdfDocument) write(w io.Writer) error {
+	var objects []string
+	pageCount := len(d.pages)
+	// objects 1 and 2 are the catalog and the page tree, 3 and 4 the fonts,
+	// followed by a page and its content stream for every page
+	var kids []string
+	for i := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 5+2*i))
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), pageCount),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+	)
+	for i, page := range d.pages {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+				pdfPageWidth, pdfPageHeight, 6+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()),
+		)
+	}
+
+	var output bytes.Buffer
+	output.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	var offsets []int
+	for i, object := range objects {
+		offsets = append(offsets, output.Len())
+		fmt.Fprintf(&output, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := output.Len()
+	fmt.Fprintf(&output, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&output, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&output, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	_, err := w.Write(output.Bytes())
+	return err
+}
+
+// textWidth returns the width in points of the text in Helvetica of the given size.
+func textWidth(text string, size float64) float64 {
+	width := 0.0
+	for _, r := range text {
+		if w, ok := pdfWidths[r]; ok {
+			width += w
+		} else {
+			width += 556
+		}
+	}
+	return width * size / 1000
+}
+
+// money formats an amount in euros.
+func money(value float64) string {
+	return fmt.Sprintf("%.2f€", value)
+}
@@ -0,0 +1,182 @@
+package report
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/expenses"
+)
+
+// MonthFormat is the layout of the month a report is made for.
+const MonthFormat string = "2006-01"
+
+// Uncategorized is the category shown for expenses and transactions without one.
+const Uncategorized string = "Uncategorized"
+
+// Amount is a labelled amount of a report.
+type Amount struct {
+	Label string
+	Value float64
+}
+
+// CategoryAmount is the planned monthly amount of the expenses of a category
+// and the amount actually spent on it in the month of the report.
+type CategoryAmount struct {
+	Category string
+	Planned  float64
+	Spent    float64
+}
+
+// Change is the amount spent on a category in the month of the report and the month before.
+type Change struct {
+	Category string
+	Previous float64
+	Current  float64
+}
+
+// Difference returns how much more was spent than the month before.
+func (c Change) Difference() float64 {
+	return c.Current - c.Previous
+}
+
+// Percent returns the difference as a percentage of the month before, and false if
+// nothing was spent the month before.
+func (c Change) Percent() (float64, bool) {
+	if c.Previous == 0 {
+		return 0, false
+	}
+	return c.Difference() / c.Previous * 100, true
+}
+
+// Report holds everything shown in the monthly report of a profile.
+type Report struct {
+	Profile     string
+	Month       time.Time
+	Generated   time.Time
+	Income      []Amount
+	Expenses    []CategoryAmount
+	Calculation expenses.Calculation
+	Goals       []expenses.GoalProgress
+	Changes     []Change
+}
+
+// TotalIncome returns the sum of the income of the report.
+func (r Report) TotalIncome() float64 {
+	total := 0.0
+	for _, income := range r.Income {
+		total += income.Value
+	}
+	return total
+}
+
+// TotalPlanned returns the sum of the planned expenses of the report.
+func (r Report) TotalPlanned() float64 {
+	total := 0.0
+	for _, expense := range r.Expenses {
+		total += expense.Planned
+	}
+	return total
+}
+
+// TotalSpent returns the sum of the amounts spent in the month of the report.
+func (r Report) TotalSpent() float64 {
+	total := 0.0
+	for _, expense := range r.Expenses {
+		total += expense.Spent
+	}
+	return total
+}
+
+// Build collects the monthly report of the given profile for the month of the given day.
+// The income is the money received in the month by category, or the salary if no income was
+// imported for it. Expenses compare the expense list, by category, with what the imported
+// transactions show was spent, and the changes compare that spending with the month before.
+// The calculation is the one of Calculate as of the end of the month, or today for the
+// current month, and the goals show their progress on the same day.
+func Build(profileName string, ProfileData config.ProfileData, month time.Time) Report {
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.Local)
+	day := start.AddDate(0, 1, -1)
+	now := time.Now()
+	if now.Before(day) {
+		day = now
+	}
+	report := Report{
+		Profile:     profileName,
+		Month:       start,
+		Generated:   now,
+		Calculation: expenses.ComputeAt(ProfileData, day),
+	}
+
+	income := map[string]float64{}
+	spent := map[string]float64{}
+	previous := map[string]float64{}
+	current := start.Format(MonthFormat)
+	before := start.AddDate(0, -1, 0).Format(MonthFormat)
+	for _, transaction := range ProfileData.Transactions {
+		category := categoryName(transaction.Category)
+		switch {
+		case !strings.HasPrefix(transaction.Date, current) && !strings.HasPrefix(transaction.Date, before):
+		case transaction.Amount > 0 && strings.HasPrefix(transaction.Date, current):
+			income[category] += transaction.Amount
+		case transaction.Amount < 0 && strings.HasPrefix(transaction.Date, current):
+			spent[category] -= transaction.Amount
+		case transaction.Amount < 0:
+			previous[category] -= transaction.Amount
+		}
+	}
+	for _, category := range sortedKeys(income) {
+		report.Income = append(report.Income, Amount{Label: category, Value: income[category]})
+	}
+	if len(report.Income) == 0 && ProfileData.Config.Salary > 0 {
+		report.Income = append(report.Income, Amount{Label: "Salary", Value: ProfileData.Config.Salary})
+	}
+
+	planned := map[string]float64{}
+	for _, expense := range ProfileData.Expenses {
+		planned[categoryName(expense.Category)] += expense.MonthlyAmount()
+	}
+	categories := map[string]float64{}
+	for category := range planned {
+		categories[category] = 0
+	}
+	for category := range spent {
+		categories[category] = 0
+	}
+	for _, category := range sortedKeys(categories) {
+		report.Expenses = append(report.Expenses, CategoryAmount{Category: category, Planned: planned[category], Spent: spent[category]})
+	}
+
+	for category := range previous {
+		categories[category] = 0
+	}
+	for _, category := range sortedKeys(categories) {
+		if spent[category] == 0 && previous[category] == 0 {
+			continue
+		}
+		report.Changes = append(report.Changes, Change{Category: category, Previous: previous[category], Current: spent[category]})
+	}
+
+	for _, goal := range ProfileData.Goals {
+		report.Goals = append(report.Goals, expenses.CalculateGoal(goal, day))
+	}
+	return report
+}
+
+// categoryName returns the category, or Uncategorized if it is empty.
+func categoryName(category string) string {
+	if strings.TrimSpace(category) == "" {
+		return Uncategorized
+	}
+	return strings.TrimSpace(category)
+}
+
+// sortedKeys returns the keys of the map in alphabetical order.
+func sortedKeys(values map[string]float64) []string {
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,195 @@
+package rpc
+
+import (
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/expenses"
+	"wallkeiro/core/rpc/wallkeiropb"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The functions in this file convert between the types of wallkeiro and their protobuf messages.
+
+func profileToProto(name string, p config.ProfileData) *wallkeiropb.Profile {
+	profile := &wallkeiropb.Profile{Name: name, Config: configToProto(p.Config)}
+	for _, expense := range p.Expenses {
+		profile.Expenses = append(profile.Expenses, expenseToProto(expense))
+	}
+	for _, debt := range p.Debts {
+		profile.Debts = append(profile.Debts, debtToProto(debt))
+	}
+	for _, goal := range p.Goals {
+		profile.Goals = append(profile.Goals, goalToProto(goal))
+	}
+	for _, transaction := range p.Transactions {
+		profile.Transactions = append(profile.Transactions, &wallkeiropb.Transaction{
+			Id:        transaction.ID,
+			Date:      transaction.Date,
+			ValueDate: transaction.ValueDate,
+			Amount:    transaction.Amount,
+			Payee:     transaction.Payee,
+			Iban:      transaction.IBAN,
+			Memo:      transaction.Memo,
+			Category:  transaction.Category,
+			Tags:      transaction.Tags,
+			Source:    transaction.Source,
+		})
+	}
+	for _, rule := range p.Rules {
+		profile.Rules = append(profile.Rules, &wallkeiropb.Rule{
+			Name:         rule.Name,
+			Priority:     int32(rule.Priority),
+			Payee:        rule.Payee,
+			MinAmount:    rule.MinAmount,
+			MaxAmount:    rule.MaxAmount,
+			Iban:         rule.IBAN,
+			MemoKeywords: rule.MemoKeywords,
+			Category:     rule.Category,
+			Tags:         rule.Tags,
+		})
+	}
+	return profile
+}
+
+func profileFromProto(profile *wallkeiropb.Profile) config.ProfileData {
+	p := config.ProfileData{
+		Config:       configFromProto(profile.GetConfig()),
+		Expenses:     []config.ExpensesStuct{},
+		Debts:        []config.DebtStruct{},
+		Goals:        []config.GoalStruct{},
+		Transactions: []config.TransactionStruct{},
+		Rules:        []config.RuleStruct{},
+	}
+	for _, expense := range profile.GetExpenses() {
+		p.Expenses = append(p.Expenses, expenseFromProto(expense))
+	}
+	for _, debt := range profile.GetDebts() {
+		p.Debts = append(p.Debts, config.DebtStruct{
+			Name:           debt.GetName(),
+			Balance:        debt.GetBalance(),
+			APR:            debt.GetApr(),
+			MinimumPayment: debt.GetMinimumPayment(),
+		})
+	}
+	for _, goal := range profile.GetGoals() {
+		p.Goals = append(p.Goals, goalFromProto(goal))
+	}
+	for _, transaction := range profile.GetTransactions() {
+		p.Transactions = append(p.Transactions, config.TransactionStruct{
+			ID:        transaction.GetId(),
+			Date:      transaction.GetDate(),
+			ValueDate: transaction.GetValueDate(),
+			Amount:    transaction.GetAmount(),
+			Payee:     transaction.GetPayee(),
+			IBAN:      transaction.GetIban(),
+			Memo:      transaction.GetMemo(),
+			Category:  transaction.GetCategory(),
+			Tags:      transaction.GetTags(),
+			Source:    transaction.GetSource(),
+		})
+	}
+	for _, rule := range profile.GetRules() {
+		p.Rules = append(p.Rules, config.RuleStruct{
+			Name:         rule.GetName(),
+			Priority:     int(rule.GetPriority()),
+			Payee:        rule.GetPayee(),
+			MinAmount:    rule.GetMinAmount(),
+			MaxAmount:    rule.GetMaxAmount(),
+			IBAN:         rule.GetIban(),
+			MemoKeywords: rule.GetMemoKeywords(),
+			Category:     rule.GetCategory(),
+			Tags:         rule.GetTags(),
+		})
+	}
+	return p
+}
+
+func configToProto(c config.ConfigStruct) *wallkeiropb.Config {
+	return &wallkeiropb.Config{
+		Salary:            c.Salary,
+		SalaryType:        string(c.SalaryType),
+		Level:             int32(c.SavingLevel),
+		Payday:            int32(c.Payday),
+		Savings:           c.Savings,
+		EmergencyMonths:   int32(c.EmergencyMonths),
+		Inflation:         c.Inflation,
+		CategoryInflation: c.CategoryInflation,
+	}
+}
+
+func configFromProto(c *wallkeiropb.Config) config.ConfigStruct {
+	return config.ConfigStruct{
+		Salary:            c.GetSalary(),
+		SalaryType:        config.SalaryType(c.GetSalaryType()),
+		SavingLevel:       int(c.GetLevel()),
+		Payday:            int(c.GetPayday()),
+		Savings:           c.GetSavings(),
+		EmergencyMonths:   int(c.GetEmergencyMonths()),
+		Inflation:         c.GetInflation(),
+		CategoryInflation: c.GetCategoryInflation(),
+	}
+}
+
+func expenseToProto(e config.ExpensesStuct) *wallkeiropb.Expense {
+	return &wallkeiropb.Expense{
+		Id:            e.ID,
+		Name:          e.Name,
+		Amount:        e.Amount,
+		Discretionary: e.Discretionary,
+		Category:      e.Category,
+		Frequency:     string(e.Frequency),
+		DueDay:        int32(e.DueDay),
+		DueMonth:      int32(e.DueMonth),
+	}
+}
+
+func expenseFromProto(e *wallkeiropb.Expense) config.ExpensesStuct {
+	return config.ExpensesStuct{
+		ID:            e.GetId(),
+		Name:          e.GetName(),
+		Amount:        e.GetAmount(),
+		Discretionary: e.GetDiscretionary(),
+		Category:      e.GetCategory(),
+		Frequency:     config.Frequency(e.GetFrequency()),
+		DueDay:        int(e.GetDueDay()),
+		DueMonth:      int(e.GetDueMonth()),
+	}
+}
+
+func debtToProto(d config.DebtStruct) *wallkeiropb.Debt {
+	return &wallkeiropb.Debt{Name: d.Name, Balance: d.Balance, Apr: d.APR, MinimumPayment: d.MinimumPayment}
+}
+
+func goalToProto(g config.GoalStruct) *wallkeiropb.Goal {
+	return &wallkeiropb.Goal{Name: g.Name, Target: g.Target, Saved: g.Saved, Deadline: g.Deadline}
+}
+
+func goalFromProto(g *wallkeiropb.Goal) config.GoalStruct {
+	return config.GoalStruct{Name: g.GetName(), Target: g.GetTarget(), Saved: g.GetSaved(), Deadline: g.GetDeadline()}
+}
+
+func calculationToProto(c expenses.Calculation) *wallkeiropb.Calculation {
+	return &wallkeiropb.Calculation{
+		Salary:              c.Salary,
+		TotalExpenses:       c.TotalExpenses,
+		DebtPayments:        c.DebtPayments,
+		DesiredFinalBalance: c.DesiredFinalBalance,
+		RemainingAmount:     c.RemainingAmount,
+		WithdrawnAmount:     c.WithdrawnAmount,
+		LowestBalance:       c.LowestBalance,
+		LowestBalanceDate:   timestamp(c.LowestBalanceDate),
+	}
+}
+
+func paymentToProto(p expenses.Payment) *wallkeiropb.Payment {
+	return &wallkeiropb.Payment{Date: timestamp(p.Date), Name: p.Name, Amount: p.Amount, Balance: p.Balance}
+}
+
+// timestamp returns the time as a protobuf timestamp, or nil for the zero time.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
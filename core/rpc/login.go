@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"wallkeiro/core/auth"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// storeKey is the key of the auth.Store of the logged in user in the context of a call.
+type storeKey struct{}
+
+// storeOf returns the auth.Store of the user who made the call, through which every
+// profile is read and written, so the permissions of the user are always checked.
+func storeOf(ctx context.Context) auth.Store {
+	store, _ := ctx.Value(storeKey{}).(auth.Store)
+	return store
+}
+
+// login logs in the user of a call with its authorization metadata.
+func login(ctx context.Context) (context.Context, error) {
+	var authorization string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			authorization = values[0]
+		}
+	}
+	store, err := auth.Login(authorization)
+	if err != nil {
+		return nil, failure(err)
+	}
+	return context.WithValue(ctx, storeKey{}, store), nil
+}
+
+func loginUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := login(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func loginStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := login(stream.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &loggedInStream{stream, ctx})
+}
+
+// loggedInStream is a server stream whose context carries the store of the logged in user.
+type loggedInStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loggedInStream) Context() context.Context {
+	return s.ctx
+}
+
+// authorization sends the authorization metadata with every call.
+type authorization string
+
+func (a authorization) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": string(a)}, nil
+}
+
+func (a authorization) RequireTransportSecurity() bool {
+	return false
+}
+
+// WithToken logs in to the server with an API token.
+func WithToken(token string) grpc.DialOption {
+	return grpc.WithPerRPCCredentials(authorization("Bearer " + token))
+}
+
+// WithPassword logs in to the server with a user name and password.
+func WithPassword(user, password string) grpc.DialOption {
+	return grpc.WithPerRPCCredentials(authorization("Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+password))))
+}
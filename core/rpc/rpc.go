@@ -0,0 +1,87 @@
+// Package rpc serves the gRPC API of wallkeiro described in wallkeiropb/wallkeiro.proto.
+// It offers the operations of the REST API to Go services, typed, together with a
+// stream of updates of a profile.
+package rpc
+
+import (
+	goerrors "errors"
+	"io/fs"
+	"log"
+	"net"
+	"os"
+	"time"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/rpc/wallkeiropb"
+	"wallkeiro/core/validate"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultAddress is the address the gRPC API is served on by default, reachable from this computer only.
+const DefaultAddress string = "127.0.0.1:8422"
+
+// WatchInterval is how often WatchProfile looks for changes of the profile it watches.
+var WatchInterval time.Duration = time.Second
+
+// Server implements the gRPC API. Calls are served concurrently: the storage writes a changed
+// profile only if it is still the version the change was made to (see config.Backend), and
+// the call fails with codes.Aborted otherwise.
+type Server struct {
+	wallkeiropb.UnimplementedWallkeiroServer
+}
+
+// NewServer returns a gRPC server with the wallkeiro API registered. Every call has to
+// log in with authorization metadata once there are user accounts, see auth.Login.
+func NewServer() *grpc.Server {
+	server := grpc.NewServer(grpc.UnaryInterceptor(loginUnary), grpc.StreamInterceptor(loginStream))
+	wallkeiropb.RegisterWallkeiroServer(server, &Server{})
+	return server
+}
+
+// Serve serves the gRPC API on the given address until it fails.
+func Serve(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	log.Printf("Serving the wallkeiro gRPC API on %s", address)
+	return NewServer().Serve(listener)
+}
+
+// Dial connects to the gRPC API served on the given address, and returns the
+// connection, which has to be closed, and a client using it. Pass WithToken or
+// WithPassword to log in to a server with user accounts.
+func Dial(address string, options ...grpc.DialOption) (*grpc.ClientConn, wallkeiropb.WallkeiroClient, error) {
+	options = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, options...)
+	conn, err := grpc.NewClient(address, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, wallkeiropb.NewWallkeiroClient(conn), nil
+}
+
+// failure returns the error as a gRPC status with the code that fits it: unauthenticated
+// for failed logins, permission denied for changes to profiles shared for reading, not found
+// for missing profiles and expenses, already exists for existing ones, and invalid argument
+// for invalid input.
+func failure(err error) error {
+	switch {
+	case goerrors.Is(err, errors.ErrUnauthorized):
+		return status.Error(codes.Unauthenticated, errors.ErrUnauthorized.Error())
+	case goerrors.Is(err, errors.ErrForbidden):
+		return status.Error(codes.PermissionDenied, errors.ErrForbidden.Error())
+	case goerrors.Is(err, fs.ErrNotExist), goerrors.Is(err, errors.ErrNotFound):
+		return status.Error(codes.NotFound, errors.ErrNotFound.Error())
+	case goerrors.Is(err, os.ErrExist), goerrors.Is(err, errors.ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, errors.ErrAlreadyExists.Error())
+	case goerrors.Is(err, errors.ErrProfileChanged):
+		return status.Error(codes.Aborted, errors.ErrProfileChanged.Error())
+	case validate.InvalidInput(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
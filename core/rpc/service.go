@@ -0,0 +1,257 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/expenses"
+	"wallkeiro/core/rpc/wallkeiropb"
+	"wallkeiro/core/validate"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *Server) ListProfiles(ctx context.Context, req *wallkeiropb.ListProfilesRequest) (*wallkeiropb.ListProfilesResponse, error) {
+	profiles, err := storeOf(ctx).Profiles()
+	if err != nil {
+		return nil, failure(err)
+	}
+	return &wallkeiropb.ListProfilesResponse{Names: profiles}, nil
+}
+
+func (s *Server) GetProfile(ctx context.Context, req *wallkeiropb.ProfileRequest) (*wallkeiropb.Profile, error) {
+	profileData, err := storeOf(ctx).Read(req.GetName())
+	if err != nil {
+		return nil, failure(err)
+	}
+	return profileToProto(strings.ToLower(req.GetName()), profileData), nil
+}
+
+func (s *Server) CreateProfile(ctx context.Context, req *wallkeiropb.ProfileRequest) (*wallkeiropb.Profile, error) {
+	if err := validate.Name(req.GetName()); err != nil {
+		return nil, failure(err)
+	}
+	if err := storeOf(ctx).Create(req.GetName()); err != nil {
+		return nil, failure(err)
+	}
+	return s.GetProfile(ctx, req)
+}
+
+func (s *Server) UpdateProfile(ctx context.Context, req *wallkeiropb.UpdateProfileRequest) (*wallkeiropb.Profile, error) {
+	name := req.GetProfile().GetName()
+	if _, err := storeOf(ctx).Read(name); err != nil {
+		return nil, failure(err)
+	}
+	profileData := profileFromProto(req.GetProfile())
+	if err := validate.Profile(profileData); err != nil {
+		return nil, failure(err)
+	}
+	if err := storeOf(ctx).Update(name, &profileData); err != nil {
+		return nil, failure(err)
+	}
+	return profileToProto(strings.ToLower(name), profileData), nil
+}
+
+func (s *Server) RenameProfile(ctx context.Context, req *wallkeiropb.RenameProfileRequest) (*wallkeiropb.Profile, error) {
+	if err := validate.Name(req.GetNewName()); err != nil {
+		return nil, failure(err)
+	}
+	if err := storeOf(ctx).Rename(req.GetName(), req.GetNewName()); err != nil {
+		return nil, failure(err)
+	}
+	return s.GetProfile(ctx, &wallkeiropb.ProfileRequest{Name: req.GetNewName()})
+}
+
+func (s *Server) DeleteProfile(ctx context.Context, req *wallkeiropb.ProfileRequest) (*emptypb.Empty, error) {
+	if err := storeOf(ctx).Delete(req.GetName()); err != nil {
+		return nil, failure(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) UpdateConfig(ctx context.Context, req *wallkeiropb.UpdateConfigRequest) (*wallkeiropb.Config, error) {
+	configData := configFromProto(req.GetConfig())
+	if err := validate.Config(configData); err != nil {
+		return nil, failure(err)
+	}
+	err := s.change(ctx, req.GetName(), func(p *config.ProfileData) error {
+		p.Config = configData
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return configToProto(configData), nil
+}
+
+func (s *Server) AddExpense(ctx context.Context, req *wallkeiropb.AddExpenseRequest) (*wallkeiropb.Expense, error) {
+	expense := expenseFromProto(req.GetExpense())
+	if err := validate.Expense(expense); err != nil {
+		return nil, failure(err)
+	}
+	err := s.change(ctx, req.GetName(), func(p *config.ProfileData) error {
+		p.Expenses = append(p.Expenses, expense)
+		config.NewIDs(p)
+		expense = p.Expenses[len(p.Expenses)-1]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return expenseToProto(expense), nil
+}
+
+func (s *Server) UpdateExpense(ctx context.Context, req *wallkeiropb.UpdateExpenseRequest) (*wallkeiropb.Expense, error) {
+	expense := expenseFromProto(req.GetExpense())
+	if err := validate.Expense(expense); err != nil {
+		return nil, failure(err)
+	}
+	err := s.change(ctx, req.GetName(), func(p *config.ProfileData) error {
+		index := expenseIndex(p.Expenses, req.GetId(), req.GetIndex())
+		if index < 0 {
+			return errors.ErrNotFound
+		}
+		// an id naming another expense than the one found was edited from an older profile
+		if expense.ID != "" && expense.ID != p.Expenses[index].ID {
+			return errors.ErrProfileChanged
+		}
+		expense.ID = p.Expenses[index].ID
+		p.Expenses[index] = expense
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return expenseToProto(expense), nil
+}
+
+func (s *Server) DeleteExpense(ctx context.Context, req *wallkeiropb.DeleteExpenseRequest) (*emptypb.Empty, error) {
+	err := s.change(ctx, req.GetName(), func(p *config.ProfileData) error {
+		index := expenseIndex(p.Expenses, req.GetId(), req.GetIndex())
+		if index < 0 {
+			return errors.ErrNotFound
+		}
+		p.Expenses = append(p.Expenses[:index], p.Expenses[index+1:]...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) Calculate(ctx context.Context, req *wallkeiropb.ProfileRequest) (*wallkeiropb.Calculation, error) {
+	profileData, err := storeOf(ctx).Read(req.GetName())
+	if err != nil {
+		return nil, failure(err)
+	}
+	return calculationToProto(expenses.Compute(profileData)), nil
+}
+
+func (s *Server) Upcoming(ctx context.Context, req *wallkeiropb.UpcomingRequest) (*wallkeiropb.PaymentList, error) {
+	profileData, err := storeOf(ctx).Read(req.GetName())
+	if err != nil {
+		return nil, failure(err)
+	}
+	days := int(req.GetDays())
+	if days < 0 {
+		return nil, failure(errors.ErrInvalidRequest)
+	}
+	if days == 0 {
+		days = 30
+	}
+	list := &wallkeiropb.PaymentList{}
+	for _, payment := range expenses.Upcoming(profileData, time.Now(), days) {
+		list.Payments = append(list.Payments, paymentToProto(payment))
+	}
+	return list, nil
+}
+
+func (s *Server) CashFlow(ctx context.Context, req *wallkeiropb.ProfileRequest) (*wallkeiropb.CashFlowCalendar, error) {
+	profileData, err := storeOf(ctx).Read(req.GetName())
+	if err != nil {
+		return nil, failure(err)
+	}
+	day := time.Now()
+	result := expenses.ComputeAt(profileData, day)
+	calendar := &wallkeiropb.CashFlowCalendar{}
+	for _, cashFlowDay := range expenses.CashFlow(profileData, expenses.LastPayday(profileData, day), result.WithdrawnAmount) {
+		protoDay := &wallkeiropb.CashFlowDay{Date: timestamp(cashFlowDay.Date), Balance: cashFlowDay.Balance}
+		for _, payment := range cashFlowDay.Payments {
+			protoDay.Payments = append(protoDay.Payments, paymentToProto(payment))
+		}
+		calendar.Days = append(calendar.Days, protoDay)
+	}
+	return calendar, nil
+}
+
+func (s *Server) EmergencyFund(ctx context.Context, req *wallkeiropb.ProfileRequest) (*wallkeiropb.EmergencyFundStatus, error) {
+	profileData, err := storeOf(ctx).Read(req.GetName())
+	if err != nil {
+		return nil, failure(err)
+	}
+	fund := expenses.CalculateEmergencyFund(profileData)
+	return &wallkeiropb.EmergencyFundStatus{
+		Savings:           fund.Savings,
+		EssentialExpenses: fund.EssentialExpenses,
+		MonthsCovered:     fund.MonthsCovered,
+		TargetMonths:      int32(fund.TargetMonths),
+		Target:            fund.Target,
+		Gap:               fund.Gap,
+		Contribution:      fund.Contribution,
+		MonthsToTarget:    int32(fund.MonthsToTarget),
+	}, nil
+}
+
+func (s *Server) GoalProgress(ctx context.Context, req *wallkeiropb.ProfileRequest) (*wallkeiropb.GoalProgressList, error) {
+	profileData, err := storeOf(ctx).Read(req.GetName())
+	if err != nil {
+		return nil, failure(err)
+	}
+	list := &wallkeiropb.GoalProgressList{}
+	for _, goal := range profileData.Goals {
+		progress := expenses.CalculateGoal(goal, time.Now())
+		list.Goals = append(list.Goals, &wallkeiropb.GoalProgress{
+			Goal:          goalToProto(progress.Goal),
+			Progress:      progress.Progress,
+			Missing:       progress.Missing,
+			MonthsLeft:    int32(progress.MonthsLeft),
+			MonthlyNeeded: progress.MonthlyNeeded,
+		})
+	}
+	return list, nil
+}
+
+// expenseIndex returns the position of the expense with the id, or when the id is empty the index
+// if it is in range. It returns -1 if there is no such expense.
+func expenseIndex(expenses []config.ExpensesStuct, id string, index int32) int {
+	if id != "" {
+		for i, expense := range expenses {
+			if expense.ID == id {
+				return i
+			}
+		}
+		return -1
+	}
+	if index < 0 || int(index) >= len(expenses) {
+		return -1
+	}
+	return int(index)
+}
+
+// change reads the profile, changes it and saves it, returning the error as a gRPC status.
+func (s *Server) change(ctx context.Context, name string, change func(p *config.ProfileData) error) error {
+	profileData, err := storeOf(ctx).Read(name)
+	if err != nil {
+		return failure(err)
+	}
+	if err := change(&profileData); err != nil {
+		return failure(err)
+	}
+	if err := storeOf(ctx).Update(name, &profileData); err != nil {
+		return failure(err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"wallkeiro/core/config"
+	"wallkeiro/core/rpc/wallkeiropb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testClient makes a temporary folder the working directory, with a profiles folder, and
+// returns a client of the gRPC API served in memory.
+func testClient(t *testing.T) wallkeiropb.WallkeiroClient {
+	t.Helper()
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(workingDirectory) })
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(config.ProfilesFolder, 0755); err != nil {
+		t.Fatal(err)
+	}
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer()
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	conn, client, err := Dial("passthrough:///wallkeiro", grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return client
+}
+
+// TestComputeInParallel calculates profiles of different saving levels in concurrent calls,
+// so run with -race it fails if one call changes what the other computes with.
+func TestComputeInParallel(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+	want := map[string]float64{"careful": 190, "relaxed": 100}
+	for name, level := range map[string]int32{"careful": 1, "relaxed": 4} {
+		if _, err := client.CreateProfile(ctx, &wallkeiropb.ProfileRequest{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+		settings := &wallkeiropb.Config{Salary: 2000, SalaryType: string(config.Fixed), Level: level, Payday: 1}
+		if _, err := client.UpdateConfig(ctx, &wallkeiropb.UpdateConfigRequest{Name: name, Config: settings}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	for name := range want {
+		name := name
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			for round := 0; round < 20; round++ {
+				calculation, err := client.Calculate(ctx, &wallkeiropb.ProfileRequest{Name: name})
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if calculation.GetDesiredFinalBalance() != want[name] {
+					t.Errorf("%s: desired final balance %v, want %v", name, calculation.GetDesiredFinalBalance(), want[name])
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for round := 0; round < 20; round++ {
+				if _, err := client.CashFlow(ctx, &wallkeiropb.ProfileRequest{Name: name}); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			stream, err := client.WatchProfile(ctx, &wallkeiropb.ProfileRequest{Name: name})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			update, err := stream.Recv()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if update.GetCalculation().GetDesiredFinalBalance() != want[name] {
+				t.Errorf("%s: watched desired final balance %v, want %v", name, update.GetCalculation().GetDesiredFinalBalance(), want[name])
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestExpensesByID(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+	if _, err := client.CreateProfile(ctx, &wallkeiropb.ProfileRequest{Name: "home"}); err != nil {
+		t.Fatal(err)
+	}
+	rent, err := client.AddExpense(ctx, &wallkeiropb.AddExpenseRequest{Name: "home", Expense: &wallkeiropb.Expense{Name: "Rent", Amount: 900}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	food, err := client.AddExpense(ctx, &wallkeiropb.AddExpenseRequest{Name: "home", Expense: &wallkeiropb.Expense{Name: "Food", Amount: 300}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rent.GetId() == "" || food.GetId() == "" || rent.GetId() == food.GetId() {
+		t.Fatalf("added expenses have the ids %q and %q", rent.GetId(), food.GetId())
+	}
+
+	// deleting the rent moves the food to the front, where it is still found by its id
+	if _, err := client.DeleteExpense(ctx, &wallkeiropb.DeleteExpenseRequest{Name: "home", Id: rent.GetId()}); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := client.UpdateExpense(ctx, &wallkeiropb.UpdateExpenseRequest{Name: "home", Id: food.GetId(), Expense: &wallkeiropb.Expense{Name: "Food", Amount: 350}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.GetId() != food.GetId() || saved.GetAmount() != 350 {
+		t.Fatalf("saved %v, want the food with an amount of 350", saved)
+	}
+
+	// an expense giving the id of another expense than the one at the index is refused
+	if _, err := client.AddExpense(ctx, &wallkeiropb.AddExpenseRequest{Name: "home", Expense: &wallkeiropb.Expense{Name: "Rent", Amount: 950}}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.UpdateExpense(ctx, &wallkeiropb.UpdateExpenseRequest{Name: "home", Index: 1, Expense: &wallkeiropb.Expense{Id: food.GetId(), Name: "Food", Amount: 400}})
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("updating with the id of another expense: got %v, want %v", err, codes.Aborted)
+	}
+	_, err = client.DeleteExpense(ctx, &wallkeiropb.DeleteExpenseRequest{Name: "home", Id: rent.GetId()})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("deleting a deleted expense: got %v, want %v", err, codes.NotFound)
+	}
+}
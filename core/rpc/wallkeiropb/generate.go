@@ -0,0 +1,5 @@
+// Package wallkeiropb is the generated Go client and server code of the gRPC API
+// described in wallkeiro.proto. Use NewWallkeiroClient to call a wallkeiro server.
+package wallkeiropb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative wallkeiro.proto
@@ -0,0 +1,735 @@
+// The gRPC API of wallkeiro, served by "wallkeiro serve" next to the REST API.
+// It offers the same operations on profiles as the REST API, typed, and streams
+// a profile with its calculation every time the profile changes.
+//
+// Regenerate the Go code in this folder after changing this file with
+//
+//	go generate ./core/rpc/wallkeiropb
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: wallkeiro.proto
+
+package wallkeiropb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Wallkeiro_ListProfiles_FullMethodName  = "/wallkeiro.v1.Wallkeiro/ListProfiles"
+	Wallkeiro_GetProfile_FullMethodName    = "/wallkeiro.v1.Wallkeiro/GetProfile"
+	Wallkeiro_CreateProfile_FullMethodName = "/wallkeiro.v1.Wallkeiro/CreateProfile"
+	Wallkeiro_UpdateProfile_FullMethodName = "/wallkeiro.v1.Wallkeiro/UpdateProfile"
+	Wallkeiro_RenameProfile_FullMethodName = "/wallkeiro.v1.Wallkeiro/RenameProfile"
+	Wallkeiro_DeleteProfile_FullMethodName = "/wallkeiro.v1.Wallkeiro/DeleteProfile"
+	Wallkeiro_UpdateConfig_FullMethodName  = "/wallkeiro.v1.Wallkeiro/UpdateConfig"
+	Wallkeiro_AddExpense_FullMethodName    = "/wallkeiro.v1.Wallkeiro/AddExpense"
+	Wallkeiro_UpdateExpense_FullMethodName = "/wallkeiro.v1.Wallkeiro/UpdateExpense"
+	Wallkeiro_DeleteExpense_FullMethodName = "/wallkeiro.v1.Wallkeiro/DeleteExpense"
+	Wallkeiro_Calculate_FullMethodName     = "/wallkeiro.v1.Wallkeiro/Calculate"
+	Wallkeiro_Upcoming_FullMethodName      = "/wallkeiro.v1.Wallkeiro/Upcoming"
+	Wallkeiro_CashFlow_FullMethodName      = "/wallkeiro.v1.Wallkeiro/CashFlow"
+	Wallkeiro_EmergencyFund_FullMethodName = "/wallkeiro.v1.Wallkeiro/EmergencyFund"
+	Wallkeiro_GoalProgress_FullMethodName  = "/wallkeiro.v1.Wallkeiro/GoalProgress"
+	Wallkeiro_WatchProfile_FullMethodName  = "/wallkeiro.v1.Wallkeiro/WatchProfile"
+)
+
+// WallkeiroClient is the client API for Wallkeiro service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WallkeiroClient interface {
+	// ListProfiles returns the names of all profiles.
+	ListProfiles(ctx context.Context, in *ListProfilesRequest, opts ...grpc.CallOption) (*ListProfilesResponse, error)
+	// GetProfile returns a whole profile.
+	GetProfile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*Profile, error)
+	// CreateProfile creates an empty profile with the default settings.
+	CreateProfile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*Profile, error)
+	// UpdateProfile replaces a whole profile.
+	UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*Profile, error)
+	// RenameProfile gives a profile a new name.
+	RenameProfile(ctx context.Context, in *RenameProfileRequest, opts ...grpc.CallOption) (*Profile, error)
+	// DeleteProfile deletes a profile.
+	DeleteProfile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// UpdateConfig replaces the settings of a profile.
+	UpdateConfig(ctx context.Context, in *UpdateConfigRequest, opts ...grpc.CallOption) (*Config, error)
+	// AddExpense adds an expense at the end of the expenses of a profile and returns it as saved, with its id.
+	AddExpense(ctx context.Context, in *AddExpenseRequest, opts ...grpc.CallOption) (*Expense, error)
+	// UpdateExpense replaces the expense with the given id, or else at the given position.
+	UpdateExpense(ctx context.Context, in *UpdateExpenseRequest, opts ...grpc.CallOption) (*Expense, error)
+	// DeleteExpense deletes the expense with the given id, or else at the given position.
+	DeleteExpense(ctx context.Context, in *DeleteExpenseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Calculate calculates the suggested savings of a profile.
+	Calculate(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*Calculation, error)
+	// Upcoming returns the payments due in the next days.
+	Upcoming(ctx context.Context, in *UpcomingRequest, opts ...grpc.CallOption) (*PaymentList, error)
+	// CashFlow returns the balance of every day of the current pay period.
+	CashFlow(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*CashFlowCalendar, error)
+	// EmergencyFund returns how many months the savings of a profile cover.
+	EmergencyFund(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*EmergencyFundStatus, error)
+	// GoalProgress returns the progress of every savings goal of a profile.
+	GoalProgress(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*GoalProgressList, error)
+	// WatchProfile sends the profile with its calculation right away, and again
+	// every time the profile changes, until the profile is deleted or the call ends.
+	WatchProfile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (Wallkeiro_WatchProfileClient, error)
+}
+
+type wallkeiroClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWallkeiroClient(cc grpc.ClientConnInterface) WallkeiroClient {
+	return &wallkeiroClient{cc}
+}
+
+func (c *wallkeiroClient) ListProfiles(ctx context.Context, in *ListProfilesRequest, opts ...grpc.CallOption) (*ListProfilesResponse, error) {
+	out := new(ListProfilesResponse)
+	err := c.cc.Invoke(ctx, Wallkeiro_ListProfiles_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wallkeiroClient) GetProfile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*Profile, error) {
+	out := new(Profile)
+	err := c.cc.Invoke(ctx, Wallkeiro_GetProfile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wallkeiroClient) CreateProfile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*Profile, error) {
+	out := new(Profile)
+	err := c.cc.Invoke(ctx, Wallkeiro_CreateProfile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wallkeiroClient) UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*Profile, error) {
+	out := new(Profile)
+	err := c.cc.Invoke(ctx, Wallkeiro_UpdateProfile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wallkeiroClient) RenameProfile(ctx context.Context, in *RenameProfileRequest, opts ...grpc.CallOption) (*Profile, error) {
+	out := new(Profile)
+	err := c.cc.Invoke(ctx, Wallkeiro_RenameProfile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wallkeiroClient) DeleteProfile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Wallkeiro_DeleteProfile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wallkeiroClient) UpdateConfig(ctx context.Context, in *UpdateConfigRequest, opts ...grpc.CallOption) (*Config, error) {
+	out := new(Config)
+	err := c.cc.Invoke(ctx, Wallkeiro_UpdateConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wallkeiroClient) AddExpense(ctx context.Context, in *AddExpenseRequest, opts ...grpc.CallOption) (*Expense, error) {
+	out := new(Expense)
+	err := c.cc.Invoke(ctx, Wallkeiro_AddExpense_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wallkeiroClient) UpdateExpense(ctx context.Context, in *UpdateExpenseRequest, opts ...grpc.CallOption) (*Expense, error) {
+	out := new(Expense)
+	err := c.cc.Invoke(ctx, Wallkeiro_UpdateExpense_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wallkeiroClient) DeleteExpense(ctx context.Context, in *DeleteExpenseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Wallkeiro_DeleteExpense_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wallkeiroClient) Calculate(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*Calculation, error) {
+	out := new(Calculation)
+	err := c.cc.Invoke(ctx, Wallkeiro_Calculate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wallkeiroClient) Upcoming(ctx context.Context, in *UpcomingRequest, opts ...grpc.CallOption) (*PaymentList, error) {
+	out := new(PaymentList)
+	err := c.cc.Invoke(ctx, Wallkeiro_Upcoming_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wallkeiroClient) CashFlow(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*CashFlowCalendar, error) {
+	out := new(CashFlowCalendar)
+	err := c.cc.Invoke(ctx, Wallkeiro_CashFlow_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wallkeiroClient) EmergencyFund(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*EmergencyFundStatus, error) {
+	out := new(EmergencyFundStatus)
+	err := c.cc.Invoke(ctx, Wallkeiro_EmergencyFund_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wallkeiroClient) GoalProgress(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*GoalProgressList, error) {
+	out := new(GoalProgressList)
+	err := c.cc.Invoke(ctx, Wallkeiro_GoalProgress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wallkeiroClient) WatchProfile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (Wallkeiro_WatchProfileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Wallkeiro_ServiceDesc.Streams[0], Wallkeiro_WatchProfile_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &wallkeiroWatchProfileClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Wallkeiro_WatchProfileClient interface {
+	Recv() (*ProfileUpdate, error)
+	grpc.ClientStream
+}
+
+type wallkeiroWatchProfileClient struct {
+	grpc.ClientStream
+}
+
+func (x *wallkeiroWatchProfileClient) Recv() (*ProfileUpdate, error) {
+	m := new(ProfileUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// WallkeiroServer is the server API for Wallkeiro service.
+// All implementations must embed UnimplementedWallkeiroServer
+// for forward compatibility
+type WallkeiroServer interface {
+	// ListProfiles returns the names of all profiles.
+	ListProfiles(context.Context, *ListProfilesRequest) (*ListProfilesResponse, error)
+	// GetProfile returns a whole profile.
+	GetProfile(context.Context, *ProfileRequest) (*Profile, error)
+	// CreateProfile creates an empty profile with the default settings.
+	CreateProfile(context.Context, *ProfileRequest) (*Profile, error)
+	// UpdateProfile replaces a whole profile.
+	UpdateProfile(context.Context, *UpdateProfileRequest) (*Profile, error)
+	// RenameProfile gives a profile a new name.
+	RenameProfile(context.Context, *RenameProfileRequest) (*Profile, error)
+	// DeleteProfile deletes a profile.
+	DeleteProfile(context.Context, *ProfileRequest) (*emptypb.Empty, error)
+	// UpdateConfig replaces the settings of a profile.
+	UpdateConfig(context.Context, *UpdateConfigRequest) (*Config, error)
+	// AddExpense adds an expense at the end of the expenses of a profile and returns it as saved, with its id.
+	AddExpense(context.Context, *AddExpenseRequest) (*Expense, error)
+	// UpdateExpense replaces the expense with the given id, or else at the given position.
+	UpdateExpense(context.Context, *UpdateExpenseRequest) (*Expense, error)
+	// DeleteExpense deletes the expense with the given id, or else at the given position.
+	DeleteExpense(context.Context, *DeleteExpenseRequest) (*emptypb.Empty, error)
+	// Calculate calculates the suggested savings of a profile.
+	Calculate(context.Context, *ProfileRequest) (*Calculation, error)
+	// Upcoming returns the payments due in the next days.
+	Upcoming(context.Context, *UpcomingRequest) (*PaymentList, error)
+	// CashFlow returns the balance of every day of the current pay period.
+	CashFlow(context.Context, *ProfileRequest) (*CashFlowCalendar, error)
+	// EmergencyFund returns how many months the savings of a profile cover.
+	EmergencyFund(context.Context, *ProfileRequest) (*EmergencyFundStatus, error)
+	// GoalProgress returns the progress of every savings goal of a profile.
+	GoalProgress(context.Context, *ProfileRequest) (*GoalProgressList, error)
+	// WatchProfile sends the profile with its calculation right away, and again
+	// every time the profile changes, until the profile is deleted or the call ends.
+	WatchProfile(*ProfileRequest, Wallkeiro_WatchProfileServer) error
+	mustEmbedUnimplementedWallkeiroServer()
+}
+
+// UnimplementedWallkeiroServer must be embedded to have forward compatible implementations.
+type UnimplementedWallkeiroServer struct {
+}
+
+func (UnimplementedWallkeiroServer) ListProfiles(context.Context, *ListProfilesRequest) (*ListProfilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProfiles not implemented")
+}
+func (UnimplementedWallkeiroServer) GetProfile(context.Context, *ProfileRequest) (*Profile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProfile not implemented")
+}
+func (UnimplementedWallkeiroServer) CreateProfile(context.Context, *ProfileRequest) (*Profile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProfile not implemented")
+}
+func (UnimplementedWallkeiroServer) UpdateProfile(context.Context, *UpdateProfileRequest) (*Profile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProfile not implemented")
+}
+func (UnimplementedWallkeiroServer) RenameProfile(context.Context, *RenameProfileRequest) (*Profile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameProfile not implemented")
+}
+func (UnimplementedWallkeiroServer) DeleteProfile(context.Context, *ProfileRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProfile not implemented")
+}
+func (UnimplementedWallkeiroServer) UpdateConfig(context.Context, *UpdateConfigRequest) (*Config, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateConfig not implemented")
+}
+func (UnimplementedWallkeiroServer) AddExpense(context.Context, *AddExpenseRequest) (*Expense, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddExpense not implemented")
+}
+func (UnimplementedWallkeiroServer) UpdateExpense(context.Context, *UpdateExpenseRequest) (*Expense, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateExpense not implemented")
+}
+func (UnimplementedWallkeiroServer) DeleteExpense(context.Context, *DeleteExpenseRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteExpense not implemented")
+}
+func (UnimplementedWallkeiroServer) Calculate(context.Context, *ProfileRequest) (*Calculation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Calculate not implemented")
+}
+func (UnimplementedWallkeiroServer) Upcoming(context.Context, *UpcomingRequest) (*PaymentList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Upcoming not implemented")
+}
+func (UnimplementedWallkeiroServer) CashFlow(context.Context, *ProfileRequest) (*CashFlowCalendar, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CashFlow not implemented")
+}
+func (UnimplementedWallkeiroServer) EmergencyFund(context.Context, *ProfileRequest) (*EmergencyFundStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EmergencyFund not implemented")
+}
+func (UnimplementedWallkeiroServer) GoalProgress(context.Context, *ProfileRequest) (*GoalProgressList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GoalProgress not implemented")
+}
+func (UnimplementedWallkeiroServer) WatchProfile(*ProfileRequest, Wallkeiro_WatchProfileServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchProfile not implemented")
+}
+func (UnimplementedWallkeiroServer) mustEmbedUnimplementedWallkeiroServer() {}
+
+// UnsafeWallkeiroServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WallkeiroServer will
+// result in compilation errors.
+type UnsafeWallkeiroServer interface {
+	mustEmbedUnimplementedWallkeiroServer()
+}
+
+func RegisterWallkeiroServer(s grpc.ServiceRegistrar, srv WallkeiroServer) {
+	s.RegisterService(&Wallkeiro_ServiceDesc, srv)
+}
+
+func _Wallkeiro_ListProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WallkeiroServer).ListProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallkeiro_ListProfiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WallkeiroServer).ListProfiles(ctx, req.(*ListProfilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallkeiro_GetProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WallkeiroServer).GetProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallkeiro_GetProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WallkeiroServer).GetProfile(ctx, req.(*ProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallkeiro_CreateProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WallkeiroServer).CreateProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallkeiro_CreateProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WallkeiroServer).CreateProfile(ctx, req.(*ProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallkeiro_UpdateProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WallkeiroServer).UpdateProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallkeiro_UpdateProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WallkeiroServer).UpdateProfile(ctx, req.(*UpdateProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallkeiro_RenameProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WallkeiroServer).RenameProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallkeiro_RenameProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WallkeiroServer).RenameProfile(ctx, req.(*RenameProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallkeiro_DeleteProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WallkeiroServer).DeleteProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallkeiro_DeleteProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WallkeiroServer).DeleteProfile(ctx, req.(*ProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallkeiro_UpdateConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WallkeiroServer).UpdateConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallkeiro_UpdateConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WallkeiroServer).UpdateConfig(ctx, req.(*UpdateConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallkeiro_AddExpense_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddExpenseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WallkeiroServer).AddExpense(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallkeiro_AddExpense_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WallkeiroServer).AddExpense(ctx, req.(*AddExpenseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallkeiro_UpdateExpense_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateExpenseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WallkeiroServer).UpdateExpense(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallkeiro_UpdateExpense_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WallkeiroServer).UpdateExpense(ctx, req.(*UpdateExpenseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallkeiro_DeleteExpense_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteExpenseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WallkeiroServer).DeleteExpense(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallkeiro_DeleteExpense_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WallkeiroServer).DeleteExpense(ctx, req.(*DeleteExpenseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallkeiro_Calculate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WallkeiroServer).Calculate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallkeiro_Calculate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WallkeiroServer).Calculate(ctx, req.(*ProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallkeiro_Upcoming_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpcomingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WallkeiroServer).Upcoming(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallkeiro_Upcoming_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WallkeiroServer).Upcoming(ctx, req.(*UpcomingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallkeiro_CashFlow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WallkeiroServer).CashFlow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallkeiro_CashFlow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WallkeiroServer).CashFlow(ctx, req.(*ProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallkeiro_EmergencyFund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WallkeiroServer).EmergencyFund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallkeiro_EmergencyFund_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WallkeiroServer).EmergencyFund(ctx, req.(*ProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallkeiro_GoalProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WallkeiroServer).GoalProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallkeiro_GoalProgress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WallkeiroServer).GoalProgress(ctx, req.(*ProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallkeiro_WatchProfile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ProfileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WallkeiroServer).WatchProfile(m, &wallkeiroWatchProfileServer{stream})
+}
+
+type Wallkeiro_WatchProfileServer interface {
+	Send(*ProfileUpdate) error
+	grpc.ServerStream
+}
+
+type wallkeiroWatchProfileServer struct {
+	grpc.ServerStream
+}
+
+func (x *wallkeiroWatchProfileServer) Send(m *ProfileUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Wallkeiro_ServiceDesc is the grpc.ServiceDesc for Wallkeiro service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Wallkeiro_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "wallkeiro.v1.Wallkeiro",
+	HandlerType: (*WallkeiroServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListProfiles",
+			Handler:    _Wallkeiro_ListProfiles_Handler,
+		},
+		{
+			MethodName: "GetProfile",
+			Handler:    _Wallkeiro_GetProfile_Handler,
+		},
+		{
+			MethodName: "CreateProfile",
+			Handler:    _Wallkeiro_CreateProfile_Handler,
+		},
+		{
+			MethodName: "UpdateProfile",
+			Handler:    _Wallkeiro_UpdateProfile_Handler,
+		},
+		{
+			MethodName: "RenameProfile",
+			Handler:    _Wallkeiro_RenameProfile_Handler,
+		},
+		{
+			MethodName: "DeleteProfile",
+			Handler:    _Wallkeiro_DeleteProfile_Handler,
+		},
+		{
+			MethodName: "UpdateConfig",
+			Handler:    _Wallkeiro_UpdateConfig_Handler,
+		},
+		{
+			MethodName: "AddExpense",
+			Handler:    _Wallkeiro_AddExpense_Handler,
+		},
+		{
+			MethodName: "UpdateExpense",
+			Handler:    _Wallkeiro_UpdateExpense_Handler,
+		},
+		{
+			MethodName: "DeleteExpense",
+			Handler:    _Wallkeiro_DeleteExpense_Handler,
+		},
+		{
+			MethodName: "Calculate",
+			Handler:    _Wallkeiro_Calculate_Handler,
+		},
+		{
+			MethodName: "Upcoming",
+			Handler:    _Wallkeiro_Upcoming_Handler,
+		},
+		{
+			MethodName: "CashFlow",
+			Handler:    _Wallkeiro_CashFlow_Handler,
+		},
+		{
+			MethodName: "EmergencyFund",
+			Handler:    _Wallkeiro_EmergencyFund_Handler,
+		},
+		{
+			MethodName: "GoalProgress",
+			Handler:    _Wallkeiro_GoalProgress_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchProfile",
+			Handler:       _Wallkeiro_WatchProfile_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "wallkeiro.proto",
+}
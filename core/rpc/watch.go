@@ -0,0 +1,46 @@
+package rpc
+
+import (
+	goerrors "errors"
+	"io/fs"
+	"strings"
+	"time"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/expenses"
+	"wallkeiro/core/rpc/wallkeiropb"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// WatchProfile sends the profile with its calculation, and then looks for changes every
+// WatchInterval, checking the permission of the user every time. Changes are found by comparing the profile file with the last one sent,
+// so changes made by the menus, the REST API or another process are sent as well.
+func (s *Server) WatchProfile(req *wallkeiropb.ProfileRequest, stream wallkeiropb.Wallkeiro_WatchProfileServer) error {
+	name := strings.ToLower(req.GetName())
+	var last *wallkeiropb.Profile
+	ticker := time.NewTicker(WatchInterval)
+	defer ticker.Stop()
+	for {
+		profileData, err := storeOf(stream.Context()).Read(name)
+		// a profile that is no longer shared with the user looks deleted to them as well
+		if (goerrors.Is(err, fs.ErrNotExist) || goerrors.Is(err, errors.ErrNotFound)) && last != nil {
+			return stream.Send(&wallkeiropb.ProfileUpdate{Profile: &wallkeiropb.Profile{Name: name}, Deleted: true})
+		}
+		if err != nil {
+			return failure(err)
+		}
+		profile := profileToProto(name, profileData)
+		if !proto.Equal(profile, last) {
+			update := &wallkeiropb.ProfileUpdate{Profile: profile, Calculation: calculationToProto(expenses.Compute(profileData))}
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+			last = profile
+		}
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package core
+
+import (
+	"wallkeiro/core/config"
+	"wallkeiro/core/rules"
+
+	"github.com/manifoldco/promptui"
+
+	"fmt"
+	"strings"
+)
+
+// RulesMenu lets the user manage the categorization rules of the given profile.
+// Rules can be added, have their priority changed or be deleted, tested against a sample
+// transaction, and re-run over every imported transaction. The uncategorized report shows
+// the transactions no rule matched, grouped by payee.
+func RulesMenu(selectedProfile string) error {
+	prompt := promptui.Select{
+		Label: "Select Rule Action",
+		Items: []string{"Show Rules", "Add Rule", "Change Rule Priority", "Delete Rule", "Test Rules", "Re-run Rules on History", "Uncategorized Report"},
+	}
+	_, actionSelector, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+	profileData, err := config.ReadProfile(selectedProfile)
+	if err != nil {
+		return err
+	}
+	switch actionSelector {
+	case "Show Rules":
+		rules.ShowRules(profileData.Rules)
+		return nil
+	case "Add Rule":
+		rule, err := promptRule()
+		if err != nil {
+			return err
+		}
+		profileData.Rules = append(profileData.Rules, rule)
+		fmt.Printf("Rule %s added successfully.\n", rule.Name)
+	case "Change Rule Priority", "Delete Rule":
+		if len(profileData.Rules) == 0 {
+			fmt.Println("No rules to edit.")
+			return nil
+		}
+		var ruleNames []string
+		for _, rule := range profileData.Rules {
+			ruleNames = append(ruleNames, fmt.Sprintf("%s (priority %d)", rule.Name, rule.Priority))
+		}
+		prompt := promptui.Select{
+			Label: "Select Rule",
+			Items: ruleNames,
+		}
+		ruleIndex, _, err := prompt.Run()
+		if err != nil {
+			return err
+		}
+		if actionSelector == "Delete Rule" {
+			profileData.Rules = append(profileData.Rules[:ruleIndex], profileData.Rules[ruleIndex+1:]...)
+			break
+		}
+		priority, err := promptAmount("Enter New Priority (higher is tried first)", fmt.Sprintf("%d", profileData.Rules[ruleIndex].Priority))
+		if err != nil {
+			return err
+		}
+		profileData.Rules[ruleIndex].Priority = int(priority)
+	case "Test Rules":
+		return testRules(profileData.Rules)
+	case "Re-run Rules on History":
+		overwritePrompt := promptui.Select{
+			Label: "Overwrite categories that are already set?",
+			Items: []string{"No", "Yes"},
+		}
+		_, overwrite, err := overwritePrompt.Run()
+		if err != nil {
+			return err
+		}
+		changed, err := rules.Apply(profileData.Rules, profileData.Transactions, overwrite == "Yes")
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d transactions categorized.\n", changed)
+		rules.ShowUncategorized(profileData.Transactions)
+	case "Uncategorized Report":
+		rules.ShowUncategorized(profileData.Transactions)
+		return nil
+	}
+	return config.UpdateProfile(selectedProfile, &profileData)
+}
+
+// promptRule asks the user for the conditions, category, tags and priority of a new rule.
+// Conditions left empty are not checked. It returns the entered rule, and an error if
+// there was an error running the prompts or the rule is not valid.
+func promptRule() (config.RuleStruct, error) {
+	var rule config.RuleStruct
+	var memoKeywords, tags string
+	fields := []struct {
+		label string
+		value *string
+	}{
+		{"Enter Rule Name", &rule.Name},
+		{"Enter Payee Regular Expression (empty for any)", &rule.Payee},
+		{"Enter Counterparty IBAN (empty for any)", &rule.IBAN},
+		{"Enter Memo Keywords, Comma Separated (empty for any)", &memoKeywords},
+		{"Enter Category", &rule.Category},
+		{"Enter Tags, Comma Separated", &tags},
+	}
+	for _, field := range fields {
+		fieldPrompt := promptui.Prompt{
+			Label: field.label,
+		}
+		value, err := fieldPrompt.Run()
+		if err != nil {
+			return rule, err
+		}
+		*field.value = strings.TrimSpace(value)
+	}
+	rule.MemoKeywords = splitList(memoKeywords)
+	rule.Tags = splitList(tags)
+	var err error
+	rule.MinAmount, err = promptAmount("Enter Minimum Amount (0 for none)", "0")
+	if err != nil {
+		return rule, err
+	}
+	rule.MaxAmount, err = promptAmount("Enter Maximum Amount (0 for none)", "0")
+	if err != nil {
+		return rule, err
+	}
+	priority, err := promptAmount("Enter Priority (higher is tried first)", "0")
+	if err != nil {
+		return rule, err
+	}
+	rule.Priority = int(priority)
+	return rule, rules.Validate(rule)
+}
+
+// testRules asks the user for a sample transaction and shows which rule categorizes it,
+// along with every other rule that matches it but has a lower priority.
+func testRules(ruleList []config.RuleStruct) error {
+	var transaction config.TransactionStruct
+	fields := []struct {
+		label string
+		value *string
+	}{
+		{"Enter Payee", &transaction.Payee},
+		{"Enter Counterparty IBAN", &transaction.IBAN},
+		{"Enter Memo", &transaction.Memo},
+	}
+	for _, field := range fields {
+		fieldPrompt := promptui.Prompt{
+			Label: field.label,
+		}
+		value, err := fieldPrompt.Run()
+		if err != nil {
+			return err
+		}
+		*field.value = value
+	}
+	amount, err := promptAmount("Enter Amount (negative for payments)", "")
+	if err != nil {
+		return err
+	}
+	transaction.Amount = amount
+	matched := false
+	for _, rule := range rules.Sorted(ruleList) {
+		matches, err := rules.Matches(rule, transaction)
+		if err != nil {
+			return err
+		}
+		if !matches {
+			continue
+		}
+		if !matched {
+			fmt.Printf("Categorized by %s: category %s, tags %s\n", rule.Name, rule.Category, strings.Join(rule.Tags, ", "))
+			matched = true
+			continue
+		}
+		fmt.Printf("Also matches %s (priority %d), which is not used.\n", rule.Name, rule.Priority)
+	}
+	if !matched {
+		fmt.Println("No rule matches this transaction.")
+	}
+	return nil
+}
+
+// splitList splits a comma separated list and drops empty entries.
+func splitList(list string) []string {
+	var values []string
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
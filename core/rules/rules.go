@@ -0,0 +1,231 @@
+package rules
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/table"
+)
+
+// Validate checks that the rule has at least one condition, sets a category or a tag,
+// and that its payee is a valid regular expression.
+func Validate(rule config.RuleStruct) error {
+	if rule.Payee == "" && rule.MinAmount == 0 && rule.MaxAmount == 0 && rule.IBAN == "" && len(rule.MemoKeywords) == 0 {
+		return errors.ErrEmptyRule
+	}
+	if rule.Category == "" && len(rule.Tags) == 0 {
+		return errors.ErrEmptyRule
+	}
+	if rule.Payee != "" {
+		if _, err := regexp.Compile("(?i)" + rule.Payee); err != nil {
+			return fmt.Errorf("%w: %v", errors.ErrInvalidRule, err)
+		}
+	}
+	return nil
+}
+
+// Sorted returns the rules in the order they are tried: highest priority first,
+// and rules of the same priority in the order they were added.
+func Sorted(rules []config.RuleStruct) []config.RuleStruct {
+	sorted := append([]config.RuleStruct{}, rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority > sorted[j].Priority
+	})
+	return sorted
+}
+
+// Matches reports whether every condition of the rule matches the transaction.
+// The payee expression and the memo keywords are matched without regard to case,
+// and IBANs are compared without spaces.
+func Matches(rule config.RuleStruct, transaction config.TransactionStruct) (bool, error) {
+	if rule.Payee != "" {
+		expression, err := regexp.Compile("(?i)" + rule.Payee)
+		if err != nil {
+			return false, fmt.Errorf("%w: %v", errors.ErrInvalidRule, err)
+		}
+		if !expression.MatchString(transaction.Payee) {
+			return false, nil
+		}
+	}
+	amount := math.Abs(transaction.Amount)
+	if rule.MinAmount != 0 && amount < rule.MinAmount {
+		return false, nil
+	}
+	if rule.MaxAmount != 0 && amount > rule.MaxAmount {
+		return false, nil
+	}
+	if rule.IBAN != "" && normalizeIBAN(rule.IBAN) != normalizeIBAN(transaction.IBAN) {
+		return false, nil
+	}
+	if len(rule.MemoKeywords) > 0 {
+		memo := strings.ToLower(transaction.Memo)
+		found := false
+		for _, keyword := range rule.MemoKeywords {
+			if keyword != "" && strings.Contains(memo, strings.ToLower(keyword)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Match returns the first rule in priority order that matches the transaction.
+// If no rule matches, ok is false.
+func Match(rules []config.RuleStruct, transaction config.TransactionStruct) (rule config.RuleStruct, ok bool, err error) {
+	for _, rule := range Sorted(rules) {
+		matches, err := Matches(rule, transaction)
+		if err != nil {
+			return rule, false, err
+		}
+		if matches {
+			return rule, true, nil
+		}
+	}
+	return config.RuleStruct{}, false, nil
+}
+
+// Apply categorizes the transactions with the first matching rule of each, and adds its tags.
+// Transactions that already have a category keep it unless overwrite is set, which is used to
+// re-run the rules over the history after they were changed. It returns how many transactions
+// were changed.
+func Apply(rules []config.RuleStruct, transactions []config.TransactionStruct, overwrite bool) (int, error) {
+	changed := 0
+	for i, transaction := range transactions {
+		if transaction.Category != "" && !overwrite {
+			continue
+		}
+		rule, ok, err := Match(rules, transaction)
+		if err != nil {
+			return changed, err
+		}
+		if !ok {
+			continue
+		}
+		updated := transaction
+		if rule.Category != "" {
+			updated.Category = rule.Category
+		}
+		updated.Tags = addTags(transaction.Tags, rule.Tags)
+		if updated.Category != transaction.Category || len(updated.Tags) != len(transaction.Tags) {
+			changed++
+		}
+		transactions[i] = updated
+	}
+	return changed, nil
+}
+
+// Uncategorized returns the transactions without a category.
+func Uncategorized(transactions []config.TransactionStruct) []config.TransactionStruct {
+	var uncategorized []config.TransactionStruct
+	for _, transaction := range transactions {
+		if transaction.Category == "" {
+			uncategorized = append(uncategorized, transaction)
+		}
+	}
+	return uncategorized
+}
+
+// ShowRules prints the rules in the order they are tried.
+func ShowRules(rules []config.RuleStruct) {
+	if len(rules) == 0 {
+		fmt.Println("No rules added.")
+		return
+	}
+	columns := []string{"Priority", "Name", "Payee", "Amount", "IBAN", "Memo", "Category", "Tags"}
+	var rows [][]string
+	for _, rule := range Sorted(rules) {
+		amount := ""
+		if rule.MinAmount != 0 || rule.MaxAmount != 0 {
+			amount = fmt.Sprintf("%.2f€ - ", rule.MinAmount)
+			if rule.MaxAmount != 0 {
+				amount += fmt.Sprintf("%.2f€", rule.MaxAmount)
+			}
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", rule.Priority),
+			rule.Name,
+			rule.Payee,
+			amount,
+			rule.IBAN,
+			strings.Join(rule.MemoKeywords, ", "),
+			rule.Category,
+			strings.Join(rule.Tags, ", "),
+		})
+	}
+	fmt.Println()
+	table.Print(columns, rows)
+}
+
+// ShowUncategorized prints the transactions without a category, and groups them by payee
+// with the number of transactions and their total, most frequent payees first, to show which
+// rules are still missing.
+func ShowUncategorized(transactions []config.TransactionStruct) {
+	uncategorized := Uncategorized(transactions)
+	if len(uncategorized) == 0 {
+		fmt.Println("Every transaction is categorized.")
+		return
+	}
+	type payeeTotal struct {
+		payee string
+		count int
+		total float64
+	}
+	var totals []*payeeTotal
+	byPayee := map[string]*payeeTotal{}
+	for _, transaction := range uncategorized {
+		payee := strings.TrimSpace(transaction.Payee)
+		if payee == "" {
+			payee = transaction.IBAN
+		}
+		if _, ok := byPayee[payee]; !ok {
+			byPayee[payee] = &payeeTotal{payee: payee}
+			totals = append(totals, byPayee[payee])
+		}
+		byPayee[payee].count++
+		byPayee[payee].total += transaction.Amount
+	}
+	sort.SliceStable(totals, func(i, j int) bool {
+		return totals[i].count > totals[j].count
+	})
+	var rows [][]string
+	for _, total := range totals {
+		rows = append(rows, []string{total.payee, fmt.Sprintf("%d", total.count), fmt.Sprintf("%.2f€", total.total)})
+	}
+	fmt.Println()
+	table.Print([]string{"Payee", "Transactions", "Total"}, rows)
+	fmt.Printf("%d of %d transactions are not categorized.\n", len(uncategorized), len(transactions))
+}
+
+// addTags returns the tags with the new ones added, leaving out any that are already there.
+func addTags(tags, added []string) []string {
+	result := append([]string{}, tags...)
+	for _, tag := range added {
+		found := false
+		for _, existing := range result {
+			if strings.EqualFold(existing, tag) {
+				found = true
+				break
+			}
+		}
+		if !found && tag != "" {
+			result = append(result, tag)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// normalizeIBAN returns the IBAN in upper case without spaces.
+func normalizeIBAN(iban string) string {
+	return strings.ToUpper(strings.ReplaceAll(iban, " ", ""))
+}
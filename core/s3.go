@@ -0,0 +1,107 @@
+package core
+
+import (
+	"wallkeiro/core/config"
+	"wallkeiro/core/encryption"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/s3"
+	"wallkeiro/core/webdav"
+
+	"github.com/manifoldco/promptui"
+
+	"fmt"
+	"path/filepath"
+)
+
+// S3 runs "wallkeiro s3" with the given arguments:
+//
+//	wallkeiro s3 <endpoint> <bucket> [prefix]  stores the profiles in the bucket from now on
+//	wallkeiro s3 off                           stores the profiles in the profiles folder again
+//
+// The profiles are encrypted before they are uploaded. If there is no encryption key yet,
+// one is created; it has to be copied to every computer using the bucket. When set up, the
+// profiles of the profiles folder that are not in the bucket yet are uploaded to it.
+// Profiles already in it keep the version of the bucket.
+func S3(args []string) error {
+	if len(args) == 1 && args[0] == "off" {
+		if err := s3.RemoveSettings(); err != nil {
+			return err
+		}
+		fmt.Printf("The profiles are stored in %s again. They stay in the bucket as well.\n", config.ProfilesFolder)
+		return nil
+	}
+	if len(args) < 2 || len(args) > 3 {
+		return fmt.Errorf("usage: wallkeiro s3 <endpoint> <bucket> [prefix] | wallkeiro s3 off")
+	}
+	if _, err := webdav.ReadSettings(); err != errors.ErrNotFound {
+		return errors.ErrOtherStorage
+	}
+	settings := s3.Settings{Endpoint: args[0], Bucket: args[1]}
+	if len(args) == 3 {
+		settings.Prefix = args[2]
+	}
+	var err error
+	regionPrompt := promptui.Prompt{
+		Label:   "Enter Region",
+		Default: s3.DefaultRegion,
+	}
+	if settings.Region, err = regionPrompt.Run(); err != nil {
+		return err
+	}
+	accessKeyPrompt := promptui.Prompt{
+		Label: "Enter Access Key",
+	}
+	if settings.AccessKey, err = accessKeyPrompt.Run(); err != nil {
+		return err
+	}
+	secretKeyPrompt := promptui.Prompt{
+		Label: "Enter Secret Key",
+		Mask:  '*',
+	}
+	if settings.SecretKey, err = secretKeyPrompt.Run(); err != nil {
+		return err
+	}
+	keys, err := encryption.ReadKeys()
+	if err == errors.ErrNoEncryptionKey {
+		if keys, err = encryption.CreateKeys(); err == nil {
+			fmt.Printf("Created the encryption key in %s. Copy that folder to every computer using the bucket, the profiles can not be read without it.\n",
+				filepath.Join(config.ProfilesFolder, encryption.Folder))
+		}
+	}
+	if err != nil {
+		return err
+	}
+	backend := s3.NewBackend(settings, keys)
+	remote, err := backend.List()
+	if err != nil {
+		return err
+	}
+	inBucket := map[string]bool{}
+	for _, name := range remote {
+		inBucket[name] = true
+	}
+	local := config.LocalBackend{}
+	names, err := local.List()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if inBucket[name] {
+			fmt.Printf("Profile %s is already in the bucket, its version there is used.\n", name)
+			continue
+		}
+		data, _, err := local.Read(name)
+		if err != nil {
+			return err
+		}
+		if _, _, err := backend.Write(name, data, ""); err != nil {
+			return err
+		}
+		fmt.Printf("Profile %s encrypted and uploaded.\n", name)
+	}
+	if err := s3.SaveSettings(backend.Settings); err != nil {
+		return err
+	}
+	fmt.Printf("The profiles are stored in the bucket %s from now on.\n", settings.Bucket)
+	return nil
+}
@@ -0,0 +1,406 @@
+// Package s3 stores the profiles in a bucket of an S3-compatible object store, like MinIO or
+// Amazon S3, instead of the profiles folder. Every profile is one object, encrypted with the
+// key of the encryption package before it is uploaded, so the bucket only ever holds
+// ciphertext and the store can not read the profiles. Every object is written only if it did
+// not change in the bucket since it was last read, using conditional writes, so two computers
+// never overwrite each other's changes: a write refused by the store is merged field by field
+// with the version in the bucket (see syncer.MergeKeepingLocal) and written again.
+package s3
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/encryption"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/syncer"
+)
+
+// Folder is the folder inside the profiles folder where the settings are kept.
+const Folder string = "s3"
+
+// Extension is the extension of the objects holding the encrypted profiles.
+const Extension string = ".json.enc"
+
+// DefaultRegion is the region used by MinIO and most other S3-compatible stores.
+const DefaultRegion string = "us-east-1"
+
+// Settings are the bucket the profiles are stored in and the access key to it. Objects are
+// addressed by path, like https://s3.example.com/<bucket>/<prefix><profile>.json.enc, which
+// every S3-compatible store understands.
+type Settings struct {
+	Endpoint  string `json:"endpoint"`
+	Region    string `json:"region"`
+	Bucket    string `json:"bucket"`
+	Prefix    string `json:"prefix"`
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+}
+
+// settingsPath returns the path of the file containing the settings.
+func settingsPath() string {
+	return filepath.Join(config.ProfilesFolder, Folder, "settings.json")
+}
+
+// ReadSettings returns the settings, or errors.ErrNotFound if S3 is not set up.
+func ReadSettings() (Settings, error) {
+	var settings Settings
+	data, err := os.ReadFile(settingsPath())
+	if goerrors.Is(err, fs.ErrNotExist) {
+		return settings, errors.ErrNotFound
+	}
+	if err != nil {
+		return settings, err
+	}
+	return settings, json.Unmarshal(data, &settings)
+}
+
+// SaveSettings saves the settings. The file is only readable by the user running wallkeiro,
+// since it contains the secret key.
+func SaveSettings(settings Settings) error {
+	if err := os.MkdirAll(filepath.Join(config.ProfilesFolder, Folder), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(settingsPath(), data, 0600)
+}
+
+// RemoveSettings stops storing the profiles in the bucket. The profiles stay in the bucket.
+func RemoveSettings() error {
+	return os.RemoveAll(filepath.Join(config.ProfilesFolder, Folder))
+}
+
+// Use makes config store the profiles in the bucket, if it is set up. The encryption keys
+// have to be on this computer then.
+func Use() error {
+	settings, err := ReadSettings()
+	if err == errors.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	keys, err := encryption.ReadKeys()
+	if err != nil {
+		return err
+	}
+	config.Storage = NewBackend(settings, keys)
+	return nil
+}
+
+// Backend is a config.Backend storing the profiles in a bucket.
+type Backend struct {
+	Settings Settings
+	Keys     encryption.Keys
+	HTTP     *http.Client
+	// mu guards read, which every operation changes.
+	mu sync.Mutex
+	// read holds the versions of every profile read or written lately, oldest first, so a
+	// write edited from one of them can be merged with the changes made since.
+	read map[string][]version
+}
+
+// version is a version of a profile in the bucket.
+type version struct {
+	etag string
+	data []byte
+}
+
+// keptVersions is how many of the versions of a profile read or written lately are kept.
+const keptVersions int = 8
+
+// remember keeps the version of the profile, forgetting its oldest version kept if there are too many.
+func (b *Backend) remember(name string, latest version) {
+	name = strings.ToLower(name)
+	kept := append(b.read[name], latest)
+	if len(kept) > keptVersions {
+		kept = kept[len(kept)-keptVersions:]
+	}
+	b.read[name] = kept
+}
+
+// lookup returns the version of the profile with the ETag, which has no data if it is not kept.
+func (b *Backend) lookup(name, etag string) version {
+	kept := b.read[strings.ToLower(name)]
+	for i := len(kept) - 1; i >= 0; i-- {
+		if kept[i].etag == etag {
+			return kept[i]
+		}
+	}
+	return version{etag: etag}
+}
+
+// latest returns the version of the profile read or written last, which has no ETag if there is none.
+func (b *Backend) latest(name string) version {
+	kept := b.read[strings.ToLower(name)]
+	if len(kept) == 0 {
+		return version{}
+	}
+	return kept[len(kept)-1]
+}
+
+// NewBackend returns a Backend storing the profiles in the bucket of the settings, encrypted
+// with the keys.
+func NewBackend(settings Settings, keys encryption.Keys) *Backend {
+	if settings.Region == "" {
+		settings.Region = DefaultRegion
+	}
+	settings.Endpoint = strings.TrimSuffix(settings.Endpoint, "/")
+	return &Backend{Settings: settings, Keys: keys, HTTP: &http.Client{Timeout: 15 * time.Second}, read: map[string][]version{}}
+}
+
+// request sends a signed request for the object of the given profile, or for the bucket
+// if the name is empty, and returns the response and its body.
+func (b *Backend) request(method, name string, query url.Values, body []byte, headers map[string]string) (*http.Response, []byte, error) {
+	target, err := url.Parse(b.Settings.Endpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	path := target.Path + "/" + b.Settings.Bucket
+	if name != "" {
+		path += "/" + b.Settings.Prefix + strings.ToLower(name) + Extension
+	}
+	escapePath(target, path)
+	target.RawQuery = canonicalQuery(query)
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	request, err := http.NewRequest(method, target.String(), reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	sign(request, body, b.Settings.AccessKey, b.Settings.SecretKey, b.Settings.Region, time.Now())
+	response, err := b.HTTP.Do(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(response.Body)
+	return response, data, err
+}
+
+// s3Error is the error an S3-compatible store answers with.
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// failed returns the error for an unexpected response of the store, with the reason it gave.
+func failed(method string, response *http.Response, body []byte) error {
+	var reason s3Error
+	if xml.Unmarshal(body, &reason) == nil && reason.Code != "" {
+		return fmt.Errorf("S3 %s answered %s: %s %s", method, response.Status, reason.Code, reason.Message)
+	}
+	return fmt.Errorf("S3 %s answered %s", method, response.Status)
+}
+
+// listBucketResult is the part of the answer to ListObjectsV2 needed to list the profiles.
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List returns the names of the profiles in the bucket.
+func (b *Backend) List() ([]string, error) {
+	query := url.Values{"list-type": {"2"}, "prefix": {b.Settings.Prefix}}
+	profiles := []string{}
+	for {
+		response, body, err := b.request(http.MethodGet, "", query, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		if response.StatusCode != http.StatusOK {
+			return nil, failed("ListObjectsV2", response, body)
+		}
+		var result listBucketResult
+		if err := xml.Unmarshal(body, &result); err != nil {
+			return nil, err
+		}
+		for _, object := range result.Contents {
+			name := strings.TrimPrefix(object.Key, b.Settings.Prefix)
+			if strings.HasSuffix(name, Extension) && !strings.Contains(name, "/") {
+				profiles = append(profiles, strings.TrimSuffix(name, Extension))
+			}
+		}
+		if !result.IsTruncated {
+			break
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+	sort.Strings(profiles)
+	return profiles, nil
+}
+
+// get downloads and decrypts the profile. It returns an error wrapping fs.ErrNotExist if
+// there is no such profile.
+func (b *Backend) get(name string) (version, error) {
+	response, body, err := b.request(http.MethodGet, name, nil, nil, nil)
+	if err != nil {
+		return version{}, err
+	}
+	if response.StatusCode == http.StatusNotFound {
+		return version{}, fmt.Errorf("%s: %w", name, fs.ErrNotExist)
+	}
+	if response.StatusCode != http.StatusOK {
+		return version{}, failed("GetObject", response, body)
+	}
+	data, err := encryption.Open(body, b.Keys.Private)
+	if err != nil {
+		return version{}, fmt.Errorf("%s: %w", name, err)
+	}
+	return version{etag: response.Header.Get("ETag"), data: data}, nil
+}
+
+// put encrypts and uploads the profile if the object in the bucket has the given ETag, or if
+// there is no such object if the ETag is empty. It returns the ETag of the object written,
+// or reports whether the store refused it because the condition did not hold.
+func (b *Backend) put(name string, data []byte, etag string) (string, bool, error) {
+	encrypted, err := encryption.Seal(data, b.Keys.Public)
+	if err != nil {
+		return "", false, err
+	}
+	headers := map[string]string{"Content-Type": "application/octet-stream"}
+	if etag != "" {
+		headers["If-Match"] = etag
+	} else {
+		headers["If-None-Match"] = "*"
+	}
+	response, body, err := b.request(http.MethodPut, name, nil, encrypted, headers)
+	if err != nil {
+		return "", false, err
+	}
+	switch response.StatusCode {
+	case http.StatusOK:
+		written := response.Header.Get("ETag")
+		b.remember(name, version{etag: written, data: data})
+		return written, false, nil
+	case http.StatusPreconditionFailed, http.StatusConflict:
+		// 409 is answered when another conditional write of the object is in progress
+		return "", true, nil
+	}
+	return "", false, failed("PutObject", response, body)
+}
+
+// Read returns the decrypted profile and its ETag as its version.
+func (b *Backend) Read(name string) ([]byte, string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	latest, err := b.get(name)
+	if err != nil {
+		return nil, "", err
+	}
+	b.remember(name, latest)
+	return latest.data, latest.etag, nil
+}
+
+// Write encrypts and uploads the profile edited from the given version, unless the profile
+// changed in the bucket since. Then the version in the bucket is merged with this one, using
+// the version edited, and the merged version is uploaded instead. If the version edited is no
+// longer kept, it fails with errors.ErrProfileChanged.
+func (b *Backend) Write(name string, data []byte, edited string) ([]byte, string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	base := b.lookup(name, edited)
+	for attempt := 0; attempt < 3; attempt++ {
+		written, refused, err := b.put(name, data, base.etag)
+		if err != nil {
+			return nil, "", err
+		}
+		if !refused {
+			return data, written, nil
+		}
+		latest, err := b.get(name)
+		if goerrors.Is(err, fs.ErrNotExist) {
+			// deleted in the meantime, so it is uploaded as a new object
+			base = version{}
+			continue
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		// the version edited is no longer kept, so the changes made since can not be told apart
+		if base.etag != "" && base.data == nil {
+			return nil, "", errors.ErrProfileChanged
+		}
+		if data, err = syncer.MergeKeepingLocal(base.data, data, latest.data); err != nil {
+			return nil, "", err
+		}
+		b.remember(name, latest)
+		base = latest
+	}
+	return nil, "", errors.ErrOutdated
+}
+
+// Remove deletes the profile from the bucket, unless it changed there since it was last read.
+func (b *Backend) Remove(name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.remove(name)
+}
+
+// remove deletes the object of the profile, if it has the ETag it was last read with.
+func (b *Backend) remove(name string) error {
+	headers := map[string]string{}
+	if etag := b.latest(name).etag; etag != "" {
+		headers["If-Match"] = etag
+	}
+	response, body, err := b.request(http.MethodDelete, name, nil, nil, headers)
+	if err != nil {
+		return err
+	}
+	switch response.StatusCode {
+	case http.StatusNoContent, http.StatusOK:
+		delete(b.read, strings.ToLower(name))
+		return nil
+	case http.StatusPreconditionFailed:
+		return errors.ErrOutdated
+	}
+	return failed("DeleteObject", response, body)
+}
+
+// Rename uploads the profile under the new name, refusing to overwrite another profile, and
+// deletes the old object. Objects can not be renamed, and copying them inside the store can
+// not be made conditional on every store.
+func (b *Backend) Rename(oldName, newName string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	latest, err := b.get(oldName)
+	if err != nil {
+		return err
+	}
+	if base := b.latest(oldName); base.etag != "" && base.etag != latest.etag {
+		return errors.ErrOutdated
+	}
+	b.remember(oldName, latest)
+	_, refused, err := b.put(newName, latest.data, "")
+	if err != nil {
+		return err
+	}
+	if refused {
+		return errors.ErrAlreadyExists
+	}
+	return b.remove(oldName)
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"wallkeiro/core/config"
+	"wallkeiro/core/expenses"
+	"wallkeiro/core/validate"
+
+	"github.com/manifoldco/promptui"
+
+	"fmt"
+)
+
+const CreateNewScenario string = "create new scenario"
+
+// ScenarioMenu lets the user work with the what-if scenarios of the given profile.
+// The user selects an existing scenario or creates a new one by cloning the profile,
+// and can then change the scenario's salary, saving level and expenses, or compare
+// the scenario's calculation against the profile side by side.
+// Every change is written to the scenario file only, the profile itself is never updated.
+func ScenarioMenu(selectedProfile string) error {
+	var selectedScenario string
+	prompt := promptui.Select{
+		Label: "Select Scenario",
+		Items: append(config.GetScenarios(selectedProfile), CreateNewScenario),
+	}
+	_, scenarioSelector, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+
+	if scenarioSelector == CreateNewScenario {
+		prompt := promptui.Prompt{
+			Label: "Enter New Scenario Name",
+			Validate: validate.Name,
+		}
+		scenarioName, err := prompt.Run()
+		if err != nil {
+			return err
+		}
+		err = config.CreateScenario(selectedProfile, scenarioName)
+		if err != nil {
+			return err
+		}
+		selectedScenario = scenarioName
+		fmt.Printf("Scenario %s created from profile %s.\n", scenarioName, selectedProfile)
+	} else {
+		selectedScenario = scenarioSelector
+	}
+	fmt.Printf("Scenario %s selected.\n", selectedScenario)
+	prompt = promptui.Select{
+		Label: "Select Scenario Action",
+		Items: []string{"Compare With Profile", "Edit Saving Level", "Edit Salary", "Show Expenses", "Add Expense", "Edit Expenses", "Delete Scenario"},
+	}
+	ScenarioActionMenu:
+	_, actionSelector, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+	scenarioData, err := config.ReadScenario(selectedProfile, selectedScenario)
+	if err != nil {
+		return err
+	}
+	// the scenario file is only written after the actions changing it
+	edited := true
+	switch actionSelector {
+	case "Compare With Profile":
+		edited = false
+		profileData, err := config.ReadProfile(selectedProfile)
+		if err != nil {
+			return err
+		}
+		expenses.Compare(profileData, scenarioData, selectedScenario)
+	case "Edit Salary":
+		scenarioData.Config.Salary, scenarioData.Config.SalaryType, err = promptSalary()
+		if err != nil {
+			return err
+		}
+	case "Edit Saving Level":
+		scenarioData.Config.SavingLevel, err = promptSavingLevel()
+		if err != nil {
+			return err
+		}
+	case "Show Expenses":
+		edited = false
+		expenses.Show(scenarioData)
+	case "Add Expense":
+		expenseName, expenseAmount, err := promptExpense()
+		if err != nil {
+			return err
+		}
+		scenarioData = expenses.Add(scenarioData, expenseName, expenseAmount)
+	case "Edit Expenses":
+		if len(scenarioData.Expenses) > 0 {
+			scenarioData = expenses.Edit(scenarioData)
+		} else {
+			edited = false
+			fmt.Println("No expenses to edit.")
+		}
+	case "Delete Scenario":
+		return config.DeleteScenario(selectedProfile, selectedScenario)
+	}
+	if edited {
+		err = config.UpdateScenario(selectedProfile, selectedScenario, &scenarioData)
+		if err != nil {
+			return err
+		}
+	}
+	if goBack, err := GoBackPrompt(); err != nil {
+		return err
+	} else if goBack {
+		goto ScenarioActionMenu
+	}
+	return nil
+}
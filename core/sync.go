@@ -0,0 +1,121 @@
+package core
+
+import (
+	"wallkeiro/core/errors"
+	"wallkeiro/core/syncer"
+
+	"github.com/manifoldco/promptui"
+
+	"encoding/json"
+	"fmt"
+)
+
+// SyncMenu lets the user sync the given profile, or every profile, with a wallkeiro sync
+// server started with "wallkeiro sync-server", so the profiles stay the same on every
+// device. The sync server is asked for the first time and can be changed later.
+// When the profile was changed on this device and on the server in different ways,
+// the user chooses which version of every conflicting item to keep.
+func SyncMenu(selectedProfile string) error {
+	prompt := promptui.Select{
+		Label: "Select Sync Action",
+		Items: []string{"Sync This Profile", "Sync All Profiles", "Set Sync Server"},
+	}
+	_, actionSelector, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+	remote, err := syncer.ReadRemote()
+	if err == errors.ErrNoSyncServer || actionSelector == "Set Sync Server" {
+		remote, err = promptRemote()
+	}
+	if err != nil || actionSelector == "Set Sync Server" {
+		return err
+	}
+	client := syncer.NewClient(remote)
+	switch actionSelector {
+	case "Sync This Profile":
+		result, err := client.Sync(selectedProfile, resolveConflict)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Profile %s %s.\n", selectedProfile, result)
+	case "Sync All Profiles":
+		synced, err := client.SyncAll(resolveConflict)
+		for _, profile := range synced {
+			fmt.Printf("Profile %s %s.\n", profile.Name, profile.Result)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SyncAll syncs every profile with the sync server without asking anything, for
+// "wallkeiro sync". Profiles with conflicts are not synced and have to be synced in the menu.
+func SyncAll() error {
+	remote, err := syncer.ReadRemote()
+	if err != nil {
+		return err
+	}
+	synced, err := syncer.NewClient(remote).SyncAll(nil)
+	for _, profile := range synced {
+		fmt.Printf("Profile %s %s.\n", profile.Name, profile.Result)
+	}
+	return err
+}
+
+// promptRemote asks the user for the sync server and the API token of their account on it, and saves them.
+func promptRemote() (syncer.Remote, error) {
+	var remote syncer.Remote
+	urlPrompt := promptui.Prompt{
+		Label:   "Enter Sync Server URL",
+		Default: "http://" + syncer.DefaultAddress,
+	}
+	var err error
+	remote.URL, err = urlPrompt.Run()
+	if err != nil {
+		return remote, err
+	}
+	tokenPrompt := promptui.Prompt{
+		Label: "Enter API Token (empty if the server has no users)",
+		Mask:  '*',
+	}
+	remote.Token, err = tokenPrompt.Run()
+	if err != nil {
+		return remote, err
+	}
+	if err := syncer.SaveRemote(remote); err != nil {
+		return remote, err
+	}
+	fmt.Printf("Syncing with %s.\n", remote.URL)
+	return remote, nil
+}
+
+// resolveConflict shows both versions of a conflicting item and asks the user which one to keep.
+func resolveConflict(conflict syncer.Conflict) (bool, error) {
+	fmt.Printf("Conflict: %s.\n", conflict.Describe())
+	fmt.Printf("  On this device: %s\n", describeVersion(conflict.Local))
+	fmt.Printf("  On the server:  %s\n", describeVersion(conflict.Remote))
+	prompt := promptui.Select{
+		Label: "Select Version to Keep",
+		Items: []string{"Keep This Device's Version", "Keep the Server's Version"},
+	}
+	index, _, err := prompt.Run()
+	if err != nil {
+		return false, err
+	}
+	return index == 0, nil
+}
+
+// describeVersion returns a version of a conflicting item as a line of JSON, or "deleted".
+func describeVersion(version interface{}) string {
+	if version == nil {
+		return "deleted"
+	}
+	data, err := json.Marshal(version)
+	if err != nil {
+		return fmt.Sprint(version)
+	}
+	return string(data)
+}
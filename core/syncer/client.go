@@ -0,0 +1,376 @@
+package syncer
+
+import (
+	"bytes"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+)
+
+// StateFolder is the folder inside the profiles folder where a device keeps the sync server
+// it syncs with and the version of every profile it last synced.
+const StateFolder string = "sync"
+
+// Result tells what syncing a profile did.
+type Result string
+
+const (
+	UpToDate Result = "up to date"
+	Pulled   Result = "pulled"
+	Pushed   Result = "pushed"
+	Merged   Result = "merged"
+	Deleted  Result = "deleted"
+)
+
+// Remote is the sync server a device syncs with. The token is an API token of the user
+// account on the server, and is empty for servers without user accounts.
+type Remote struct {
+	URL   string `json:"url"`
+	Token string `json:"token"`
+}
+
+// base is the version of a profile this device last synced, which both the profile on this
+// device and the one on the server started from. Deleted is set once the deletion of the
+// profile was synced, so a profile missing on this device can be told apart from one deleted here.
+type base struct {
+	Revision int                `json:"revision"`
+	Deleted  bool               `json:"deleted,omitempty"`
+	Profile  config.ProfileData `json:"profile"`
+}
+
+// remotePath returns the path of the file containing the sync server.
+func remotePath() string {
+	return filepath.Join(config.ProfilesFolder, StateFolder, "remote.json")
+}
+
+// basePath returns the path of the file containing the version of a profile last synced.
+func basePath(name string) string {
+	return filepath.Join(config.ProfilesFolder, StateFolder, "profiles", strings.ToLower(name)+".json")
+}
+
+// readState reads a JSON file of the state folder into the value, reporting whether it exists.
+func readState(path string, value interface{}) (bool, error) {
+	data, err := os.ReadFile(path)
+	if goerrors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, json.Unmarshal(data, value)
+}
+
+// writeState writes the value as a JSON file of the state folder, which is only readable
+// by the user running wallkeiro, since the remote contains the API token.
+func writeState(path string, value interface{}) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// ReadRemote returns the sync server this device syncs with, or errors.ErrNoSyncServer.
+func ReadRemote() (Remote, error) {
+	var remote Remote
+	ok, err := readState(remotePath(), &remote)
+	if err == nil && !ok {
+		err = errors.ErrNoSyncServer
+	}
+	return remote, err
+}
+
+// SaveRemote sets the sync server this device syncs with.
+func SaveRemote(remote Remote) error {
+	if _, err := url.ParseRequestURI(remote.URL); err != nil || !strings.HasPrefix(remote.URL, "http") {
+		return fmt.Errorf("%w: %q is not an http or https URL", errors.ErrInvalidRequest, remote.URL)
+	}
+	remote.URL = strings.TrimRight(remote.URL, "/")
+	return writeState(remotePath(), remote)
+}
+
+// Client talks to a sync server.
+type Client struct {
+	Remote Remote
+	HTTP   *http.Client
+}
+
+// NewClient returns a client of the given sync server.
+func NewClient(remote Remote) *Client {
+	return &Client{Remote: remote, HTTP: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// do sends a request to the server and decodes the JSON response into the value.
+// A 409 Conflict response is decoded as well, and returned as errors.ErrOutdated.
+func (c *Client) do(method, path string, body, value interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	request, err := http.NewRequest(method, c.Remote.URL+"/sync/v1/"+path, reader)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if c.Remote.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+c.Remote.Token)
+	}
+	response, err := c.HTTP.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	switch response.StatusCode {
+	case http.StatusOK:
+		return json.NewDecoder(response.Body).Decode(value)
+	case http.StatusConflict:
+		if err := json.NewDecoder(response.Body).Decode(value); err != nil {
+			return err
+		}
+		return errors.ErrOutdated
+	case http.StatusNotFound:
+		return errors.ErrNotFound
+	case http.StatusUnauthorized:
+		return errors.ErrUnauthorized
+	}
+	var failure struct {
+		Error string `json:"error"`
+	}
+	json.NewDecoder(response.Body).Decode(&failure)
+	return fmt.Errorf("sync server answered %s: %s", response.Status, failure.Error)
+}
+
+// List returns the names and revisions of the profiles on the server.
+func (c *Client) List() ([]Document, error) {
+	var documents []Document
+	err := c.do(http.MethodGet, "profiles", nil, &documents)
+	return documents, err
+}
+
+// Get returns the latest version of the profile on the server.
+func (c *Client) Get(name string) (Document, error) {
+	var document Document
+	err := c.do(http.MethodGet, "profiles/"+url.PathEscape(name), nil, &document)
+	return document, err
+}
+
+// Put stores a new version of the profile on the server, based on the given revision.
+// If the profile changed on the server since, it returns errors.ErrOutdated together with the latest version.
+func (c *Client) Put(name string, baseRevision int, profile config.ProfileData) (Document, error) {
+	var document Document
+	err := c.do(http.MethodPut, "profiles/"+url.PathEscape(name), pushRequest{BaseRevision: baseRevision, Profile: profile}, &document)
+	return document, err
+}
+
+// Delete deletes the profile on the server, based on the given revision.
+// If the profile changed on the server since, it returns errors.ErrOutdated together with the latest version.
+func (c *Client) Delete(name string, baseRevision int) (Document, error) {
+	var document Document
+	err := c.do(http.MethodPut, "profiles/"+url.PathEscape(name), pushRequest{BaseRevision: baseRevision, Deleted: true}, &document)
+	return document, err
+}
+
+// Sync syncs the profile on this device with the server: changes made only on this device are
+// pushed, changes made only on the server are pulled, and changes made on both are merged and
+// pushed. Profiles missing on one side are copied to it, unless they were deleted there since
+// the last sync: then they are deleted on the other side as well, if they did not change there. For every conflict, resolve is asked
+// whether to keep the version of this device instead of the one on the server; if resolve is
+// nil, Sync fails with errors.ErrSyncConflict and changes nothing.
+func (c *Client) Sync(name string, resolve func(conflict Conflict) (bool, error)) (Result, error) {
+	local, err := config.ReadProfile(name)
+	localExists := err == nil
+	if err != nil && !goerrors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	var last base
+	synced, err := readState(basePath(name), &last)
+	if err != nil {
+		return "", err
+	}
+	// a profile synced before and missing now was deleted on this device
+	deletedHere := !localExists && synced && !last.Deleted
+	// another device may push between fetching the profile and pushing it, then the merge is repeated
+	for attempt := 0; attempt < 3; attempt++ {
+		remote, err := c.Get(name)
+		if err == errors.ErrNotFound {
+			if !localExists {
+				return "", errors.ErrNotFound
+			}
+			document, err := c.Put(name, 0, local)
+			if err == errors.ErrOutdated {
+				continue
+			}
+			if err != nil {
+				return "", err
+			}
+			return Pushed, writeState(basePath(name), base{Revision: document.Revision, Profile: local})
+		}
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case !localExists && remote.Deleted:
+			return UpToDate, writeState(basePath(name), base{Revision: remote.Revision, Deleted: true})
+		case deletedHere && remote.Revision == last.Revision:
+			document, err := c.Delete(name, remote.Revision)
+			if err == errors.ErrOutdated {
+				continue
+			}
+			if err != nil {
+				return "", err
+			}
+			return Deleted, writeState(basePath(name), base{Revision: document.Revision, Deleted: true})
+		case remote.Deleted && synced && !last.Deleted && equal(local, last.Profile):
+			if err := config.DeleteProfile(name); err != nil {
+				return "", err
+			}
+			return Deleted, writeState(basePath(name), base{Revision: remote.Revision, Deleted: true})
+		case remote.Deleted:
+			// changed here since it was deleted on the server, or created here again, so it is kept
+			document, err := c.Put(name, remote.Revision, local)
+			if err == errors.ErrOutdated {
+				continue
+			}
+			if err != nil {
+				return "", err
+			}
+			return Pushed, writeState(basePath(name), base{Revision: document.Revision, Profile: local})
+		}
+		// a profile deleted here but changed on the server since is pulled again, so no change is lost
+		if !localExists || equal(local, last.Profile) {
+			if remote.Revision == last.Revision && localExists {
+				return UpToDate, nil
+			}
+			if err := writeLocal(name, local, remote.Profile); err != nil {
+				return "", err
+			}
+			return Pulled, writeState(basePath(name), base{Revision: remote.Revision, Profile: remote.Profile})
+		}
+		result, merged := Pushed, local
+		if remote.Revision != last.Revision {
+			var conflicts []Conflict
+			result = Merged
+			merged, conflicts, err = Merge(last.Profile, local, remote.Profile)
+			if err != nil {
+				return "", err
+			}
+			if len(conflicts) > 0 && resolve == nil {
+				var descriptions []string
+				for _, conflict := range conflicts {
+					descriptions = append(descriptions, conflict.Describe())
+				}
+				return "", fmt.Errorf("%w: %s", errors.ErrSyncConflict, strings.Join(descriptions, "; "))
+			}
+			for _, conflict := range conflicts {
+				keepLocal, err := resolve(conflict)
+				if err != nil {
+					return "", err
+				}
+				if keepLocal {
+					if err := conflict.KeepLocal(&merged); err != nil {
+						return "", err
+					}
+				}
+			}
+		}
+		document := remote
+		if !equal(merged, remote.Profile) {
+			document, err = c.Put(name, remote.Revision, merged)
+			if err == errors.ErrOutdated {
+				continue
+			}
+			if err != nil {
+				return "", err
+			}
+		} else {
+			result = Pulled
+		}
+		if err := writeLocal(name, local, merged); err != nil {
+			return "", err
+		}
+		return result, writeState(basePath(name), base{Revision: document.Revision, Profile: merged})
+	}
+	return "", errors.ErrOutdated
+}
+
+// writeLocal writes the synced version of the profile over the one read when the sync started.
+// If the profile was changed on this device meanwhile, the changes are merged into the synced
+// version, keeping them in conflicts, and the merge is written instead. They stay out of the
+// base, which is the synced version, so the next sync pushes them.
+func writeLocal(name string, local, synced config.ProfileData) error {
+	for attempt := 0; attempt < 3; attempt++ {
+		written := synced
+		written.Version = local.Version
+		err := config.UpdateProfile(name, &written)
+		if !goerrors.Is(err, errors.ErrProfileChanged) {
+			return err
+		}
+		current, err := config.ReadProfile(name)
+		if err != nil {
+			return err
+		}
+		merged, conflicts, err := Merge(local, current, synced)
+		if err != nil {
+			return err
+		}
+		for _, conflict := range conflicts {
+			if err := conflict.KeepLocal(&merged); err != nil {
+				return err
+			}
+		}
+		local, synced = current, merged
+	}
+	return errors.ErrProfileChanged
+}
+
+// Synced tells what syncing a profile did.
+type Synced struct {
+	Name   string
+	Result Result
+}
+
+// SyncAll syncs every profile on this device and on the server, and returns what syncing
+// each of them did. It stops at the first profile that fails to sync.
+func (c *Client) SyncAll(resolve func(conflict Conflict) (bool, error)) ([]Synced, error) {
+	synced := []Synced{}
+	names := config.GetProfiles()
+	documents, err := c.List()
+	if err != nil {
+		return synced, err
+	}
+	for _, document := range documents {
+		found := false
+		for _, name := range names {
+			found = found || strings.EqualFold(name, document.Name)
+		}
+		// profiles deleted everywhere are not synced anymore
+		if !found && !document.Deleted {
+			names = append(names, document.Name)
+		}
+	}
+	for _, name := range names {
+		result, err := c.Sync(name, resolve)
+		if err != nil {
+			return synced, fmt.Errorf("%s: %w", name, err)
+		}
+		synced = append(synced, Synced{Name: name, Result: result})
+	}
+	return synced, nil
+}
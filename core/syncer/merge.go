@@ -0,0 +1,247 @@
+package syncer
+
+import (
+	"encoding/json"
+	"fmt"
+	"wallkeiro/core/config"
+)
+
+// Conflict is a change made on this device that contradicts a change made on the sync server
+// since the last sync: both set the same field of the same item to different values, or one
+// changed an item the other deleted. Local and Remote are the two versions of the field,
+// or of the whole item if Field is empty, nil if it was deleted.
+type Conflict struct {
+	Kind   string
+	Name   string
+	Field  string
+	Local  interface{}
+	Remote interface{}
+	// operations are the operations of this device that make the merged profile use the local version.
+	operations []config.Operation
+}
+
+// KeepLocal makes the merged profile use the local version of the conflicting field or item
+// instead of the remote one, which Merge keeps by default.
+func (c Conflict) KeepLocal(p *config.ProfileData) error {
+	return config.Apply(p, c.operations)
+}
+
+// Describe returns a line telling what the conflict is about.
+func (c Conflict) Describe() string {
+	switch {
+	case c.Field != "" && c.Kind == "settings":
+		return fmt.Sprintf("setting %s was changed both here and on the server", c.Field)
+	case c.Field != "":
+		return fmt.Sprintf("%s of %s %s was changed both here and on the server", c.Field, c.Kind, c.Name)
+	case c.Local == nil:
+		return fmt.Sprintf("%s %s was deleted here but changed on the server", c.Kind, c.Name)
+	case c.Remote == nil:
+		return fmt.Sprintf("%s %s was changed here but deleted on the server", c.Kind, c.Name)
+	}
+	return fmt.Sprintf("%s %s was added both here and on the server", c.Kind, c.Name)
+}
+
+// kinds are the names of the items of the lists of a profile, for describing conflicts.
+var kinds = map[string]string{"config": "settings", "expenses": "expense", "debts": "debt", "goals": "goal", "transactions": "transaction", "rules": "rule"}
+
+// changes are the operations of one side changing one item, by the field they set.
+type changes struct {
+	added   json.RawMessage
+	removed bool
+	fields  map[string]json.RawMessage
+}
+
+// Merge merges the changes made to a profile on this device (local) and on the sync server
+// (remote) since the version both started from (base). The changes of this device are turned
+// into operations (see config.Diff) and replayed on the remote profile, so additions on both
+// sides are both kept, and edits of different fields of the same expense are combined.
+// Operations contradicting a change of the server are returned as conflicts instead, and the
+// merged profile has the remote version until Conflict.KeepLocal is called.
+func Merge(base, local, remote config.ProfileData) (config.ProfileData, []Conflict, error) {
+	localOperations, err := config.Diff(base, local)
+	if err != nil {
+		return remote, nil, err
+	}
+	remoteOperations, err := config.Diff(base, remote)
+	if err != nil {
+		return remote, nil, err
+	}
+	remoteChanges := map[string]*changes{}
+	for _, operation := range remoteOperations {
+		item := changesOf(remoteChanges, operation)
+		switch operation.Type {
+		case config.OpAdd:
+			item.added = operation.Value
+		case config.OpRemove:
+			item.removed = true
+		case config.OpSet:
+			item.fields[operation.Field] = operation.Value
+		}
+	}
+	merged := remote
+	var conflicts []Conflict
+	// itemConflicts collects the conflicts about whole items, so an item changed in several
+	// fields here and deleted on the server is a single conflict
+	itemConflicts := map[string]int{}
+	for _, operation := range localOperations {
+		remoteItem := changesOf(remoteChanges, operation)
+		conflict := Conflict{Kind: kinds[operation.List], Name: itemName(base, local, remote, operation), operations: []config.Operation{operation}}
+		switch {
+		case operation.Type == config.OpSet && remoteItem.removed:
+			conflict.Local = itemValue(local, operation.List, operation.ID)
+			conflict.operations = []config.Operation{{Type: config.OpAdd, List: operation.List, ID: operation.ID, Value: mustJSON(conflict.Local)}}
+			if index, ok := itemConflicts[operation.List+"/"+operation.ID]; ok {
+				conflicts[index] = conflict
+				continue
+			}
+			itemConflicts[operation.List+"/"+operation.ID] = len(conflicts)
+		case operation.Type == config.OpSet && remoteItem.fields[operation.Field] != nil:
+			if string(remoteItem.fields[operation.Field]) == string(operation.Value) {
+				continue
+			}
+			conflict.Field = operation.Field
+			conflict.Local = decode(operation.Value)
+			conflict.Remote = decode(remoteItem.fields[operation.Field])
+		case operation.Type == config.OpRemove && len(remoteItem.fields) > 0:
+			conflict.Remote = itemValue(remote, operation.List, operation.ID)
+		case operation.Type == config.OpAdd && remoteItem.added != nil:
+			if equal(decode(remoteItem.added), decode(operation.Value)) {
+				continue
+			}
+			conflict.Local = decode(operation.Value)
+			conflict.Remote = decode(remoteItem.added)
+		default:
+			if err := config.Apply(&merged, conflict.operations); err != nil {
+				return remote, nil, err
+			}
+			continue
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return merged, conflicts, nil
+}
+
+// MergeKeepingLocal merges the JSON of the versions of a profile like Merge, keeping the local
+// version of every conflicting field or item, since it was written last. It is used by the
+// storages that merge a write refused by the server without asking the user. A missing base
+// stands for a profile created on both sides.
+func MergeKeepingLocal(base, local, remote []byte) ([]byte, error) {
+	var baseData, localData, remoteData config.ProfileData
+	if base != nil {
+		if err := json.Unmarshal(base, &baseData); err != nil {
+			return nil, err
+		}
+	}
+	if err := json.Unmarshal(local, &localData); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(remote, &remoteData); err != nil {
+		return nil, err
+	}
+	for _, data := range []*config.ProfileData{&baseData, &localData, &remoteData} {
+		config.AssignIDs(data)
+	}
+	merged, conflicts, err := Merge(baseData, localData, remoteData)
+	if err != nil {
+		return nil, err
+	}
+	for _, conflict := range conflicts {
+		if err := conflict.KeepLocal(&merged); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(merged)
+}
+
+// changesOf returns the changes of the item the operation is about, creating them if there are none yet.
+func changesOf(all map[string]*changes, operation config.Operation) *changes {
+	key := operation.List + "/" + operation.ID
+	if all[key] == nil {
+		all[key] = &changes{fields: map[string]json.RawMessage{}}
+	}
+	return all[key]
+}
+
+// itemName returns the name of the item the operation is about, from whichever version has it.
+func itemName(base, local, remote config.ProfileData, operation config.Operation) string {
+	if operation.List == "config" {
+		return ""
+	}
+	for _, p := range []config.ProfileData{local, remote, base} {
+		if value, ok := itemValue(p, operation.List, operation.ID).(map[string]interface{}); ok {
+			if name, ok := value["name"].(string); ok && name != "" {
+				return name
+			}
+		}
+	}
+	return operation.ID
+}
+
+// itemValue returns the item of the list with the given ID as decoded JSON, or nil if there is none.
+func itemValue(p config.ProfileData, list, id string) interface{} {
+	var fields map[string]json.RawMessage
+	var items []map[string]interface{}
+	if json.Unmarshal(mustJSON(p), &fields) != nil || json.Unmarshal(fields[list], &items) != nil {
+		return nil
+	}
+	for _, value := range items {
+		if value[config.KeyField(list)] == id {
+			return value
+		}
+	}
+	return nil
+}
+
+// decode returns the JSON value as plain Go values.
+func decode(value json.RawMessage) interface{} {
+	var decoded interface{}
+	json.Unmarshal(value, &decoded)
+	return decoded
+}
+
+// mustJSON returns the JSON of a value that can always be marshalled.
+func mustJSON(value interface{}) json.RawMessage {
+	data, _ := json.Marshal(value)
+	return data
+}
+
+// equal reports whether two values have the same JSON, leaving out empty lists and objects
+// and nulls, since a nil list is marshalled as null and an empty one as [].
+func equal(a, b interface{}) bool {
+	var decodedA, decodedB interface{}
+	if json.Unmarshal(mustJSON(a), &decodedA) != nil || json.Unmarshal(mustJSON(b), &decodedB) != nil {
+		return false
+	}
+	return string(mustJSON(withoutEmpty(decodedA))) == string(mustJSON(withoutEmpty(decodedB)))
+}
+
+// withoutEmpty removes the fields holding null, an empty list or an empty object from the
+// decoded JSON value and the values inside it.
+func withoutEmpty(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			field = withoutEmpty(field)
+			switch field := field.(type) {
+			case nil:
+				delete(value, key)
+			case map[string]interface{}:
+				if len(field) == 0 {
+					delete(value, key)
+				}
+			case []interface{}:
+				if len(field) == 0 {
+					delete(value, key)
+				}
+			}
+			if _, ok := value[key]; ok {
+				value[key] = field
+			}
+		}
+	case []interface{}:
+		for i := range value {
+			value[i] = withoutEmpty(value[i])
+		}
+	}
+	return value
+}
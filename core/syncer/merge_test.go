@@ -0,0 +1,78 @@
+package syncer
+
+import (
+	"fmt"
+	"testing"
+	"wallkeiro/core/config"
+)
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name          string
+		local, remote func(p *config.ProfileData)
+		// want is the rent and food amounts of the merged profile, -1 for a deleted expense
+		want []float64
+		// conflicts describe the conflicts reported
+		conflicts []string
+	}{
+		{
+			name:   "edits to different fields of one expense are combined",
+			local:  func(p *config.ProfileData) { p.Expenses[0].Amount = 950 },
+			remote: func(p *config.ProfileData) { p.Expenses[0].DueDay = 5 },
+			want:   []float64{950, 300},
+		},
+		{
+			name:      "a remove here against an edit there is a conflict",
+			local:     func(p *config.ProfileData) { p.Expenses = p.Expenses[1:] },
+			remote:    func(p *config.ProfileData) { p.Expenses[0].Amount = 950 },
+			want:      []float64{950, 300},
+			conflicts: []string{"expense Rent was deleted here but changed on the server"},
+		},
+		{
+			name:      "an edit here against a remove there is a conflict",
+			local:     func(p *config.ProfileData) { p.Expenses[0].Amount = 950 },
+			remote:    func(p *config.ProfileData) { p.Expenses = p.Expenses[1:] },
+			want:      []float64{-1, 300},
+			conflicts: []string{"expense Rent was changed here but deleted on the server"},
+		},
+		{
+			name:      "different values of one field are a conflict",
+			local:     func(p *config.ProfileData) { p.Expenses[1].Amount = 250 },
+			remote:    func(p *config.ProfileData) { p.Expenses[1].Amount = 350 },
+			want:      []float64{900, 350},
+			conflicts: []string{"amount of expense Food was changed both here and on the server"},
+		},
+		{
+			name:   "the same remove on both sides is no conflict",
+			local:  func(p *config.ProfileData) { p.Expenses = p.Expenses[:1] },
+			remote: func(p *config.ProfileData) { p.Expenses = p.Expenses[:1] },
+			want:   []float64{900, -1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			base := config.ProfileData{Expenses: []config.ExpensesStuct{{Name: "Rent", Amount: 900, DueDay: 1}, {Name: "Food", Amount: 300}}}
+			config.AssignIDs(&base)
+			local, remote := base, base
+			for _, p := range []*config.ProfileData{&local, &remote} {
+				p.Expenses = append([]config.ExpensesStuct(nil), base.Expenses...)
+			}
+			test.local(&local)
+			test.remote(&remote)
+			merged, conflicts, err := Merge(base, local, remote)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := []float64{amountOf(merged, "Rent"), amountOf(merged, "Food")}; fmt.Sprint(got) != fmt.Sprint(test.want) {
+				t.Fatalf("merged rent and food amounts are %v, want %v", got, test.want)
+			}
+			var described []string
+			for _, conflict := range conflicts {
+				described = append(described, conflict.Describe())
+			}
+			if fmt.Sprint(described) != fmt.Sprint(test.conflicts) {
+				t.Fatalf("got the conflicts %q, want %q", described, test.conflicts)
+			}
+		})
+	}
+}
@@ -0,0 +1,352 @@
+// Package syncer keeps the profiles of several devices in sync through a small wallkeiro
+// sync server. Every device keeps its own copy of its profiles and works offline; syncing
+// pushes the changes made on the device to the server and pulls the changes made on the
+// others. The server gives every version of a profile a revision number and only accepts
+// a push based on its latest revision, so two devices never overwrite each other's changes.
+// A device that is behind replays its own changes on the latest version of the server first,
+// as operations on single fields of the items (see config.Operation), and asks the user
+// which version to keep when both changed the same field of an expense in different ways.
+package syncer
+
+import (
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"wallkeiro/core/auth"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/validate"
+)
+
+// DefaultAddress is the address the sync server is served on by default.
+const DefaultAddress string = "127.0.0.1:8423"
+
+// DefaultFolder is the folder the sync server stores the profiles in by default.
+var DefaultFolder = filepath.Join(config.ProfilesFolder, "sync-server")
+
+// maxBodySize is the largest request body accepted, which is plenty for a whole profile.
+const maxBodySize int64 = 10 << 20
+
+// Document is a version of a profile stored on the sync server. A deleted profile is kept as
+// a document without a profile, so devices that synced it before delete it as well instead of
+// pushing it again.
+type Document struct {
+	Name     string             `json:"name"`
+	Revision int                `json:"revision"`
+	Updated  time.Time          `json:"updated"`
+	Deleted  bool               `json:"deleted,omitempty"`
+	Profile  config.ProfileData `json:"profile"`
+}
+
+// Storage stores the latest version of every profile on the sync server. Every user of the
+// server has their own namespace, so users only sync their own profiles.
+// Get returns errors.ErrNotFound for profiles that were never pushed.
+type Storage interface {
+	List(namespace string) ([]Document, error)
+	Get(namespace, name string) (Document, error)
+	Put(namespace string, document Document) error
+}
+
+// MemoryStorage keeps the profiles in memory, for servers running inside another program.
+type MemoryStorage struct {
+	mu        sync.Mutex
+	documents map[string]map[string]Document
+}
+
+// NewMemoryStorage returns an empty MemoryStorage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{documents: map[string]map[string]Document{}}
+}
+
+// List returns the profiles of the namespace, sorted by name.
+func (m *MemoryStorage) List(namespace string) ([]Document, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	documents := []Document{}
+	for _, document := range m.documents[namespace] {
+		documents = append(documents, document)
+	}
+	sort.Slice(documents, func(i, j int) bool { return documents[i].Name < documents[j].Name })
+	return documents, nil
+}
+
+// Get returns the profile of the namespace with the given name.
+func (m *MemoryStorage) Get(namespace, name string) (Document, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	document, ok := m.documents[namespace][strings.ToLower(name)]
+	if !ok {
+		return Document{}, errors.ErrNotFound
+	}
+	return document, nil
+}
+
+// Put stores the profile in the namespace.
+func (m *MemoryStorage) Put(namespace string, document Document) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.documents[namespace] == nil {
+		m.documents[namespace] = map[string]Document{}
+	}
+	m.documents[namespace][strings.ToLower(document.Name)] = document
+	return nil
+}
+
+// DirStorage keeps every profile in a JSON file in a folder per namespace.
+type DirStorage struct {
+	Folder string
+}
+
+// NewDirStorage returns a DirStorage keeping the profiles in the given folder.
+func NewDirStorage(folder string) *DirStorage {
+	return &DirStorage{Folder: folder}
+}
+
+// namespaceFolder returns the folder of a namespace. The namespace of a server without
+// user accounts is empty and uses the folder itself.
+func (d *DirStorage) namespaceFolder(namespace string) string {
+	if namespace == "" {
+		return d.Folder
+	}
+	return filepath.Join(d.Folder, "users", strings.ToLower(namespace))
+}
+
+// List returns the profiles of the namespace, sorted by name.
+func (d *DirStorage) List(namespace string) ([]Document, error) {
+	documents := []Document{}
+	files, err := os.ReadDir(d.namespaceFolder(namespace))
+	if goerrors.Is(err, fs.ErrNotExist) {
+		return documents, nil
+	}
+	if err != nil {
+		return documents, err
+	}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		document, err := d.Get(namespace, strings.TrimSuffix(file.Name(), ".json"))
+		if err != nil {
+			return documents, err
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+// Get returns the profile of the namespace with the given name.
+func (d *DirStorage) Get(namespace, name string) (Document, error) {
+	var document Document
+	data, err := os.ReadFile(filepath.Join(d.namespaceFolder(namespace), strings.ToLower(name)+".json"))
+	if goerrors.Is(err, fs.ErrNotExist) {
+		return document, errors.ErrNotFound
+	}
+	if err != nil {
+		return document, err
+	}
+	err = json.Unmarshal(data, &document)
+	return document, err
+}
+
+// Put stores the profile in the namespace. The file is written next to the old one first
+// and then renamed, so a crash never leaves half a profile behind.
+func (d *DirStorage) Put(namespace string, document Document) error {
+	folder := d.namespaceFolder(namespace)
+	if err := os.MkdirAll(folder, 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(folder, strings.ToLower(document.Name)+".json")
+	if err := os.WriteFile(path+".tmp", data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// Server is the sync server. It serves the profiles of its storage under /sync/v1/profiles:
+// GET lists them or returns one, and PUT stores a new version of one, or deletes it, given
+// the revision it is based on. A PUT based on an older revision is refused with 409 Conflict and the
+// latest version, which the device has to merge before pushing again.
+type Server struct {
+	Storage Storage
+	// Login returns the namespace of the user who sent the request. If it is nil,
+	// every request is accepted and uses the empty namespace.
+	Login func(r *http.Request) (string, error)
+	mu    sync.Mutex
+}
+
+// NewServer returns a sync server storing the profiles in the given storage,
+// which accepts every request.
+func NewServer(storage Storage) *Server {
+	return &Server{Storage: storage}
+}
+
+// AccountLogin logs in the requests of a sync server with the user accounts of wallkeiro,
+// like the API does: as long as there are no accounts, only requests addressed to localhost
+// are accepted, and once there are, every request has to log in and every user syncs their
+// own profiles.
+func AccountLogin(r *http.Request) (string, error) {
+	if !auth.Enabled() && !auth.LocalHost(r.Host) {
+		return "", errors.ErrNotLocalhost
+	}
+	store, err := auth.Login(r.Header.Get("Authorization"))
+	return store.User, err
+}
+
+// Serve serves the sync server on the given address until it fails.
+func (s *Server) Serve(address string) error {
+	server := &http.Server{
+		Addr:              address,
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+	log.Printf("Serving the wallkeiro sync server on http://%s/sync/v1/", address)
+	return server.ListenAndServe()
+}
+
+// pushRequest is the body of a PUT, a new version of a profile and the revision it is based on,
+// 0 for a profile that is new to the server. If Deleted is set, the profile was deleted instead.
+type pushRequest struct {
+	BaseRevision int                `json:"base_revision"`
+	Deleted      bool               `json:"deleted,omitempty"`
+	Profile      config.ProfileData `json:"profile"`
+}
+
+// ServeHTTP handles a request to the sync server.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	namespace := ""
+	if s.Login != nil {
+		var err error
+		namespace, err = s.Login(r)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Basic realm="wallkeiro", charset="UTF-8"`)
+			writeFailure(w, err)
+			return
+		}
+		if err := validate.Name(namespace); namespace != "" && err != nil {
+			writeFailure(w, err)
+			return
+		}
+	}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 3 || parts[0] != "sync" || parts[1] != "v1" || parts[2] != "profiles" || len(parts) > 4 {
+		writeFailure(w, errors.ErrNotFound)
+		return
+	}
+	if len(parts) == 3 {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+		documents, err := s.Storage.List(namespace)
+		if err != nil {
+			writeFailure(w, err)
+			return
+		}
+		// the list only tells the names and revisions, the profiles are fetched one at a time
+		for i := range documents {
+			documents[i].Profile = config.ProfileData{}
+		}
+		writeJSON(w, http.StatusOK, documents)
+		return
+	}
+	name := parts[3]
+	if err := validate.Name(name); err != nil {
+		writeFailure(w, err)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		document, err := s.Storage.Get(namespace, name)
+		if err != nil {
+			writeFailure(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, document)
+	case http.MethodPut:
+		var request pushRequest
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("%w: %v", errors.ErrInvalidRequest, err))
+			return
+		}
+		if err := validate.Profile(request.Profile); err != nil && !request.Deleted {
+			writeFailure(w, err)
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		current, err := s.Storage.Get(namespace, name)
+		if err != nil && err != errors.ErrNotFound {
+			writeFailure(w, err)
+			return
+		}
+		if request.BaseRevision != current.Revision {
+			writeJSON(w, http.StatusConflict, current)
+			return
+		}
+		if request.Deleted && (current.Name == "" || current.Deleted) {
+			writeFailure(w, errors.ErrNotFound)
+			return
+		}
+		document := Document{Name: name, Revision: current.Revision + 1, Updated: time.Now().UTC(), Deleted: request.Deleted}
+		if !request.Deleted {
+			document.Profile = request.Profile
+		}
+		if current.Name != "" {
+			document.Name = current.Name
+		}
+		if err := s.Storage.Put(namespace, document); err != nil {
+			writeFailure(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, document)
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+	}
+}
+
+// writeJSON writes the value as the JSON body of the response with the given status.
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+// writeError writes the error as a JSON body with the given status.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// writeFailure writes the error with the status that fits it.
+func writeFailure(w http.ResponseWriter, err error) {
+	switch {
+	case goerrors.Is(err, errors.ErrUnauthorized):
+		writeError(w, http.StatusUnauthorized, err)
+	case goerrors.Is(err, errors.ErrForbidden), goerrors.Is(err, errors.ErrNotLocalhost):
+		writeError(w, http.StatusForbidden, err)
+	case goerrors.Is(err, errors.ErrNotFound):
+		writeError(w, http.StatusNotFound, errors.ErrNotFound)
+	case validate.InvalidInput(err):
+		writeError(w, http.StatusBadRequest, err)
+	default:
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,312 @@
+package syncer
+
+import (
+	goerrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+)
+
+// testDevices starts a sync server keeping the profiles in memory and returns a client of it,
+// and the folders of two devices. useDevice switches between the devices.
+func testDevices(t *testing.T) (*Client, string, string) {
+	t.Helper()
+	server := httptest.NewServer(NewServer(NewMemoryStorage()))
+	t.Cleanup(server.Close)
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(workingDirectory) })
+	var devices []string
+	for i := 0; i < 2; i++ {
+		folder := t.TempDir()
+		if err := os.Mkdir(folder+"/"+config.ProfilesFolder, 0755); err != nil {
+			t.Fatal(err)
+		}
+		devices = append(devices, folder)
+	}
+	return NewClient(Remote{URL: server.URL}), devices[0], devices[1]
+}
+
+// useDevice makes the profiles folder of the device the one config reads and writes.
+func useDevice(t *testing.T, device string) {
+	t.Helper()
+	if err := os.Chdir(device); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// mustSync syncs the profile and fails the test unless syncing did what was expected.
+func mustSync(t *testing.T, client *Client, name string, resolve func(Conflict) (bool, error), expected Result) {
+	t.Helper()
+	result, err := client.Sync(name, resolve)
+	if err != nil {
+		t.Fatalf("syncing %s: %v", name, err)
+	}
+	if result != expected {
+		t.Fatalf("syncing %s: got %q, want %q", name, result, expected)
+	}
+}
+
+// mustRead reads the profile and fails the test if it can not be read.
+func mustRead(t *testing.T, name string) config.ProfileData {
+	t.Helper()
+	profile, err := config.ReadProfile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return profile
+}
+
+// edit reads the profile, changes it and writes it.
+func edit(t *testing.T, name string, change func(p *config.ProfileData)) {
+	t.Helper()
+	profile := mustRead(t, name)
+	change(&profile)
+	if err := config.UpdateProfile(name, &profile); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// amountOf returns the amount of the expense with the given name, or -1 if there is none.
+func amountOf(p config.ProfileData, expense string) float64 {
+	for _, e := range p.Expenses {
+		if e.Name == expense {
+			return e.Amount
+		}
+	}
+	return -1
+}
+
+// syncedHome creates the profile "home" with a rent of 900 on the first device and syncs it
+// to the second.
+func syncedHome(t *testing.T, client *Client, a, b string) {
+	t.Helper()
+	useDevice(t, a)
+	if err := config.CreateNewProfile("home"); err != nil {
+		t.Fatal(err)
+	}
+	edit(t, "home", func(p *config.ProfileData) {
+		p.Expenses = append(p.Expenses, config.ExpensesStuct{Name: "Rent", Amount: 900})
+	})
+	mustSync(t, client, "home", nil, Pushed)
+	useDevice(t, b)
+	synced, err := client.SyncAll(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(synced) != 1 || synced[0].Result != Pulled {
+		t.Fatalf("got %v, want home pulled", synced)
+	}
+}
+
+func TestPushAndPull(t *testing.T) {
+	client, a, b := testDevices(t)
+	syncedHome(t, client, a, b)
+	if amount := amountOf(mustRead(t, "home"), "Rent"); amount != 900 {
+		t.Fatalf("pulled rent is %v, want 900", amount)
+	}
+	mustSync(t, client, "home", nil, UpToDate)
+	edit(t, "home", func(p *config.ProfileData) { p.Config.Salary = 3000 })
+	mustSync(t, client, "home", nil, Pushed)
+	useDevice(t, a)
+	mustSync(t, client, "home", nil, Pulled)
+	if salary := mustRead(t, "home").Config.Salary; salary != 3000 {
+		t.Fatalf("pulled salary is %v, want 3000", salary)
+	}
+}
+
+func TestStaleRevisionIsRefused(t *testing.T) {
+	client, _, _ := testDevices(t)
+	first, err := client.Put("home", 0, config.ProfileData{Config: config.ConfigStruct{Salary: 1, SalaryType: config.Fixed, SavingLevel: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Revision != 1 {
+		t.Fatalf("first revision is %d, want 1", first.Revision)
+	}
+	latest, err := client.Put("home", 0, config.ProfileData{Config: config.ConfigStruct{Salary: 2, SalaryType: config.Fixed, SavingLevel: 1}})
+	if err != errors.ErrOutdated {
+		t.Fatalf("push based on a stale revision: got %v, want ErrOutdated", err)
+	}
+	if latest.Revision != 1 || latest.Profile.Config.Salary != 1 {
+		t.Fatalf("the conflict answered %+v, want the latest version", latest)
+	}
+}
+
+func TestMergeWithoutConflicts(t *testing.T) {
+	client, a, b := testDevices(t)
+	syncedHome(t, client, a, b)
+	useDevice(t, a)
+	edit(t, "home", func(p *config.ProfileData) {
+		p.Expenses = append(p.Expenses, config.ExpensesStuct{Name: "Food", Amount: 300})
+	})
+	mustSync(t, client, "home", nil, Pushed)
+	useDevice(t, b)
+	edit(t, "home", func(p *config.ProfileData) { p.Expenses[0].Amount = 950 })
+	mustSync(t, client, "home", nil, Merged)
+	useDevice(t, a)
+	mustSync(t, client, "home", nil, Pulled)
+	for _, device := range []string{a, b} {
+		useDevice(t, device)
+		profile := mustRead(t, "home")
+		if amountOf(profile, "Rent") != 950 || amountOf(profile, "Food") != 300 {
+			t.Fatalf("merged expenses are %+v, want rent 950 and food 300", profile.Expenses)
+		}
+	}
+}
+
+// whilePushing is a transport that calls change before the first profile is pushed, as if the
+// profile were changed on the device while it syncs.
+type whilePushing struct {
+	change func()
+}
+
+func (w *whilePushing) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Method == http.MethodPut && w.change != nil {
+		w.change()
+		w.change = nil
+	}
+	return http.DefaultTransport.RoundTrip(request)
+}
+
+func TestLocalChangeDuringSync(t *testing.T) {
+	client, a, b := testDevices(t)
+	syncedHome(t, client, a, b)
+	useDevice(t, b)
+	edit(t, "home", func(p *config.ProfileData) { p.Expenses[0].Amount = 950 })
+	mustSync(t, client, "home", nil, Pushed)
+
+	useDevice(t, a)
+	edit(t, "home", func(p *config.ProfileData) { p.Config.Salary = 3000 })
+	changing := NewClient(client.Remote)
+	changing.HTTP.Transport = &whilePushing{change: func() {
+		edit(t, "home", func(p *config.ProfileData) {
+			p.Expenses = append(p.Expenses, config.ExpensesStuct{Name: "Food", Amount: 300})
+		})
+	}}
+	mustSync(t, changing, "home", nil, Merged)
+	profile := mustRead(t, "home")
+	if profile.Config.Salary != 3000 || amountOf(profile, "Rent") != 950 || amountOf(profile, "Food") != 300 {
+		t.Fatalf("after the sync the profile has the salary %v and the expenses %+v, want 3000, rent 950 and food 300", profile.Config.Salary, profile.Expenses)
+	}
+
+	// the change made during the sync is pushed by the next one
+	mustSync(t, client, "home", nil, Pushed)
+	useDevice(t, b)
+	mustSync(t, client, "home", nil, Pulled)
+	profile = mustRead(t, "home")
+	if profile.Config.Salary != 3000 || amountOf(profile, "Rent") != 950 || amountOf(profile, "Food") != 300 {
+		t.Fatalf("the other device has the salary %v and the expenses %+v, want 3000, rent 950 and food 300", profile.Config.Salary, profile.Expenses)
+	}
+}
+
+func TestSameNameAddedOnTwoDevices(t *testing.T) {
+	client, a, b := testDevices(t)
+	syncedHome(t, client, a, b)
+	useDevice(t, a)
+	edit(t, "home", func(p *config.ProfileData) {
+		p.Expenses = append(p.Expenses, config.ExpensesStuct{Name: "Food", Amount: 300})
+	})
+	mustSync(t, client, "home", nil, Pushed)
+	useDevice(t, b)
+	edit(t, "home", func(p *config.ProfileData) {
+		p.Expenses = append(p.Expenses, config.ExpensesStuct{Name: "Food", Amount: 50})
+	})
+	mustSync(t, client, "home", nil, Merged)
+	var amounts []float64
+	for _, expense := range mustRead(t, "home").Expenses {
+		if expense.Name == "Food" {
+			amounts = append(amounts, expense.Amount)
+		}
+	}
+	if len(amounts) != 2 {
+		t.Fatalf("food expenses after the merge: %v, want both", amounts)
+	}
+}
+
+func TestConflictResolution(t *testing.T) {
+	client, a, b := testDevices(t)
+	syncedHome(t, client, a, b)
+	useDevice(t, a)
+	edit(t, "home", func(p *config.ProfileData) { p.Expenses[0].Amount = 950 })
+	mustSync(t, client, "home", nil, Pushed)
+	useDevice(t, b)
+	edit(t, "home", func(p *config.ProfileData) { p.Expenses[0].Amount = 1000 })
+	if _, err := client.Sync("home", nil); !goerrors.Is(err, errors.ErrSyncConflict) {
+		t.Fatalf("conflict without resolve: got %v, want ErrSyncConflict", err)
+	}
+	if amount := amountOf(mustRead(t, "home"), "Rent"); amount != 1000 {
+		t.Fatalf("a failed sync changed the rent to %v", amount)
+	}
+	var asked []Conflict
+	keepLocal := func(conflict Conflict) (bool, error) {
+		asked = append(asked, conflict)
+		return true, nil
+	}
+	mustSync(t, client, "home", keepLocal, Merged)
+	if len(asked) != 1 || asked[0].Field != "amount" || asked[0].Local != 1000.0 || asked[0].Remote != 950.0 {
+		t.Fatalf("resolve was asked about %+v, want the amount of the rent", asked)
+	}
+	useDevice(t, a)
+	mustSync(t, client, "home", nil, Pulled)
+	if amount := amountOf(mustRead(t, "home"), "Rent"); amount != 1000 {
+		t.Fatalf("the kept rent is %v, want 1000", amount)
+	}
+	edit(t, "home", func(p *config.ProfileData) { p.Expenses[0].Amount = 1100 })
+	mustSync(t, client, "home", nil, Pushed)
+	useDevice(t, b)
+	edit(t, "home", func(p *config.ProfileData) { p.Expenses[0].Amount = 1200 })
+	keepRemote := func(Conflict) (bool, error) { return false, nil }
+	mustSync(t, client, "home", keepRemote, Pulled)
+	if amount := amountOf(mustRead(t, "home"), "Rent"); amount != 1100 {
+		t.Fatalf("the rent of the server is %v here, want 1100", amount)
+	}
+}
+
+func TestDeletionPropagates(t *testing.T) {
+	client, a, b := testDevices(t)
+	syncedHome(t, client, a, b)
+	useDevice(t, a)
+	if err := config.DeleteProfile("home"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.SyncAll(nil); err != nil {
+		t.Fatal(err)
+	}
+	useDevice(t, b)
+	mustSync(t, client, "home", nil, Deleted)
+	if profiles := config.GetProfiles(); len(profiles) != 0 {
+		t.Fatalf("profiles after the deletion was synced: %v", profiles)
+	}
+	useDevice(t, a)
+	synced, err := client.SyncAll(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(synced) != 0 || len(config.GetProfiles()) != 0 {
+		t.Fatalf("the deleted profile came back: %v", synced)
+	}
+}
+
+func TestDeletionKeepsProfileChangedElsewhere(t *testing.T) {
+	client, a, b := testDevices(t)
+	syncedHome(t, client, a, b)
+	edit(t, "home", func(p *config.ProfileData) { p.Config.Salary = 3000 })
+	useDevice(t, a)
+	if err := config.DeleteProfile("home"); err != nil {
+		t.Fatal(err)
+	}
+	mustSync(t, client, "home", nil, Deleted)
+	useDevice(t, b)
+	mustSync(t, client, "home", nil, Pushed)
+	useDevice(t, a)
+	mustSync(t, client, "home", nil, Pulled)
+	if salary := mustRead(t, "home").Config.Salary; salary != 3000 {
+		t.Fatalf("the profile changed on the other device has a salary of %v, want 3000", salary)
+	}
+}
@@ -0,0 +1,45 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Print prints a table to the console with the given columns and rows.
+// Every column is as wide as its widest cell, and the cells are left aligned.
+func Print(columns []string, rows [][]string) {
+	colWidths := make([]int, len(columns))
+	for colIdx, colName := range columns {
+		colWidths[colIdx] = utf8.RuneCountInString(colName)
+	}
+	for _, row := range rows {
+		for colIdx, cell := range row {
+			if utf8.RuneCountInString(cell) > colWidths[colIdx] {
+				colWidths[colIdx] = utf8.RuneCountInString(cell)
+			}
+		}
+	}
+
+	separator := func() {
+		fmt.Print("+")
+		for _, width := range colWidths {
+			fmt.Print(strings.Repeat("-", width+2), "+")
+		}
+		fmt.Println()
+	}
+
+	separator()
+	for colIdx, colName := range columns {
+		fmt.Printf("| %-*s ", colWidths[colIdx], colName)
+	}
+	fmt.Println("|")
+	separator()
+	for _, row := range rows {
+		for colIdx, cell := range row {
+			fmt.Printf("| %-*s ", colWidths[colIdx], cell)
+		}
+		fmt.Println("|")
+	}
+	separator()
+}
@@ -0,0 +1,660 @@
+package tui
+
+import (
+	goerrors "errors"
+	"fmt"
+	"strings"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/expenses"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// pane is one of the panes of the dashboard that has a list to select from.
+type pane int
+
+const (
+	expensesPane pane = iota
+	goalsPane
+)
+
+// region is a rectangle of the screen.
+type region struct {
+	x, y, width, height int
+}
+
+// contains reports whether the given cell is inside the region.
+func (r region) contains(x, y int) bool {
+	return x >= r.x && x < r.x+r.width && y >= r.y && y < r.y+r.height
+}
+
+var (
+	styleDefault  = tcell.StyleDefault
+	styleTitle    = tcell.StyleDefault.Reverse(true).Bold(true)
+	styleBorder   = tcell.StyleDefault.Foreground(tcell.ColorGray)
+	styleFocused  = tcell.StyleDefault.Foreground(tcell.ColorTeal).Bold(true)
+	styleHeader   = tcell.StyleDefault.Bold(true).Underline(true)
+	styleSelected = tcell.StyleDefault.Reverse(true)
+	styleWarning  = tcell.StyleDefault.Foreground(tcell.ColorRed).Bold(true)
+	styleGood     = tcell.StyleDefault.Foreground(tcell.ColorGreen).Bold(true)
+	styleMuted    = tcell.StyleDefault.Foreground(tcell.ColorGray)
+)
+
+// Dashboard is the full-screen view of a profile with panes for the expenses,
+// the calculation and the savings goals.
+type Dashboard struct {
+	screen      tcell.Screen
+	profile     string
+	data        config.ProfileData
+	focus       pane
+	selected    [2]int
+	offset      [2]int
+	form        *form
+	question    string
+	onConfirm   func()
+	status      string
+	statusStyle tcell.Style
+	regions     [2]region
+	lastButtons tcell.ButtonMask
+}
+
+// Run shows the dashboard of the given profile until the user quits it.
+// Every change made in the dashboard is saved to the profile right away, and the
+// calculation is updated while values are typed into a form, before they are saved.
+func Run(profileName string) error {
+	ProfileData, err := config.ReadProfile(profileName)
+	if err != nil {
+		return err
+	}
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return err
+	}
+	if err := screen.Init(); err != nil {
+		return err
+	}
+	defer screen.Fini()
+	screen.EnableMouse()
+	d := &Dashboard{screen: screen, profile: profileName, data: ProfileData}
+	d.setStatus("Welcome! Press a to add, e to edit, d to delete, s to edit the salary and q to quit.", styleMuted)
+	for {
+		d.draw()
+		switch ev := screen.PollEvent().(type) {
+		case *tcell.EventResize:
+			screen.Sync()
+		case *tcell.EventKey:
+			if d.handleKey(ev) {
+				return nil
+			}
+		case *tcell.EventMouse:
+			d.handleMouse(ev)
+		}
+	}
+}
+
+// handleKey reacts to a key press. It returns true when the dashboard should be closed.
+func (d *Dashboard) handleKey(ev *tcell.EventKey) bool {
+	if ev.Key() == tcell.KeyCtrlC {
+		return true
+	}
+	if d.form != nil {
+		if ev.Key() == tcell.KeyEscape {
+			d.form = nil
+			d.setStatus("Cancelled.", styleMuted)
+			return false
+		}
+		if d.form.handleKey(ev) {
+			d.saveForm()
+		}
+		return false
+	}
+	if d.question != "" {
+		if ev.Key() == tcell.KeyRune && (ev.Rune() == 'y' || ev.Rune() == 'Y') {
+			d.onConfirm()
+		} else {
+			d.setStatus("Cancelled.", styleMuted)
+		}
+		d.question, d.onConfirm = "", nil
+		return false
+	}
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		return true
+	case tcell.KeyTab, tcell.KeyBacktab:
+		d.focus = 1 - d.focus
+	case tcell.KeyUp:
+		d.move(-1)
+	case tcell.KeyDown:
+		d.move(1)
+	case tcell.KeyPgUp:
+		d.move(-10)
+	case tcell.KeyPgDn:
+		d.move(10)
+	case tcell.KeyHome:
+		d.move(-d.count())
+	case tcell.KeyEnd:
+		d.move(d.count())
+	case tcell.KeyEnter:
+		d.edit()
+	case tcell.KeyDelete:
+		d.delete()
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'q':
+			return true
+		case 'k':
+			d.move(-1)
+		case 'j':
+			d.move(1)
+		case 'a':
+			if d.focus == expensesPane {
+				d.form = expenseForm(d.data, -1)
+			} else {
+				d.form = goalForm(d.data, -1)
+			}
+		case 'e':
+			d.edit()
+		case 'd':
+			d.delete()
+		case 's':
+			d.form = settingsForm(d.data)
+		}
+	}
+	return false
+}
+
+// handleMouse selects the row that is clicked on, scrolls the pane under the wheel,
+// and activates the form field that is clicked on.
+func (d *Dashboard) handleMouse(ev *tcell.EventMouse) {
+	x, y := ev.Position()
+	buttons := ev.Buttons()
+	pressed := buttons&tcell.Button1 != 0 && d.lastButtons&tcell.Button1 == 0
+	d.lastButtons = buttons
+	if d.form != nil {
+		if pressed && d.form.region.contains(x, y) {
+			if i := y - d.form.region.y - 2; i >= 0 && i < len(d.form.fields) {
+				d.form.active = i
+			}
+		}
+		return
+	}
+	for _, p := range []pane{expensesPane, goalsPane} {
+		if !d.regions[p].contains(x, y) {
+			continue
+		}
+		switch {
+		case buttons&tcell.WheelUp != 0:
+			d.focus = p
+			d.move(-1)
+		case buttons&tcell.WheelDown != 0:
+			d.focus = p
+			d.move(1)
+		case pressed:
+			d.focus = p
+			row := d.rowAt(p, y)
+			if row >= 0 && row < d.count() {
+				d.selected[p] = row
+			}
+		}
+	}
+}
+
+// rowAt returns the index of the row of the pane shown on the given screen line, or -1.
+func (d *Dashboard) rowAt(p pane, y int) int {
+	line := y - d.regions[p].y - 1
+	if p == expensesPane {
+		// the first line of the expenses pane is the table header
+		line--
+		if line < 0 {
+			return -1
+		}
+		return d.offset[p] + line
+	}
+	if line < 0 {
+		return -1
+	}
+	return d.offset[p] + line/2
+}
+
+// count returns the number of rows of the focused pane.
+func (d *Dashboard) count() int {
+	if d.focus == expensesPane {
+		return len(d.data.Expenses)
+	}
+	return len(d.data.Goals)
+}
+
+// move moves the selection of the focused pane by the given number of rows.
+func (d *Dashboard) move(rows int) {
+	d.selected[d.focus] += rows
+	if d.selected[d.focus] >= d.count() {
+		d.selected[d.focus] = d.count() - 1
+	}
+	if d.selected[d.focus] < 0 {
+		d.selected[d.focus] = 0
+	}
+}
+
+// edit opens the form of the selected row of the focused pane.
+func (d *Dashboard) edit() {
+	index := d.selected[d.focus]
+	if index >= d.count() {
+		return
+	}
+	if d.focus == expensesPane {
+		d.form = expenseForm(d.data, index)
+	} else {
+		d.form = goalForm(d.data, index)
+	}
+}
+
+// delete asks to confirm deleting the selected row of the focused pane.
+func (d *Dashboard) delete() {
+	index := d.selected[d.focus]
+	if index >= d.count() {
+		return
+	}
+	focus := d.focus
+	name := ""
+	if focus == expensesPane {
+		name = d.data.Expenses[index].Name
+	} else {
+		name = d.data.Goals[index].Name
+	}
+	d.question = fmt.Sprintf("Delete %s? (y/n)", name)
+	d.onConfirm = func() {
+		updated := copyProfile(d.data)
+		if focus == expensesPane {
+			updated.Expenses = append(updated.Expenses[:index], updated.Expenses[index+1:]...)
+		} else {
+			updated.Goals = append(updated.Goals[:index], updated.Goals[index+1:]...)
+		}
+		if d.save(updated, fmt.Sprintf("%s deleted.", name)) {
+			d.move(0)
+		}
+	}
+}
+
+// saveForm applies the open form to the profile and saves it, or shows why it can not.
+func (d *Dashboard) saveForm() {
+	updated := copyProfile(d.data)
+	if err := d.form.apply(&updated, d.form.values()); err != nil {
+		d.form.err = err
+		return
+	}
+	title := d.form.title
+	d.form = nil
+	if !d.save(updated, "Saved.") {
+		return
+	}
+	if title == "Add Expense" {
+		d.focus, d.selected[expensesPane] = expensesPane, len(d.data.Expenses)-1
+	} else if title == "Add Goal" {
+		d.focus, d.selected[goalsPane] = goalsPane, len(d.data.Goals)-1
+	}
+}
+
+// save writes the updated profile and shows it with the given message. If it could not be
+// written, the profile shown stays as it was saved and the error is shown instead; if it was
+// changed elsewhere in the meantime, the profile is read again to show that version, and the
+// change made here is dropped. It reports whether the profile was written.
+func (d *Dashboard) save(updated config.ProfileData, message string) bool {
+	err := config.UpdateProfile(d.profile, &updated)
+	if err == nil {
+		d.data = updated
+		d.setStatus(message, styleGood)
+		return true
+	}
+	if !goerrors.Is(err, errors.ErrProfileChanged) {
+		d.setStatus(err.Error(), styleWarning)
+		return false
+	}
+	current, err := config.ReadProfile(d.profile)
+	if err != nil {
+		d.setStatus(err.Error(), styleWarning)
+		return false
+	}
+	d.data = current
+	focus := d.focus
+	for _, p := range []pane{expensesPane, goalsPane} {
+		d.focus = p
+		d.move(0)
+	}
+	d.focus = focus
+	d.setStatus("The profile was changed elsewhere, so your change was not saved. It is shown as it is now.", styleWarning)
+	return false
+}
+
+// setStatus sets the message of the status line.
+func (d *Dashboard) setStatus(message string, style tcell.Style) {
+	d.status, d.statusStyle = message, style
+}
+
+// preview returns the profile with the values of the open form applied, so the
+// calculation can be shown for the values being typed. Values that are not valid
+// yet are shown as an error in the form and leave the profile as it is.
+func (d *Dashboard) preview() config.ProfileData {
+	if d.form == nil {
+		return d.data
+	}
+	preview := copyProfile(d.data)
+	d.form.err = d.form.apply(&preview, d.form.values())
+	if d.form.err != nil {
+		return d.data
+	}
+	return preview
+}
+
+// draw draws the whole screen.
+func (d *Dashboard) draw() {
+	d.screen.Clear()
+	d.screen.HideCursor()
+	width, height := d.screen.Size()
+	preview := d.preview()
+
+	d.fill(region{0, 0, width, 1}, styleTitle)
+	d.print(1, 0, width-2, styleTitle, fmt.Sprintf("Wallkeiro · %s", d.profile))
+	today := time.Now().Format(config.DateFormat)
+	d.print(width-runewidth.StringWidth(today)-1, 0, width, styleTitle, today)
+
+	main := region{0, 1, width, height - 3}
+	left := region{main.x, main.y, main.width * 3 / 5, main.height}
+	right := region{left.width, main.y, main.width - left.width, main.height}
+	calculationHeight := 11
+	if right.height/2 < calculationHeight {
+		calculationHeight = right.height / 2
+	}
+	d.regions[expensesPane] = left
+	d.regions[goalsPane] = region{right.x, right.y + calculationHeight, right.width, right.height - calculationHeight}
+
+	d.drawExpenses(preview, left)
+	d.drawCalculation(preview, region{right.x, right.y, right.width, calculationHeight})
+	d.drawGoals(preview, d.regions[goalsPane])
+
+	status, style := d.status, d.statusStyle
+	if d.question != "" {
+		status, style = d.question, styleWarning
+	}
+	d.print(1, height-2, width-2, style, status)
+	help := "Tab switch pane  ↑↓ select  a add  e/Enter edit  d/Del delete  s salary  q quit"
+	if d.form != nil {
+		help = "Tab/↑↓ next field  ←→/Space change choice  Enter next/save  Ctrl-S save  Esc cancel"
+	}
+	d.fill(region{0, height - 1, width, 1}, styleTitle)
+	d.print(1, height-1, width-2, styleTitle, help)
+
+	if d.form != nil {
+		d.drawForm(width, height)
+	}
+	d.screen.Show()
+}
+
+// drawExpenses draws the expense table with the monthly total.
+func (d *Dashboard) drawExpenses(ProfileData config.ProfileData, r region) {
+	inner := d.box(r, "Expenses", d.focus == expensesPane)
+	columns := []struct {
+		title string
+		width int
+		right bool
+	}{
+		{"Name", 0, false}, {"Category", 14, false}, {"Frequency", 9, false}, {"Due", 6, false}, {"Amount", 11, true},
+	}
+	fixed := 0
+	for _, column := range columns[1:] {
+		fixed += column.width + 1
+	}
+	columns[0].width = inner.width - fixed
+	if columns[0].width < 8 {
+		columns[0].width = 8
+	}
+	rowsHeight := inner.height - 2
+	d.scroll(expensesPane, rowsHeight)
+
+	drawRow := func(y int, cells []string, style tcell.Style) {
+		x := inner.x
+		for i, column := range columns {
+			cell := truncate(cells[i], column.width)
+			if column.right {
+				d.print(x+column.width-runewidth.StringWidth(cell), y, column.width, style, cell)
+			} else {
+				d.print(x, y, column.width, style, cell)
+			}
+			x += column.width + 1
+		}
+	}
+	drawRow(inner.y, []string{"Name", "Category", "Frequency", "Due", "Amount"}, styleHeader)
+	for i := 0; i < rowsHeight && d.offset[expensesPane]+i < len(ProfileData.Expenses); i++ {
+		index := d.offset[expensesPane] + i
+		expense := ProfileData.Expenses[index]
+		due := ""
+		if expense.DueDay > 0 {
+			due = fmt.Sprintf("%d", expense.DueDay)
+			if expense.EffectiveFrequency() == config.Yearly && expense.DueMonth > 0 {
+				due = fmt.Sprintf("%d.%d", expense.DueDay, expense.DueMonth)
+			}
+		}
+		name := expense.Name
+		if expense.Discretionary {
+			name += " *"
+		}
+		style := styleDefault
+		if index == d.selected[expensesPane] {
+			style = styleSelected
+			if d.focus != expensesPane {
+				style = styleMuted.Reverse(true)
+			}
+			d.fill(region{inner.x, inner.y + 1 + i, inner.width, 1}, style)
+		}
+		drawRow(inner.y+1+i, []string{name, expense.Category, expense.EffectiveFrequency().String(), due, fmt.Sprintf("%.2f€", expense.Amount)}, style)
+	}
+	if len(ProfileData.Expenses) == 0 {
+		d.print(inner.x, inner.y+1, inner.width, styleMuted, "No expenses yet. Press a to add one.")
+	}
+	total := 0.0
+	for _, expense := range ProfileData.Expenses {
+		total += expense.MonthlyAmount()
+	}
+	footer := fmt.Sprintf("Monthly total %.2f€   * discretionary", total)
+	d.print(inner.x, inner.y+inner.height-1, inner.width, styleMuted, footer)
+}
+
+// drawCalculation draws the result of Calculate for the previewed profile.
+func (d *Dashboard) drawCalculation(ProfileData config.ProfileData, r region) {
+	title := "Calculation"
+	if d.form != nil {
+		title = "Calculation (preview)"
+	}
+	inner := d.box(r, title, false)
+	result := expenses.Compute(ProfileData)
+	lines := []struct {
+		label string
+		value float64
+		style tcell.Style
+	}{
+		{"Salary", result.Salary, styleDefault},
+		{"Total Expenses", result.TotalExpenses, styleDefault},
+		{"Minimum Debt Payments", result.DebtPayments, styleMuted},
+		{"Desired Final Balance", result.DesiredFinalBalance, styleMuted},
+		{"Remaining Amount", result.RemainingAmount, styleDefault},
+		{"Suggested Withdrawal", result.WithdrawnAmount, styleGood},
+		{"Lowest Balance", result.LowestBalance, styleDefault},
+	}
+	if result.TotalExpenses > result.Salary {
+		lines[1].style = styleWarning
+		lines[4].style = styleWarning
+	}
+	for i, line := range lines {
+		if i >= inner.height {
+			break
+		}
+		value := fmt.Sprintf("%.2f€", line.value)
+		d.print(inner.x, inner.y+i, inner.width, line.style, line.label)
+		d.print(inner.x+inner.width-runewidth.StringWidth(value), inner.y+i, inner.width, line.style, value)
+	}
+	if result.WithdrawnAmount <= 10 && len(lines) < inner.height {
+		d.print(inner.x, inner.y+len(lines), inner.width, styleWarning, "Salary too small to save more for now.")
+	}
+}
+
+// drawGoals draws the savings goals with a progress bar each.
+func (d *Dashboard) drawGoals(ProfileData config.ProfileData, r region) {
+	inner := d.box(r, "Savings Goals", d.focus == goalsPane)
+	d.scroll(goalsPane, inner.height/2)
+	if len(ProfileData.Goals) == 0 {
+		d.print(inner.x, inner.y, inner.width, styleMuted, "No goals yet. Press Tab, then a to add one.")
+	}
+	for i := 0; (i+1)*2 <= inner.height && d.offset[goalsPane]+i < len(ProfileData.Goals); i++ {
+		index := d.offset[goalsPane] + i
+		progress := expenses.CalculateGoal(ProfileData.Goals[index], time.Now())
+		y := inner.y + i*2
+		style := styleDefault
+		if index == d.selected[goalsPane] {
+			style = styleSelected
+			if d.focus != goalsPane {
+				style = styleMuted.Reverse(true)
+			}
+			d.fill(region{inner.x, y, inner.width, 1}, style)
+		}
+		amounts := fmt.Sprintf("%.0f€ / %.0f€", progress.Goal.Saved, progress.Goal.Target)
+		d.print(inner.x, y, inner.width, style, truncate(progress.Goal.Name, inner.width-runewidth.StringWidth(amounts)-1))
+		d.print(inner.x+inner.width-runewidth.StringWidth(amounts), y, inner.width, style, amounts)
+
+		label := fmt.Sprintf(" %3.0f%%", progress.Progress*100)
+		if progress.MonthsLeft > 0 {
+			label += fmt.Sprintf(" %.0f€/mo", progress.MonthlyNeeded)
+		}
+		barWidth := inner.width - runewidth.StringWidth(label)
+		if barWidth < 1 {
+			barWidth = 1
+		}
+		filled := int(progress.Progress * float64(barWidth))
+		d.print(inner.x, y+1, barWidth, styleGood, strings.Repeat("█", filled))
+		d.print(inner.x+filled, y+1, barWidth-filled, styleMuted, strings.Repeat("░", barWidth-filled))
+		d.print(inner.x+barWidth, y+1, inner.width-barWidth, styleDefault, label)
+	}
+}
+
+// drawForm draws the open form in the middle of the screen.
+func (d *Dashboard) drawForm(width, height int) {
+	formWidth := 56
+	if formWidth > width-4 {
+		formWidth = width - 4
+	}
+	r := region{(width - formWidth) / 2, (height - len(d.form.fields) - 5) / 2, formWidth, len(d.form.fields) + 5}
+	d.form.region = r
+	d.fill(r, styleDefault)
+	inner := d.box(r, d.form.title, true)
+	labelWidth := 14
+	for i, field := range d.form.fields {
+		y := inner.y + 1 + i
+		d.print(inner.x, y, labelWidth, styleMuted, field.label)
+		value := field.value
+		style := styleDefault
+		if len(field.choices) > 0 {
+			value = "◂ " + value + " ▸"
+		}
+		if i == d.form.active {
+			style = styleFocused
+		}
+		valueWidth := inner.width - labelWidth
+		shown := value
+		if runewidth.StringWidth(shown) >= valueWidth {
+			// keep the end of long values in view while typing
+			runes := []rune(shown)
+			for runewidth.StringWidth(string(runes)) >= valueWidth {
+				runes = runes[1:]
+			}
+			shown = string(runes)
+		}
+		d.print(inner.x+labelWidth, y, valueWidth, style, shown)
+		if i == d.form.active && len(field.choices) == 0 {
+			d.screen.ShowCursor(inner.x+labelWidth+runewidth.StringWidth(shown), y)
+		}
+	}
+	if d.form.err != nil {
+		d.print(inner.x, inner.y+inner.height-1, inner.width, styleWarning, truncate(d.form.err.Error(), inner.width))
+	}
+}
+
+// box draws a border with a title around the region and returns the region inside it.
+func (d *Dashboard) box(r region, title string, focused bool) region {
+	style := styleBorder
+	if focused {
+		style = styleFocused
+	}
+	right, bottom := r.x+r.width-1, r.y+r.height-1
+	for x := r.x + 1; x < right; x++ {
+		d.screen.SetContent(x, r.y, '─', nil, style)
+		d.screen.SetContent(x, bottom, '─', nil, style)
+	}
+	for y := r.y + 1; y < bottom; y++ {
+		d.screen.SetContent(r.x, y, '│', nil, style)
+		d.screen.SetContent(right, y, '│', nil, style)
+	}
+	d.screen.SetContent(r.x, r.y, '┌', nil, style)
+	d.screen.SetContent(right, r.y, '┐', nil, style)
+	d.screen.SetContent(r.x, bottom, '└', nil, style)
+	d.screen.SetContent(right, bottom, '┘', nil, style)
+	d.print(r.x+2, r.y, r.width-4, style, " "+title+" ")
+	return region{r.x + 2, r.y + 1, r.width - 4, r.height - 2}
+}
+
+// scroll keeps the selected row of the pane within the given number of visible rows.
+func (d *Dashboard) scroll(p pane, visible int) {
+	if visible < 1 {
+		visible = 1
+	}
+	if d.selected[p] < d.offset[p] {
+		d.offset[p] = d.selected[p]
+	}
+	if d.selected[p] >= d.offset[p]+visible {
+		d.offset[p] = d.selected[p] - visible + 1
+	}
+	if d.offset[p] < 0 {
+		d.offset[p] = 0
+	}
+}
+
+// print draws the text from the given cell, cut off after the given width.
+func (d *Dashboard) print(x, y, width int, style tcell.Style, text string) {
+	end := x + width
+	for _, r := range text {
+		w := runewidth.RuneWidth(r)
+		if x+w > end {
+			return
+		}
+		d.screen.SetContent(x, y, r, nil, style)
+		x += w
+	}
+}
+
+// fill fills the region with spaces of the given style.
+func (d *Dashboard) fill(r region, style tcell.Style) {
+	for y := r.y; y < r.y+r.height; y++ {
+		for x := r.x; x < r.x+r.width; x++ {
+			d.screen.SetContent(x, y, ' ', nil, style)
+		}
+	}
+}
+
+// truncate cuts the text to the given width, ending with an ellipsis if it was cut.
+func truncate(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if runewidth.StringWidth(text) <= width {
+		return text
+	}
+	return runewidth.Truncate(text, width, "…")
+}
+
+// copyProfile returns a copy of the profile data whose expenses and goals can be
+// changed without changing the original.
+func copyProfile(ProfileData config.ProfileData) config.ProfileData {
+	ProfileData.Expenses = append([]config.ExpensesStuct{}, ProfileData.Expenses...)
+	ProfileData.Goals = append([]config.GoalStruct{}, ProfileData.Goals...)
+	return ProfileData
+}
@@ -0,0 +1,268 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// field is a single input of a form. Fields with choices cycle through them
+// instead of taking typed text.
+type field struct {
+	label   string
+	value   string
+	choices []string
+}
+
+// form is a modal dialog that edits a part of the profile. apply sets the values
+// of the fields on the given profile data, which is used both to preview the change
+// in the other panes while typing and to save it.
+type form struct {
+	title  string
+	fields []field
+	active int
+	err    error
+	apply  func(ProfileData *config.ProfileData, values []string) error
+	region region
+}
+
+// values returns the current values of the fields.
+func (f *form) values() []string {
+	var values []string
+	for _, field := range f.fields {
+		values = append(values, strings.TrimSpace(field.value))
+	}
+	return values
+}
+
+// handleKey edits the form with the given key. It returns true when the form should be
+// saved, which is on Enter in the last field or Ctrl-S anywhere.
+func (f *form) handleKey(ev *tcell.EventKey) bool {
+	active := &f.fields[f.active]
+	switch ev.Key() {
+	case tcell.KeyTab, tcell.KeyDown:
+		f.active = (f.active + 1) % len(f.fields)
+	case tcell.KeyBacktab, tcell.KeyUp:
+		f.active = (f.active + len(f.fields) - 1) % len(f.fields)
+	case tcell.KeyEnter:
+		if f.active == len(f.fields)-1 {
+			return true
+		}
+		f.active++
+	case tcell.KeyCtrlS:
+		return true
+	case tcell.KeyLeft, tcell.KeyRight:
+		if len(active.choices) > 0 {
+			step := 1
+			if ev.Key() == tcell.KeyLeft {
+				step = len(active.choices) - 1
+			}
+			active.value = active.choices[(choiceIndex(*active)+step)%len(active.choices)]
+		}
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if len(active.choices) == 0 && active.value != "" {
+			runes := []rune(active.value)
+			active.value = string(runes[:len(runes)-1])
+		}
+	case tcell.KeyCtrlU:
+		if len(active.choices) == 0 {
+			active.value = ""
+		}
+	case tcell.KeyRune:
+		if len(active.choices) > 0 {
+			if ev.Rune() == ' ' {
+				active.value = active.choices[(choiceIndex(*active)+1)%len(active.choices)]
+			}
+			break
+		}
+		active.value += string(ev.Rune())
+	}
+	return false
+}
+
+// choiceIndex returns the index of the current value among the choices of the field.
+func choiceIndex(f field) int {
+	for i, choice := range f.choices {
+		if choice == f.value {
+			return i
+		}
+	}
+	return 0
+}
+
+// expenseForm returns the form that adds an expense, or edits the expense at the given index.
+func expenseForm(ProfileData config.ProfileData, index int) *form {
+	expense := config.ExpensesStuct{Frequency: config.Monthly}
+	title := "Add Expense"
+	if index >= 0 {
+		expense = ProfileData.Expenses[index]
+		title = "Edit Expense"
+	}
+	essential := "yes"
+	if expense.Discretionary {
+		essential = "no"
+	}
+	return &form{
+		title: title,
+		fields: []field{
+			{label: "Name", value: expense.Name},
+			{label: "Amount", value: formatAmount(expense.Amount)},
+			{label: "Category", value: expense.Category},
+			{label: "Frequency", value: expense.EffectiveFrequency().String(), choices: []string{config.Monthly.String(), config.Yearly.String()}},
+			{label: "Due Day", value: formatDay(expense.DueDay)},
+			{label: "Due Month", value: formatDay(expense.DueMonth)},
+			{label: "Essential", value: essential, choices: []string{"yes", "no"}},
+		},
+		apply: func(ProfileData *config.ProfileData, values []string) error {
+			amount, err := parseAmount(values[1])
+			if err != nil {
+				return err
+			}
+			dueDay, err := parseNumber(values[4], 31, errors.ErrInvalidDueDay)
+			if err != nil {
+				return err
+			}
+			dueMonth, err := parseNumber(values[5], 12, errors.ErrInvalidDueMonth)
+			if err != nil {
+				return err
+			}
+			updated := config.ExpensesStuct{
+				Name:          values[0],
+				Amount:        amount,
+				Category:      values[2],
+				Frequency:     config.Frequency(values[3]),
+				DueDay:        dueDay,
+				DueMonth:      dueMonth,
+				Discretionary: values[6] == "no",
+			}
+			if updated.Frequency == config.Yearly && updated.DueMonth == 0 {
+				return errors.ErrInvalidDueMonth
+			}
+			if index >= 0 {
+				updated.ID = ProfileData.Expenses[index].ID
+				ProfileData.Expenses[index] = updated
+			} else {
+				ProfileData.Expenses = append(ProfileData.Expenses, updated)
+			}
+			return nil
+		},
+	}
+}
+
+// goalForm returns the form that adds a savings goal, or edits the goal at the given index.
+func goalForm(ProfileData config.ProfileData, index int) *form {
+	var goal config.GoalStruct
+	title := "Add Goal"
+	if index >= 0 {
+		goal = ProfileData.Goals[index]
+		title = "Edit Goal"
+	}
+	return &form{
+		title: title,
+		fields: []field{
+			{label: "Name", value: goal.Name},
+			{label: "Target", value: formatAmount(goal.Target)},
+			{label: "Saved", value: formatAmount(goal.Saved)},
+			{label: "Deadline", value: goal.Deadline},
+		},
+		apply: func(ProfileData *config.ProfileData, values []string) error {
+			target, err := parseAmount(values[1])
+			if err != nil {
+				return err
+			}
+			saved, err := parseAmount(values[2])
+			if err != nil {
+				return err
+			}
+			if values[3] != "" {
+				if _, err := time.Parse(config.DateFormat, values[3]); err != nil {
+					return errors.ErrInvalidDate
+				}
+			}
+			updated := config.GoalStruct{Name: values[0], Target: target, Saved: saved, Deadline: values[3]}
+			if index >= 0 {
+				ProfileData.Goals[index] = updated
+			} else {
+				ProfileData.Goals = append(ProfileData.Goals, updated)
+			}
+			return nil
+		},
+	}
+}
+
+// settingsForm returns the form that edits the salary, saving level and payday of the profile.
+func settingsForm(ProfileData config.ProfileData) *form {
+	return &form{
+		title: "Edit Salary",
+		fields: []field{
+			{label: "Salary", value: formatAmount(ProfileData.Config.Salary)},
+			{label: "Salary Type", value: ProfileData.Config.SalaryType.String(), choices: []string{config.Fixed.String(), config.Hourly.String()}},
+			{label: "Saving Level", value: strconv.Itoa(ProfileData.Config.SavingLevel)},
+			{label: "Payday", value: formatDay(ProfileData.Config.Payday)},
+		},
+		apply: func(ProfileData *config.ProfileData, values []string) error {
+			salary, err := parseAmount(values[0])
+			if err != nil {
+				return err
+			}
+			level, err := parseNumber(values[2], 4, errors.ErrLevelTooHigh)
+			if err != nil || level < 1 {
+				return errors.ErrLevelTooHigh
+			}
+			payday, err := parseNumber(values[3], 31, errors.ErrInvalidDueDay)
+			if err != nil {
+				return err
+			}
+			ProfileData.Config.Salary = salary
+			ProfileData.Config.SalaryType = config.SalaryType(values[1])
+			ProfileData.Config.SavingLevel = level
+			ProfileData.Config.Payday = payday
+			return nil
+		},
+	}
+}
+
+// parseAmount parses an amount typed with a decimal point or comma.
+func parseAmount(text string) (float64, error) {
+	if text == "" {
+		return 0, nil
+	}
+	amount, err := strconv.ParseFloat(strings.ReplaceAll(text, ",", "."), 64)
+	if err != nil || amount < 0 {
+		return 0, fmt.Errorf("%w: %q", errors.ErrInvalidAmount, text)
+	}
+	return amount, nil
+}
+
+// parseNumber parses a whole number between 0 and max, where an empty text is 0.
+func parseNumber(text string, max int, invalid error) (int, error) {
+	if text == "" {
+		return 0, nil
+	}
+	number, err := strconv.Atoi(text)
+	if err != nil || number < 0 || number > max {
+		return 0, invalid
+	}
+	return number, nil
+}
+
+// formatAmount returns the amount as typed in a form, without trailing zeros.
+func formatAmount(amount float64) string {
+	if amount == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(amount, 'f', -1, 64)
+}
+
+// formatDay returns the day or month as typed in a form, empty if it is not set.
+func formatDay(day int) string {
+	if day == 0 {
+		return ""
+	}
+	return strconv.Itoa(day)
+}
@@ -0,0 +1,264 @@
+package core
+
+import (
+	"wallkeiro/core/auth"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/table"
+
+	"github.com/manifoldco/promptui"
+
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// UsersMenu lets the user manage the user accounts of the server started with
+// "wallkeiro serve". Users can be added, get a new password or API tokens, or be
+// deleted, profiles can be given an owner and be shared with other users, and the
+// audit log of the changes made through the server can be shown.
+// The first user added is an admin, and from then on the server requires logging in.
+func UsersMenu() error {
+	prompt := promptui.Select{
+		Label: "Select User Action",
+		Items: []string{"Show Users", "Add User", "Change Password", "Create API Token", "Revoke API Token", "Set Profile Owner", "Share Profile", "Stop Sharing Profile", "Delete User", "Show Audit Log"},
+	}
+	_, actionSelector, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+	accounts, err := auth.Load()
+	if err != nil {
+		return err
+	}
+	switch actionSelector {
+	case "Show Users":
+		showUsers(accounts)
+		return nil
+	case "Show Audit Log":
+		entries, err := auth.Audit("")
+		if err != nil {
+			return err
+		}
+		auth.ShowAudit(entries)
+		return nil
+	case "Add User":
+		namePrompt := promptui.Prompt{
+			Label: "Enter User Name",
+		}
+		name, err := namePrompt.Run()
+		if err != nil {
+			return err
+		}
+		password, err := promptPassword()
+		if err != nil {
+			return err
+		}
+		err = auth.Change(func(accounts *auth.Accounts) error {
+			return accounts.AddUser(name, password)
+		})
+		if err != nil {
+			return err
+		}
+		if len(accounts.Users) == 0 {
+			fmt.Printf("User %s added as admin. The server now requires logging in.\n", name)
+		} else {
+			fmt.Printf("User %s added.\n", name)
+		}
+		return nil
+	}
+
+	if len(accounts.Users) == 0 {
+		fmt.Println("No users yet.")
+		return nil
+	}
+	userPrompt := promptui.Select{
+		Label: "Select User",
+		Items: accounts.UserNames(),
+	}
+	_, name, err := userPrompt.Run()
+	if err != nil {
+		return err
+	}
+	switch actionSelector {
+	case "Change Password":
+		password, err := promptPassword()
+		if err != nil {
+			return err
+		}
+		err = auth.Change(func(accounts *auth.Accounts) error {
+			return accounts.SetPassword(name, password)
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Password of %s changed.\n", name)
+	case "Create API Token":
+		tokenPrompt := promptui.Prompt{
+			Label: "Enter Token Name (what the token is used for)",
+		}
+		tokenName, err := tokenPrompt.Run()
+		if err != nil {
+			return err
+		}
+		var token string
+		err = auth.Change(func(accounts *auth.Accounts) error {
+			var err error
+			token, err = accounts.CreateToken(name, tokenName)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("API token for %s: %s\n", name, token)
+		fmt.Println("Copy it now, it can not be shown again.")
+	case "Revoke API Token":
+		user, _ := accounts.User(name)
+		if len(user.Tokens) == 0 {
+			fmt.Println("No API tokens to revoke.")
+			return nil
+		}
+		var tokenNames []string
+		for _, token := range user.Tokens {
+			tokenNames = append(tokenNames, fmt.Sprintf("%s (%s, created %s)", token.Name, token.ID, token.Created))
+		}
+		prompt := promptui.Select{
+			Label: "Select Token",
+			Items: tokenNames,
+		}
+		tokenIndex, _, err := prompt.Run()
+		if err != nil {
+			return err
+		}
+		err = auth.Change(func(accounts *auth.Accounts) error {
+			return accounts.RevokeToken(name, user.Tokens[tokenIndex].ID)
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("API token %s revoked.\n", user.Tokens[tokenIndex].Name)
+	case "Set Profile Owner", "Share Profile", "Stop Sharing Profile":
+		profiles := config.GetProfiles()
+		if len(profiles) == 0 {
+			fmt.Println("No profiles yet.")
+			return nil
+		}
+		prompt := promptui.Select{
+			Label: "Select Profile",
+			Items: profiles,
+		}
+		_, profile, err := prompt.Run()
+		if err != nil {
+			return err
+		}
+		permission := auth.None
+		if actionSelector == "Share Profile" {
+			prompt := promptui.Select{
+				Label: "Select Permission",
+				Items: []string{string(auth.Read), string(auth.Edit)},
+			}
+			_, result, err := prompt.Run()
+			if err != nil {
+				return err
+			}
+			permission = auth.Permission(result)
+		}
+		err = auth.Change(func(accounts *auth.Accounts) error {
+			if actionSelector == "Set Profile Owner" {
+				return accounts.SetOwner(profile, name)
+			}
+			return accounts.Share(profile, name, permission)
+		})
+		if err != nil {
+			return err
+		}
+		switch actionSelector {
+		case "Set Profile Owner":
+			fmt.Printf("Profile %s is now owned by %s.\n", profile, name)
+		case "Share Profile":
+			fmt.Printf("Profile %s shared with %s for %s.\n", profile, name, permission)
+		default:
+			fmt.Printf("Profile %s is no longer shared with %s.\n", profile, name)
+		}
+	case "Delete User":
+		confirm := promptui.Prompt{
+			Label:     fmt.Sprintf("Delete user %s with their API tokens", name),
+			IsConfirm: true,
+		}
+		if _, err := confirm.Run(); err != nil {
+			fmt.Println("User not deleted.")
+			return nil
+		}
+		err = auth.Change(func(accounts *auth.Accounts) error {
+			return accounts.DeleteUser(name)
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("User %s deleted.\n", name)
+	}
+	return nil
+}
+
+// promptPassword asks the user for a new password twice, without showing it.
+func promptPassword() (string, error) {
+	prompt := promptui.Prompt{
+		Label: "Enter Password",
+		Mask:  '*',
+		Validate: func(input string) error {
+			if len([]rune(input)) < auth.MinPasswordLength {
+				return errors.ErrPasswordTooShort
+			}
+			return nil
+		},
+	}
+	password, err := prompt.Run()
+	if err != nil {
+		return "", err
+	}
+	repeat := promptui.Prompt{
+		Label: "Repeat Password",
+		Mask:  '*',
+		Validate: func(input string) error {
+			if input != password {
+				return fmt.Errorf("the passwords do not match")
+			}
+			return nil
+		},
+	}
+	if _, err := repeat.Run(); err != nil {
+		return "", err
+	}
+	return password, nil
+}
+
+// showUsers prints a table of the users, the profiles they own and the profiles shared with them.
+func showUsers(accounts auth.Accounts) {
+	if len(accounts.Users) == 0 {
+		fmt.Println("No users yet. The server can only be used from this computer, without logging in.")
+		return
+	}
+	var rows [][]string
+	for _, name := range accounts.UserNames() {
+		user, _ := accounts.User(name)
+		var owned, shared []string
+		for profile, access := range accounts.Profiles {
+			if strings.EqualFold(access.Owner, name) {
+				owned = append(owned, profile)
+			}
+			for sharedWith, permission := range access.Shares {
+				if strings.EqualFold(sharedWith, name) {
+					shared = append(shared, fmt.Sprintf("%s (%s)", profile, permission))
+				}
+			}
+		}
+		sort.Strings(owned)
+		sort.Strings(shared)
+		admin := ""
+		if user.Admin {
+			admin = "yes"
+		}
+		rows = append(rows, []string{name, admin, fmt.Sprintf("%d", len(user.Tokens)), strings.Join(owned, ", "), strings.Join(shared, ", ")})
+	}
+	table.Print([]string{"User", "Admin", "API Tokens", "Owns", "Shared With Them"}, rows)
+}
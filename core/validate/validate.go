@@ -0,0 +1,135 @@
+// Package validate checks profiles and their items before they are saved by the
+// REST and gRPC APIs, which can not rely on the menus to only accept valid input.
+package validate
+
+import (
+	goerrors "errors"
+	"fmt"
+	"strings"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/rules"
+)
+
+// Profile checks the settings and every item of the lists of a whole profile.
+func Profile(profileData config.ProfileData) error {
+	if err := Config(profileData.Config); err != nil {
+		return err
+	}
+	for _, expense := range profileData.Expenses {
+		if err := Expense(expense); err != nil {
+			return err
+		}
+	}
+	for _, debt := range profileData.Debts {
+		if err := Debt(debt); err != nil {
+			return err
+		}
+	}
+	for _, goal := range profileData.Goals {
+		if err := Goal(goal); err != nil {
+			return err
+		}
+	}
+	for _, rule := range profileData.Rules {
+		if err := Rule(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Config checks the settings of a profile the same way the menus do.
+func Config(configData config.ConfigStruct) error {
+	if configData.Salary < 0 || configData.Savings < 0 {
+		return errors.ErrInvalidAmount
+	}
+	if configData.SavingLevel < 1 || configData.SavingLevel > 4 {
+		return errors.ErrLevelTooHigh
+	}
+	if configData.Payday < 0 || configData.Payday > 31 {
+		return errors.ErrInvalidDueDay
+	}
+	if configData.SalaryType != config.Fixed && configData.SalaryType != config.Hourly {
+		return errors.ErrInvalidRequest
+	}
+	return nil
+}
+
+// Expense checks that an expense has a name, a positive amount and a valid due date.
+func Expense(expense config.ExpensesStuct) error {
+	if expense.Name == "" {
+		return errors.ErrNameRequired
+	}
+	if expense.Amount < 0 {
+		return errors.ErrInvalidAmount
+	}
+	if expense.Frequency != "" && expense.Frequency != config.Monthly && expense.Frequency != config.Yearly {
+		return errors.ErrInvalidRequest
+	}
+	if expense.DueDay < 0 || expense.DueDay > 31 {
+		return errors.ErrInvalidDueDay
+	}
+	if expense.DueMonth < 0 || expense.DueMonth > 12 {
+		return errors.ErrInvalidDueMonth
+	}
+	return nil
+}
+
+// Debt checks that a debt has a name and no negative amounts.
+func Debt(debt config.DebtStruct) error {
+	if debt.Name == "" {
+		return errors.ErrNameRequired
+	}
+	if debt.Balance < 0 || debt.APR < 0 || debt.MinimumPayment < 0 {
+		return errors.ErrInvalidAmount
+	}
+	return nil
+}
+
+// Goal checks that a goal has a name, no negative amounts and a valid deadline if it has one.
+func Goal(goal config.GoalStruct) error {
+	if goal.Name == "" {
+		return errors.ErrNameRequired
+	}
+	if goal.Target < 0 || goal.Saved < 0 {
+		return errors.ErrInvalidAmount
+	}
+	if goal.Deadline != "" {
+		if _, err := time.Parse(config.DateFormat, goal.Deadline); err != nil {
+			return errors.ErrInvalidDate
+		}
+	}
+	return nil
+}
+
+// Rule checks a categorization rule like the rules menu does.
+func Rule(rule config.RuleStruct) error {
+	if err := rules.Validate(rule); err != nil {
+		return fmt.Errorf("%w: %v", errors.ErrInvalidRequest, err)
+	}
+	return nil
+}
+
+// Name checks that a profile or scenario name can be used as a file name.
+func Name(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.ErrNameRequired
+	}
+	if strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return errors.ErrInvalidName
+	}
+	return nil
+}
+
+// InvalidInput reports whether the error is caused by the input rather than by reading or writing the profile.
+func InvalidInput(err error) bool {
+	for _, invalid := range []error{errors.ErrInvalidRequest, errors.ErrNameRequired, errors.ErrInvalidName, errors.ErrInvalidAmount,
+		errors.ErrInvalidDueDay, errors.ErrInvalidDueMonth, errors.ErrInvalidDate, errors.ErrLevelTooHigh, errors.ErrInvalidPermission, errors.ErrPasswordTooShort} {
+		if goerrors.Is(err, invalid) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,386 @@
+"use strict";
+
+// The web interface of wallkeiro. Everything goes through the REST API under /v1,
+// so the interface can do nothing the API can not.
+
+const API = "/v1";
+const COLORS = ["#3b6ea5", "#e08e45", "#5a9e6f", "#b5485d", "#8a6fb0", "#4aa3a2", "#c9a227", "#7a7a7a"];
+const SVG = "http://www.w3.org/2000/svg";
+
+let current = null;
+
+async function request(method, path, body) {
+  const options = { method, headers: {} };
+  if (body !== undefined) {
+    options.headers["Content-Type"] = "application/json";
+    options.body = JSON.stringify(body);
+  }
+  const response = await fetch(API + path, options);
+  if (response.status === 204) {
+    return null;
+  }
+  const data = await response.json();
+  if (!response.ok) {
+    throw new Error(data.error || response.statusText);
+  }
+  return data;
+}
+
+function profilePath(suffix) {
+  return "/profiles/" + encodeURIComponent(current) + (suffix || "");
+}
+
+function money(value) {
+  return value.toLocaleString(undefined, { minimumFractionDigits: 2, maximumFractionDigits: 2 }) + " €";
+}
+
+function element(tag, attributes, ...children) {
+  const node = document.createElement(tag);
+  for (const [name, value] of Object.entries(attributes || {})) {
+    if (name === "onclick") {
+      node.addEventListener("click", value);
+    } else {
+      node.setAttribute(name, value);
+    }
+  }
+  for (const child of children) {
+    node.append(child);
+  }
+  return node;
+}
+
+function svgElement(tag, attributes, text) {
+  const node = document.createElementNS(SVG, tag);
+  for (const [name, value] of Object.entries(attributes)) {
+    node.setAttribute(name, value);
+  }
+  if (text !== undefined) {
+    node.textContent = text;
+  }
+  return node;
+}
+
+function showMessage(text, isError) {
+  const message = document.getElementById("message");
+  message.textContent = text;
+  message.className = isError ? "error" : "ok";
+  clearTimeout(showMessage.timer);
+  showMessage.timer = setTimeout(() => { message.textContent = ""; }, 4000);
+}
+
+// attempt runs the action and shows its error, if any, instead of failing silently.
+async function attempt(action) {
+  try {
+    await action();
+  } catch (error) {
+    showMessage(error.message, true);
+  }
+}
+
+async function loadProfiles() {
+  const profiles = await request("GET", "/profiles");
+  const list = document.getElementById("profiles");
+  list.replaceChildren();
+  for (const name of profiles) {
+    const link = element("a", { href: "#" + encodeURIComponent(name) }, name);
+    if (name === current) {
+      link.className = "active";
+    }
+    list.append(element("li", {}, link));
+  }
+  if (profiles.length === 0) {
+    list.append(element("li", { class: "muted" }, "No profiles yet"));
+  }
+}
+
+async function selectProfile(name) {
+  current = name || null;
+  await loadProfiles();
+  document.getElementById("profile").hidden = !current;
+  document.getElementById("empty").hidden = !!current;
+  if (current) {
+    await loadProfile();
+  }
+}
+
+async function loadProfile() {
+  const [profile, calculation, cashflow, goals] = await Promise.all([
+    request("GET", profilePath()),
+    request("GET", profilePath("/calculate")),
+    request("GET", profilePath("/cashflow")),
+    request("GET", profilePath("/goal-progress")),
+  ]);
+  document.getElementById("profile-name").textContent = current;
+  fillSettings(profile.config);
+  renderExpenses(profile.expenses);
+  renderCalculation(calculation);
+  renderCategoryChart(profile.expenses);
+  renderCashFlowChart(cashflow);
+  renderGoals(goals);
+}
+
+function fillSettings(config) {
+  const form = document.getElementById("settings");
+  form.salary.value = config.salary;
+  form.salary_type.value = config.salary_type || "fixed";
+  form.level.value = config.level || 1;
+  form.payday.value = config.payday || "";
+  form.dataset.config = JSON.stringify(config);
+}
+
+function monthlyAmount(expense) {
+  return expense.frequency === "yearly" ? expense.amount / 12 : expense.amount;
+}
+
+function renderExpenses(expenses) {
+  const body = document.getElementById("expenses");
+  body.replaceChildren();
+  let total = 0;
+  expenses.forEach((expense, index) => {
+    total += monthlyAmount(expense);
+    let due = expense.due_day ? String(expense.due_day) : "";
+    if (due && expense.frequency === "yearly" && expense.due_month) {
+      due += "." + expense.due_month + ".";
+    }
+    const name = element("td", {}, expense.name);
+    if (expense.discretionary) {
+      name.append(element("span", { class: "tag" }, "discretionary"));
+    }
+    body.append(element("tr", {},
+      name,
+      element("td", {}, expense.category || ""),
+      element("td", {}, expense.frequency || "monthly"),
+      element("td", {}, due),
+      element("td", { class: "amount" }, money(expense.amount)),
+      element("td", { class: "actions" },
+        element("button", { class: "secondary", onclick: () => editExpense(index, expense) }, "Edit"),
+        element("button", { class: "danger", onclick: () => attempt(() => deleteExpense(index, expense)) }, "Delete")),
+    ));
+  });
+  if (expenses.length === 0) {
+    body.append(element("tr", {}, element("td", { colspan: "6", class: "muted" }, "No expenses yet. Add your first one below.")));
+  }
+  body.append(element("tr", { class: "total" },
+    element("td", { colspan: "4" }, "Monthly total"),
+    element("td", { class: "amount" }, money(total)),
+    element("td", {})));
+}
+
+function renderCalculation(calculation) {
+  const list = document.getElementById("calculation");
+  list.replaceChildren();
+  const rows = [
+    ["Salary", calculation.salary],
+    ["Total expenses", calculation.total_expenses],
+    ["Minimum debt payments", calculation.debt_payments],
+    ["Desired final balance", calculation.desired_final_balance],
+    ["Remaining after expenses", calculation.remaining_amount],
+    ["Lowest balance before payday", calculation.lowest_balance],
+  ];
+  for (const [label, value] of rows) {
+    list.append(element("dt", {}, label), element("dd", {}, money(value)));
+  }
+  list.append(element("dt", { class: "highlight" }, "Suggested savings"), element("dd", { class: "highlight" }, money(calculation.withdrawn_amount)));
+  if (calculation.total_expenses > calculation.salary) {
+    list.append(element("dt", { class: "warning" }, "Nothing to save, expenses are more than salary"), element("dd", {}, ""));
+  }
+}
+
+function renderCategoryChart(expenses) {
+  const totals = new Map();
+  for (const expense of expenses) {
+    const category = expense.category || "Uncategorized";
+    totals.set(category, (totals.get(category) || 0) + monthlyAmount(expense));
+  }
+  const chart = document.getElementById("category-chart");
+  chart.replaceChildren();
+  const entries = [...totals.entries()].sort((a, b) => b[1] - a[1]);
+  const sum = entries.reduce((total, entry) => total + entry[1], 0);
+  if (sum === 0) {
+    chart.append(element("p", { class: "muted" }, "Add expenses to see them by category."));
+    return;
+  }
+  // a donut chart drawn with one arc per category
+  const svg = svgElement("svg", { viewBox: "0 0 320 200", role: "img", "aria-label": "Expenses by category" });
+  let angle = -Math.PI / 2;
+  entries.forEach(([category, value], i) => {
+    const color = COLORS[i % COLORS.length];
+    const sweep = value / sum * Math.PI * 2;
+    if (entries.length === 1) {
+      svg.append(svgElement("circle", { cx: 100, cy: 100, r: 70, fill: "none", stroke: color, "stroke-width": 40 }));
+    } else {
+      const start = [100 + 70 * Math.cos(angle), 100 + 70 * Math.sin(angle)];
+      const end = [100 + 70 * Math.cos(angle + sweep), 100 + 70 * Math.sin(angle + sweep)];
+      const large = sweep > Math.PI ? 1 : 0;
+      svg.append(svgElement("path", {
+        d: `M ${start[0]} ${start[1]} A 70 70 0 ${large} 1 ${end[0]} ${end[1]}`,
+        fill: "none", stroke: color, "stroke-width": 40,
+      }));
+    }
+    angle += sweep;
+    if (i < 8) {
+      svg.append(svgElement("rect", { x: 200, y: 20 + i * 20, width: 12, height: 12, fill: color }));
+      svg.append(svgElement("text", { x: 218, y: 31 + i * 20, "font-size": 11 }, `${category} ${Math.round(value / sum * 100)}%`));
+    }
+  });
+  svg.append(svgElement("text", { x: 100, y: 104, "text-anchor": "middle", "font-size": 13, "font-weight": "bold" }, money(sum)));
+  chart.append(svg);
+}
+
+function renderCashFlowChart(days) {
+  const chart = document.getElementById("cashflow-chart");
+  chart.replaceChildren();
+  if (!days || days.length === 0) {
+    chart.append(element("p", { class: "muted" }, "Set a salary to see the balance until the next payday."));
+    return;
+  }
+  const width = 320, height = 200, left = 50, bottom = 20;
+  const balances = days.map(day => day.balance);
+  const max = Math.max(...balances, 0);
+  const min = Math.min(...balances, 0);
+  const range = max - min || 1;
+  const x = i => left + i * (width - left - 10) / Math.max(days.length - 1, 1);
+  const y = value => 10 + (max - value) / range * (height - bottom - 10);
+  const svg = svgElement("svg", { viewBox: `0 0 ${width} ${height}`, role: "img", "aria-label": "Balance until next payday" });
+  svg.append(svgElement("line", { x1: left, x2: width - 10, y1: y(0), y2: y(0), stroke: "#bbb" }));
+  svg.append(svgElement("text", { x: left - 4, y: y(max) + 4, "text-anchor": "end", "font-size": 10 }, Math.round(max)));
+  svg.append(svgElement("text", { x: left - 4, y: y(min) + 4, "text-anchor": "end", "font-size": 10 }, Math.round(min)));
+  const points = days.map((day, i) => `${x(i)},${y(day.balance)}`).join(" ");
+  svg.append(svgElement("polyline", { points, fill: "none", stroke: COLORS[0], "stroke-width": 2 }));
+  let lowest = 0;
+  balances.forEach((balance, i) => { if (balance < balances[lowest]) lowest = i; });
+  svg.append(svgElement("circle", { cx: x(lowest), cy: y(balances[lowest]), r: 4, fill: COLORS[3] }));
+  const first = days[0].date.slice(0, 10), last = days[days.length - 1].date.slice(0, 10);
+  svg.append(svgElement("text", { x: left, y: height - 4, "font-size": 10 }, first));
+  svg.append(svgElement("text", { x: width - 10, y: height - 4, "font-size": 10, "text-anchor": "end" }, last));
+  chart.append(svg);
+}
+
+function renderGoals(goals) {
+  const container = document.getElementById("goals");
+  container.replaceChildren();
+  if (goals.length === 0) {
+    container.append(element("p", { class: "muted" }, "No savings goals yet."));
+    return;
+  }
+  for (const progress of goals) {
+    const svg = svgElement("svg", { viewBox: "0 0 300 12", preserveAspectRatio: "none", class: "progress" });
+    svg.append(svgElement("rect", { x: 0, y: 0, width: 300, height: 12, fill: "#e6e6e6" }));
+    svg.append(svgElement("rect", { x: 0, y: 0, width: 300 * Math.min(progress.progress, 1), height: 12, fill: COLORS[2] }));
+    let detail = `${money(progress.goal.saved)} of ${money(progress.goal.target)}`;
+    if (progress.months_left > 0) {
+      detail += `, ${money(progress.monthly_needed)} a month until ${progress.goal.deadline}`;
+    }
+    container.append(element("div", { class: "goal" },
+      element("strong", {}, `${progress.goal.name} (${Math.round(progress.progress * 100)}%)`),
+      svg,
+      element("span", { class: "muted" }, detail)));
+  }
+}
+
+function editExpense(index, expense) {
+  const form = document.getElementById("expense");
+  form.index.value = expense.id || index;
+  form.name.value = expense.name;
+  form.amount.value = expense.amount;
+  form.category.value = expense.category || "";
+  form.frequency.value = expense.frequency || "monthly";
+  form.due_day.value = expense.due_day || "";
+  form.due_month.value = expense.due_month || "";
+  form.discretionary.checked = expense.discretionary;
+  form.querySelector("button[type=submit]").textContent = "Save Expense";
+  document.getElementById("cancel-edit").hidden = false;
+  form.name.focus();
+}
+
+function resetExpenseForm() {
+  const form = document.getElementById("expense");
+  form.reset();
+  form.index.value = "";
+  form.querySelector("button[type=submit]").textContent = "Add Expense";
+  document.getElementById("cancel-edit").hidden = true;
+}
+
+async function deleteExpense(index, expense) {
+  if (!confirm(`Delete the expense ${expense.name}?`)) {
+    return;
+  }
+  await request("DELETE", profilePath("/expenses/" + encodeURIComponent(expense.id || index)));
+  showMessage(`Expense ${expense.name} deleted.`);
+  await loadProfile();
+}
+
+document.getElementById("new-profile").addEventListener("submit", event => {
+  event.preventDefault();
+  attempt(async () => {
+    const name = event.target.name.value.trim();
+    await request("POST", "/profiles", { name });
+    event.target.reset();
+    showMessage(`Profile ${name} created.`);
+    location.hash = encodeURIComponent(name.toLowerCase());
+  });
+});
+
+document.getElementById("settings").addEventListener("submit", event => {
+  event.preventDefault();
+  attempt(async () => {
+    const form = event.target;
+    const config = JSON.parse(form.dataset.config);
+    config.salary = Number(form.salary.value);
+    config.salary_type = form.salary_type.value;
+    config.level = Number(form.level.value);
+    config.payday = Number(form.payday.value || 0);
+    await request("PUT", profilePath("/config"), config);
+    showMessage("Settings saved.");
+    await loadProfile();
+  });
+});
+
+document.getElementById("expense").addEventListener("submit", event => {
+  event.preventDefault();
+  attempt(async () => {
+    const form = event.target;
+    const expense = {
+      name: form.name.value.trim(),
+      amount: Number(form.amount.value),
+      category: form.category.value.trim(),
+      frequency: form.frequency.value,
+      due_day: Number(form.due_day.value || 0),
+      due_month: Number(form.due_month.value || 0),
+      discretionary: form.discretionary.checked,
+    };
+    if (form.index.value === "") {
+      await request("POST", profilePath("/expenses"), expense);
+      showMessage(`Expense ${expense.name} added.`);
+    } else {
+      await request("PUT", profilePath("/expenses/" + encodeURIComponent(form.index.value)), expense);
+      showMessage(`Expense ${expense.name} saved.`);
+    }
+    resetExpenseForm();
+    await loadProfile();
+  });
+});
+
+document.getElementById("cancel-edit").addEventListener("click", resetExpenseForm);
+
+document.getElementById("rename").addEventListener("click", () => attempt(async () => {
+  const name = prompt("New profile name", current);
+  if (!name || name === current) {
+    return;
+  }
+  await request("POST", profilePath("/rename"), { name });
+  showMessage(`Profile renamed to ${name}.`);
+  location.hash = encodeURIComponent(name.toLowerCase());
+}));
+
+document.getElementById("delete").addEventListener("click", () => attempt(async () => {
+  if (!confirm(`Delete the profile ${current} with all its expenses? This can not be undone.`)) {
+    return;
+  }
+  await request("DELETE", profilePath());
+  showMessage(`Profile ${current} deleted.`);
+  location.hash = "";
+}));
+
+window.addEventListener("hashchange", () => attempt(() => selectProfile(decodeURIComponent(location.hash.slice(1)))));
+attempt(() => selectProfile(decodeURIComponent(location.hash.slice(1))));
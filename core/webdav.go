@@ -0,0 +1,85 @@
+package core
+
+import (
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/s3"
+	"wallkeiro/core/webdav"
+
+	"github.com/manifoldco/promptui"
+
+	"fmt"
+	"path/filepath"
+)
+
+// WebDAV runs "wallkeiro webdav" with the given arguments:
+//
+//	wallkeiro webdav <url> [user]  stores the profiles in the WebDAV folder at the url from now on
+//	wallkeiro webdav off           stores the profiles in the profiles folder again
+//
+// When set up, the profiles of the profiles folder that are not in the WebDAV folder yet
+// are uploaded to it. Profiles already in it keep the version of the WebDAV folder.
+func WebDAV(args []string) error {
+	if len(args) == 1 && args[0] == "off" {
+		if err := webdav.RemoveSettings(); err != nil {
+			return err
+		}
+		fmt.Printf("The profiles are stored in %s again. They stay in the WebDAV folder as well.\n", config.ProfilesFolder)
+		return nil
+	}
+	if len(args) < 1 || len(args) > 2 {
+		return fmt.Errorf("usage: wallkeiro webdav <url> [user] | wallkeiro webdav off")
+	}
+	if _, err := s3.ReadSettings(); err != errors.ErrNotFound {
+		return errors.ErrOtherStorage
+	}
+	settings := webdav.Settings{URL: args[0]}
+	if len(args) == 2 {
+		settings.User = args[1]
+		prompt := promptui.Prompt{
+			Label: "Enter Password",
+			Mask:  '*',
+		}
+		var err error
+		settings.Password, err = prompt.Run()
+		if err != nil {
+			return err
+		}
+	}
+	backend := webdav.NewBackend(settings, filepath.Join(config.ProfilesFolder, webdav.Folder, "cache"))
+	if err := backend.Check(); err != nil {
+		return err
+	}
+	remote, err := backend.List()
+	if err != nil {
+		return err
+	}
+	onServer := map[string]bool{}
+	for _, name := range remote {
+		onServer[name] = true
+	}
+	local := config.LocalBackend{}
+	names, err := local.List()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if onServer[name] {
+			fmt.Printf("Profile %s is already in the WebDAV folder, its version there is used.\n", name)
+			continue
+		}
+		data, _, err := local.Read(name)
+		if err != nil {
+			return err
+		}
+		if _, _, err := backend.Write(name, data, ""); err != nil {
+			return err
+		}
+		fmt.Printf("Profile %s uploaded.\n", name)
+	}
+	if err := webdav.SaveSettings(backend.Settings); err != nil {
+		return err
+	}
+	fmt.Printf("The profiles are stored in %s from now on.\n", backend.Settings.URL)
+	return nil
+}
@@ -0,0 +1,596 @@
+// Package webdav stores the profiles on a WebDAV server, like Nextcloud, instead of the
+// profiles folder. Every profile is a JSON file in one folder of the server. Every file is
+// written only if it did not change on the server since it was last read, using its ETag,
+// so two computers never overwrite each other's changes: a write refused by the server is
+// merged field by field with the version on the server (see syncer.Merge) and written again.
+// The last version read from the server and the changes not written yet are cached in the
+// profiles folder, so the profiles can be used offline and are written once the server can
+// be reached again.
+package webdav
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+	"wallkeiro/core/syncer"
+)
+
+// Folder is the folder inside the profiles folder where the settings and the cache are kept.
+const Folder string = "webdav"
+
+// Settings are the WebDAV folder the profiles are stored in and the login to it.
+// With Nextcloud, the URL is like https://cloud.example.com/remote.php/dav/files/<user>/wallkeiro/
+// and the password an app password.
+type Settings struct {
+	URL      string `json:"url"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+// settingsPath returns the path of the file containing the settings.
+func settingsPath() string {
+	return filepath.Join(config.ProfilesFolder, Folder, "settings.json")
+}
+
+// ReadSettings returns the settings, or errors.ErrNotFound if WebDAV is not set up.
+func ReadSettings() (Settings, error) {
+	var settings Settings
+	data, err := os.ReadFile(settingsPath())
+	if goerrors.Is(err, fs.ErrNotExist) {
+		return settings, errors.ErrNotFound
+	}
+	if err != nil {
+		return settings, err
+	}
+	return settings, json.Unmarshal(data, &settings)
+}
+
+// SaveSettings saves the settings. The file is only readable by the user running wallkeiro,
+// since it contains the password.
+func SaveSettings(settings Settings) error {
+	if err := os.MkdirAll(filepath.Join(config.ProfilesFolder, Folder), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(settingsPath(), data, 0600)
+}
+
+// RemoveSettings stops storing the profiles on the WebDAV server. The cache is removed as
+// well, the profiles stay on the server.
+func RemoveSettings() error {
+	return os.RemoveAll(filepath.Join(config.ProfilesFolder, Folder))
+}
+
+// Use makes config store the profiles on the WebDAV server, if it is set up.
+func Use() error {
+	settings, err := ReadSettings()
+	if err == errors.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	config.Storage = NewBackend(settings, filepath.Join(config.ProfilesFolder, Folder, "cache"))
+	return nil
+}
+
+// Backend is a config.Backend storing the profiles on a WebDAV server.
+type Backend struct {
+	Settings Settings
+	// CacheFolder is the folder the cached profiles are kept in.
+	CacheFolder string
+	HTTP        *http.Client
+	// mu makes the operations happen one at a time, since each reads and writes the cache.
+	mu sync.Mutex
+}
+
+// NewBackend returns a Backend storing the profiles in the WebDAV folder of the settings
+// and caching them in the given folder.
+func NewBackend(settings Settings, cacheFolder string) *Backend {
+	if !strings.HasSuffix(settings.URL, "/") {
+		settings.URL += "/"
+	}
+	return &Backend{Settings: settings, CacheFolder: cacheFolder, HTTP: &http.Client{Timeout: 15 * time.Second}}
+}
+
+// entry is the cached state of a profile: the last version read from or written to the server
+// with its ETag, and the version written on this computer that is not on the server yet with
+// the version it was edited from. The versions handed out lately are kept too, so a write
+// edited from one of them can be merged with the changes made since.
+type entry struct {
+	ETag        string          `json:"etag"`
+	Base        json.RawMessage `json:"base,omitempty"`
+	Pending     json.RawMessage `json:"pending,omitempty"`
+	PendingBase string          `json:"pending_base,omitempty"`
+	Versions    []version       `json:"versions,omitempty"`
+}
+
+// version is a version of a profile handed out by Read or Write.
+type version struct {
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// keptVersions is how many of the versions handed out lately are kept for merging.
+const keptVersions int = 8
+
+// remember keeps the data of the version, forgetting the oldest version kept if there are too many.
+func (e *entry) remember(current string, data []byte) {
+	if current == "" || e.lookup(current) != nil {
+		return
+	}
+	e.Versions = append(e.Versions, version{Version: current, Data: data})
+	if len(e.Versions) > keptVersions {
+		e.Versions = e.Versions[len(e.Versions)-keptVersions:]
+	}
+}
+
+// lookup returns the data of the version, or nil if it is not kept.
+func (e entry) lookup(wanted string) json.RawMessage {
+	for _, kept := range e.Versions {
+		if kept.Version == wanted {
+			return kept.Data
+		}
+	}
+	return nil
+}
+
+// pendingVersion returns the version of a profile written on this computer that is not on the
+// server yet. It can not be mistaken for an ETag, which are quoted.
+func pendingVersion(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "pending-" + hex.EncodeToString(sum[:])
+}
+
+// handOut returns the version of the profile a read or write ends with, the one not written
+// yet if there is one, and keeps it for merging writes edited from it.
+func (b *Backend) handOut(name string, cached entry) ([]byte, string, error) {
+	data, current := cached.Base, cached.ETag
+	if cached.Pending != nil {
+		data, current = cached.Pending, pendingVersion(cached.Pending)
+	}
+	cached.remember(current, data)
+	return data, current, b.writeCache(name, cached)
+}
+
+// offline is an error of a request that did not reach the server.
+type offline struct {
+	err error
+}
+
+func (o offline) Error() string {
+	return "the WebDAV server can not be reached: " + o.err.Error()
+}
+
+// cachePath returns the path of the cache file of the profile.
+func (b *Backend) cachePath(name string) string {
+	return filepath.Join(b.CacheFolder, strings.ToLower(name)+".json")
+}
+
+// readCache returns the cached state of the profile, which is empty if there is none.
+func (b *Backend) readCache(name string) (entry, error) {
+	var cached entry
+	data, err := os.ReadFile(b.cachePath(name))
+	if goerrors.Is(err, fs.ErrNotExist) {
+		return cached, nil
+	}
+	if err != nil {
+		return cached, err
+	}
+	return cached, json.Unmarshal(data, &cached)
+}
+
+// writeCache saves the cached state of the profile.
+func (b *Backend) writeCache(name string, cached entry) error {
+	if err := os.MkdirAll(b.CacheFolder, 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(b.cachePath(name), data, 0600)
+}
+
+// removeCache removes the cached state of the profile, if there is one.
+func (b *Backend) removeCache(name string) error {
+	err := os.Remove(b.cachePath(name))
+	if goerrors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// request sends a request to the file of the given name in the WebDAV folder, or to the
+// folder itself if the name is empty, with the given headers.
+func (b *Backend) request(method, name string, body []byte, headers map[string]string) (*http.Response, []byte, error) {
+	target := b.Settings.URL
+	if name != "" {
+		target += url.PathEscape(strings.ToLower(name) + ".json")
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	request, err := http.NewRequest(method, target, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	if b.Settings.User != "" {
+		request.SetBasicAuth(b.Settings.User, b.Settings.Password)
+	}
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	response, err := b.HTTP.Do(request)
+	if err != nil {
+		return nil, nil, offline{err}
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, offline{err}
+	}
+	if response.StatusCode == http.StatusUnauthorized {
+		return response, data, errors.ErrUnauthorized
+	}
+	return response, data, nil
+}
+
+// failed returns the error for an unexpected response of the server.
+func failed(method string, response *http.Response) error {
+	return fmt.Errorf("WebDAV %s answered %s", method, response.Status)
+}
+
+// List returns the names of the profiles on the server, together with the ones written on
+// this computer that are not on the server yet. When offline, it returns the cached profiles.
+func (b *Backend) List() ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.flushAll()
+	names := map[string]bool{}
+	response, data, err := b.request("PROPFIND", "", []byte(propfindBody), map[string]string{"Depth": "1", "Content-Type": "application/xml"})
+	var offlineErr offline
+	switch {
+	case goerrors.As(err, &offlineErr):
+		cached, _ := os.ReadDir(b.CacheFolder)
+		for _, file := range cached {
+			names[strings.TrimSuffix(file.Name(), ".json")] = true
+		}
+	case err != nil:
+		return nil, err
+	case response.StatusCode == http.StatusNotFound:
+	case response.StatusCode != http.StatusMultiStatus:
+		return nil, failed("PROPFIND", response)
+	default:
+		files, err := parseMultistatus(data)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			names[file] = true
+		}
+	}
+	pending, _ := os.ReadDir(b.CacheFolder)
+	for _, file := range pending {
+		name := strings.TrimSuffix(file.Name(), ".json")
+		if cached, err := b.readCache(name); err == nil && cached.Pending != nil {
+			names[name] = true
+		}
+	}
+	profiles := []string{}
+	for name := range names {
+		profiles = append(profiles, name)
+	}
+	sort.Strings(profiles)
+	return profiles, nil
+}
+
+// Read returns the profile and its version. If it did not change on the server since it was
+// cached, the cached version is used, and when offline, the cached version or the one not
+// written yet. Reading never changes the version a write not on the server yet is based on.
+func (b *Backend) Read(name string) ([]byte, string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	cached, err := b.readCache(name)
+	if err != nil {
+		return nil, "", err
+	}
+	if cached.Pending != nil {
+		if cached, err = b.flush(name, cached); err != nil {
+			return nil, "", err
+		}
+		if cached.Pending != nil {
+			return b.handOut(name, cached)
+		}
+	}
+	headers := map[string]string{}
+	if cached.ETag != "" && cached.Base != nil {
+		headers["If-None-Match"] = cached.ETag
+	}
+	response, data, err := b.request(http.MethodGet, name, nil, headers)
+	var offlineErr offline
+	switch {
+	case goerrors.As(err, &offlineErr):
+		if cached.Base == nil {
+			return nil, "", err
+		}
+		return cached.Base, cached.ETag, nil
+	case err != nil:
+		return nil, "", err
+	case response.StatusCode == http.StatusNotModified:
+		return cached.Base, cached.ETag, nil
+	case response.StatusCode == http.StatusNotFound:
+		b.removeCache(name)
+		return nil, "", fmt.Errorf("%s: %w", name, fs.ErrNotExist)
+	case response.StatusCode != http.StatusOK:
+		return nil, "", failed("GET", response)
+	}
+	cached.ETag, cached.Base = response.Header.Get("ETag"), data
+	return b.handOut(name, cached)
+}
+
+// Write writes the profile edited from the given version. It is cached first, so it is kept
+// when offline and written once the server can be reached again. If the profile changed on
+// the server since that version, both are merged. If the version is no longer kept, the
+// changes made since can not be told apart, and it fails with errors.ErrProfileChanged.
+func (b *Backend) Write(name string, data []byte, edited string) ([]byte, string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	cached, err := b.readCache(name)
+	if err != nil {
+		return nil, "", err
+	}
+	base := cached.lookup(edited)
+	switch {
+	case cached.Pending != nil && edited == pendingVersion(cached.Pending):
+		// edited from the version not written yet, which it replaces
+	case edited != "" && base == nil:
+		return nil, "", errors.ErrProfileChanged
+	case cached.Pending == nil:
+		cached.PendingBase = edited
+	default:
+		// the version not written yet was not the one edited, so its changes are kept as well
+		if data, err = syncer.MergeKeepingLocal(base, data, cached.Pending); err != nil {
+			return nil, "", err
+		}
+	}
+	cached.Pending = data
+	if err := b.writeCache(name, cached); err != nil {
+		return nil, "", err
+	}
+	if cached, err = b.flush(name, cached); err != nil {
+		return nil, "", err
+	}
+	return b.handOut(name, cached)
+}
+
+// flushAll writes every profile not written yet, as far as the server can be reached.
+func (b *Backend) flushAll() {
+	files, _ := os.ReadDir(b.CacheFolder)
+	for _, file := range files {
+		name := strings.TrimSuffix(file.Name(), ".json")
+		if cached, err := b.readCache(name); err == nil && cached.Pending != nil {
+			b.flush(name, cached)
+		}
+	}
+}
+
+// flush writes the version of the profile not written yet, if the version on the server is
+// still the one it is based on. Otherwise it merges both and tries again. When offline, it
+// keeps the version for later and returns no error. It returns the new cached state.
+func (b *Backend) flush(name string, cached entry) (entry, error) {
+	for attempt := 0; attempt < 3; attempt++ {
+		headers := map[string]string{"Content-Type": "application/json"}
+		if cached.PendingBase != "" {
+			headers["If-Match"] = cached.PendingBase
+		} else {
+			headers["If-None-Match"] = "*"
+		}
+		response, _, err := b.request(http.MethodPut, name, cached.Pending, headers)
+		var offlineErr offline
+		switch {
+		case goerrors.As(err, &offlineErr):
+			return cached, nil
+		case err != nil:
+			return cached, err
+		case response.StatusCode == http.StatusConflict && attempt == 0:
+			// the folder does not exist yet
+			if _, _, err := b.request("MKCOL", "", nil, nil); err != nil {
+				return cached, err
+			}
+			continue
+		case response.StatusCode == http.StatusPreconditionFailed:
+			if cached, err = b.mergeLatest(name, cached); err != nil {
+				return cached, err
+			}
+			continue
+		case response.StatusCode < 200 || response.StatusCode > 299:
+			return cached, failed("PUT", response)
+		}
+		etag := response.Header.Get("ETag")
+		if etag == "" {
+			// some servers do not tell the ETag of what was written
+			if head, _, err := b.request(http.MethodHead, name, nil, nil); err == nil {
+				etag = head.Header.Get("ETag")
+			}
+		}
+		cached.ETag, cached.Base = etag, cached.Pending
+		cached.Pending, cached.PendingBase = nil, ""
+		return cached, b.writeCache(name, cached)
+	}
+	return cached, errors.ErrOutdated
+}
+
+// mergeLatest reads the version of the profile on the server and merges the version not
+// written yet into it, using the version it was edited from, if it is still kept. Fields
+// changed both on the server and on this computer keep the version of this computer, which
+// was written last.
+func (b *Backend) mergeLatest(name string, cached entry) (entry, error) {
+	response, data, err := b.request(http.MethodGet, name, nil, nil)
+	if err != nil {
+		return cached, err
+	}
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		// deleted on the server, so it is written as a new file
+		cached.PendingBase = ""
+		return cached, b.writeCache(name, cached)
+	default:
+		return cached, failed("GET", response)
+	}
+	merged, err := syncer.MergeKeepingLocal(cached.lookup(cached.PendingBase), cached.Pending, data)
+	if err != nil {
+		return cached, err
+	}
+	cached.ETag, cached.Base = response.Header.Get("ETag"), data
+	cached.remember(cached.ETag, data)
+	cached.Pending, cached.PendingBase = merged, cached.ETag
+	return cached, b.writeCache(name, cached)
+}
+
+// Remove deletes the profile from the server, unless it changed there since it was last read.
+// Deleting needs the server to be reachable.
+func (b *Backend) Remove(name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	cached, err := b.readCache(name)
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{}
+	if cached.ETag != "" {
+		headers["If-Match"] = cached.ETag
+	}
+	response, _, err := b.request(http.MethodDelete, name, nil, headers)
+	if err != nil {
+		return err
+	}
+	switch {
+	case response.StatusCode == http.StatusPreconditionFailed:
+		return errors.ErrOutdated
+	case response.StatusCode == http.StatusNotFound && cached.Pending == nil:
+		return fmt.Errorf("%s: %w", name, fs.ErrNotExist)
+	case response.StatusCode != http.StatusNotFound && (response.StatusCode < 200 || response.StatusCode > 299):
+		return failed("DELETE", response)
+	}
+	return b.removeCache(name)
+}
+
+// Rename moves the profile on the server, refusing to overwrite another profile.
+// Renaming needs the server to be reachable.
+func (b *Backend) Rename(oldName, newName string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	cached, err := b.readCache(oldName)
+	if err != nil {
+		return err
+	}
+	if cached.Pending != nil {
+		if cached, err = b.flush(oldName, cached); err != nil {
+			return err
+		}
+		if cached.Pending != nil {
+			return offline{fmt.Errorf("%s is not written yet", oldName)}
+		}
+	}
+	headers := map[string]string{"Destination": b.Settings.URL + url.PathEscape(strings.ToLower(newName)+".json"), "Overwrite": "F"}
+	if cached.ETag != "" {
+		headers["If-Match"] = cached.ETag
+	}
+	response, _, err := b.request("MOVE", oldName, nil, headers)
+	if err != nil {
+		return err
+	}
+	switch {
+	case response.StatusCode == http.StatusPreconditionFailed:
+		// refused either because the new name is taken or because the profile changed
+		if head, _, err := b.request(http.MethodHead, newName, nil, nil); err == nil && head.StatusCode == http.StatusOK {
+			return errors.ErrAlreadyExists
+		}
+		return errors.ErrOutdated
+	case response.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%s: %w", oldName, fs.ErrNotExist)
+	case response.StatusCode < 200 || response.StatusCode > 299:
+		return failed("MOVE", response)
+	}
+	// the ETag may change with the name, so the profile is cached again when read under the new name
+	return b.removeCache(oldName)
+}
+
+// Check makes sure the WebDAV folder can be reached with the settings, creating it if needed.
+func (b *Backend) Check() error {
+	response, _, err := b.request("PROPFIND", "", []byte(propfindBody), map[string]string{"Depth": "0", "Content-Type": "application/xml"})
+	if err != nil {
+		return err
+	}
+	switch response.StatusCode {
+	case http.StatusMultiStatus:
+		return nil
+	case http.StatusNotFound:
+		response, _, err = b.request("MKCOL", "", nil, nil)
+		if err != nil {
+			return err
+		}
+		if response.StatusCode != http.StatusCreated {
+			return failed("MKCOL", response)
+		}
+		return nil
+	}
+	return failed("PROPFIND", response)
+}
+
+// propfindBody asks only for the type of the resources, to tell files from folders.
+const propfindBody string = `<?xml version="1.0" encoding="utf-8"?><propfind xmlns="DAV:"><prop><resourcetype/></prop></propfind>`
+
+// multistatus is the part of the answer to a PROPFIND needed to list the files of the folder.
+type multistatus struct {
+	Responses []struct {
+		Href       string `xml:"href"`
+		Collection []struct {
+			Found *struct{} `xml:"collection"`
+		} `xml:"propstat>prop>resourcetype"`
+	} `xml:"response"`
+}
+
+// parseMultistatus returns the names of the JSON files listed in the answer to a PROPFIND, without ".json".
+func parseMultistatus(data []byte) ([]string, error) {
+	var status multistatus
+	if err := xml.Unmarshal(data, &status); err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, response := range status.Responses {
+		folder := false
+		for _, resourceType := range response.Collection {
+			folder = folder || resourceType.Found != nil
+		}
+		href, err := url.PathUnescape(response.Href)
+		if err != nil || folder || !strings.HasSuffix(href, ".json") {
+			continue
+		}
+		files = append(files, strings.TrimSuffix(path.Base(href), ".json"))
+	}
+	return files, nil
+}
@@ -0,0 +1,232 @@
+package webdav
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"wallkeiro/core/config"
+	"wallkeiro/core/errors"
+
+	netwebdav "golang.org/x/net/webdav"
+)
+
+// testServer is a WebDAV server keeping its files in memory. Unlike the handler of
+// golang.org/x/net/webdav it serves, it honours If-Match and If-None-Match when writing,
+// deleting and moving files, like Nextcloud does.
+type testServer struct {
+	handler *netwebdav.Handler
+	// mu makes every request that changes a file happen one at a time, so the ETag a
+	// request is checked against is still the ETag of the file when the request is served.
+	mu sync.Mutex
+}
+
+// etag returns the ETag of the file at the path, computed like the handler does, or an empty
+// string if there is no such file.
+func (s *testServer) etag(ctx context.Context, path string) string {
+	info, err := s.handler.FileSystem.Stat(ctx, path)
+	if err != nil || info.IsDir() {
+		return ""
+	}
+	return fmt.Sprintf(`"%x%x"`, info.ModTime().UnixNano(), info.Size())
+}
+
+// matches reports whether the header, a list of ETags or "*", matches the ETag of a file,
+// which is empty if the file does not exist.
+func matches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if (candidate == "*" && etag != "") || (candidate != "" && strings.TrimPrefix(candidate, "W/") == etag) {
+			return true
+		}
+	}
+	return false
+}
+
+// ServeHTTP serves the WebDAV request, refusing to change a file whose ETag does not match
+// the conditions of the request with 412 Precondition Failed.
+func (s *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut, http.MethodDelete, "MOVE":
+	default:
+		s.handler.ServeHTTP(w, r)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	etag := s.etag(r.Context(), r.URL.Path)
+	if header := r.Header.Get("If-Match"); header != "" && !matches(header, etag) {
+		http.Error(w, "the file changed since it was read", http.StatusPreconditionFailed)
+		return
+	}
+	if header := r.Header.Get("If-None-Match"); header != "" && matches(header, etag) {
+		http.Error(w, "the file already exists", http.StatusPreconditionFailed)
+		return
+	}
+	s.handler.ServeHTTP(w, r)
+}
+
+// testComputers starts a WebDAV server and returns two backends storing the profiles in the
+// same folder of it, each with a cache of its own like two computers.
+func testComputers(t *testing.T) (*Backend, *Backend) {
+	t.Helper()
+	server := httptest.NewServer(&testServer{handler: &netwebdav.Handler{FileSystem: netwebdav.NewMemFS(), LockSystem: netwebdav.NewMemLS()}})
+	t.Cleanup(server.Close)
+	var backends []*Backend
+	for i := 0; i < 2; i++ {
+		backend := NewBackend(Settings{URL: server.URL + "/wallkeiro"}, t.TempDir())
+		if err := backend.Check(); err != nil {
+			t.Fatal(err)
+		}
+		backends = append(backends, backend)
+	}
+	return backends[0], backends[1]
+}
+
+// home returns the JSON of a profile with the given salary and rent.
+func home(t *testing.T, salary, rent float64) []byte {
+	t.Helper()
+	data, err := json.Marshal(config.ProfileData{
+		Config:   config.ConfigStruct{Salary: salary, SalaryType: config.Fixed, SavingLevel: 1},
+		Expenses: []config.ExpensesStuct{{ID: "rent", Name: "Rent", Amount: rent}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// mustRead reads the profile and returns its salary, its rent and its version.
+func mustRead(t *testing.T, backend *Backend, name string) (float64, float64, string) {
+	t.Helper()
+	data, version, err := backend.Read(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	salary, rent := decode(t, data)
+	return salary, rent, version
+}
+
+// decode returns the salary and the rent of the profile.
+func decode(t *testing.T, data []byte) (float64, float64) {
+	t.Helper()
+	var profile config.ProfileData
+	if err := json.Unmarshal(data, &profile); err != nil {
+		t.Fatal(err)
+	}
+	if len(profile.Expenses) != 1 {
+		t.Fatalf("the profile has the expenses %+v, want only the rent", profile.Expenses)
+	}
+	return profile.Config.Salary, profile.Expenses[0].Amount
+}
+
+// mustWrite writes the profile as edited from the version and returns the version written.
+func mustWrite(t *testing.T, backend *Backend, name string, data []byte, edited string) string {
+	t.Helper()
+	_, version, err := backend.Write(name, data, edited)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return version
+}
+
+func TestStaleETagIsRefused(t *testing.T) {
+	a, b := testComputers(t)
+	first := mustWrite(t, a, "home", home(t, 1000, 900), "")
+	_, _, stale := mustRead(t, b, "home")
+	if stale != first {
+		t.Fatalf("the other computer read the version %s, want %s", stale, first)
+	}
+	if latest := mustWrite(t, a, "home", home(t, 2000, 900), first); latest == first {
+		t.Fatal("writing did not change the ETag")
+	}
+	response, _, err := b.request(http.MethodPut, "home", home(t, 3000, 900), map[string]string{"If-Match": stale})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.StatusCode != http.StatusPreconditionFailed {
+		t.Fatalf("a write with a stale ETag was answered with %s, want 412", response.Status)
+	}
+	if err := b.Remove("home"); err != errors.ErrOutdated {
+		t.Fatalf("removing the profile changed elsewhere: got %v, want ErrOutdated", err)
+	}
+	if salary, _, _ := mustRead(t, a, "home"); salary != 2000 {
+		t.Fatalf("the salary on the server is %v, want 2000", salary)
+	}
+}
+
+func TestConflictIsMerged(t *testing.T) {
+	a, b := testComputers(t)
+	mustWrite(t, a, "home", home(t, 1000, 900), "")
+	_, _, edited := mustRead(t, b, "home")
+	_, _, version := mustRead(t, a, "home")
+	mustWrite(t, a, "home", home(t, 3000, 900), version)
+	// reading the profile again, like UpdateProfile does to describe the change, does not
+	// make the version written be based on the latest one
+	mustRead(t, b, "home")
+	data, _, err := b.Write("home", home(t, 1000, 950), edited)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if salary, rent := decode(t, data); salary != 3000 || rent != 950 {
+		t.Fatalf("the merged profile has a salary of %v and a rent of %v, want 3000 and 950", salary, rent)
+	}
+	if salary, rent, _ := mustRead(t, a, "home"); salary != 3000 || rent != 950 {
+		t.Fatalf("the server has a salary of %v and a rent of %v, want 3000 and 950", salary, rent)
+	}
+}
+
+func TestUnknownVersionIsRefused(t *testing.T) {
+	a, _ := testComputers(t)
+	version := mustWrite(t, a, "home", home(t, 1000, 900), "")
+	if _, _, err := a.Write("home", home(t, 2000, 900), `"forgotten"`); err != errors.ErrProfileChanged {
+		t.Fatalf("writing a version edited from one not kept: got %v, want ErrProfileChanged", err)
+	}
+
+	// the same holds while a version is waiting to be written
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+	a.Settings.URL = unreachable.URL + "/wallkeiro/"
+	mustWrite(t, a, "home", home(t, 3000, 900), version)
+	if _, _, err := a.Write("home", home(t, 2000, 950), `"forgotten"`); err != errors.ErrProfileChanged {
+		t.Fatalf("offline writing a version edited from one not kept: got %v, want ErrProfileChanged", err)
+	}
+	if salary, rent, _ := mustRead(t, a, "home"); salary != 3000 || rent != 900 {
+		t.Fatalf("the cached profile has a salary of %v and a rent of %v, want 3000 and 900", salary, rent)
+	}
+}
+
+func TestOfflineCache(t *testing.T) {
+	a, b := testComputers(t)
+	mustWrite(t, a, "home", home(t, 1000, 900), "")
+	_, _, version := mustRead(t, a, "home")
+	online := a.Settings.URL
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+	a.Settings.URL = unreachable.URL + "/wallkeiro/"
+
+	if salary, _, _ := mustRead(t, a, "home"); salary != 1000 {
+		t.Fatalf("the cached salary is %v, want 1000", salary)
+	}
+	pending := mustWrite(t, a, "home", home(t, 3000, 900), version)
+	if salary, _, read := mustRead(t, a, "home"); salary != 3000 || read != pending {
+		t.Fatalf("offline the profile has a salary of %v and the version %s, want 3000 and %s", salary, read, pending)
+	}
+	if profiles, err := a.List(); err != nil || len(profiles) != 1 || profiles[0] != "home" {
+		t.Fatalf("offline the profiles are %v (%v), want home", profiles, err)
+	}
+	_, _, other := mustRead(t, b, "home")
+	mustWrite(t, b, "home", home(t, 1000, 950), other)
+
+	a.Settings.URL = online
+	if _, err := a.List(); err != nil {
+		t.Fatal(err)
+	}
+	if salary, rent, _ := mustRead(t, b, "home"); salary != 3000 || rent != 950 {
+		t.Fatalf("after replaying the cache the server has a salary of %v and a rent of %v, want 3000 and 950", salary, rent)
+	}
+}
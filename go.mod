@@ -2,10 +2,23 @@ module wallkeiro
 
 go 1.20
 
-require golang.org/x/crypto v0.30.0
+require (
+	github.com/gdamore/tcell/v2 v2.8.1
+	github.com/manifoldco/promptui v0.9.0
+	github.com/mattn/go-runewidth v0.0.16
+	golang.org/x/crypto v0.30.0
+	golang.org/x/net v0.26.0
+	golang.org/x/text v0.21.0
+	google.golang.org/grpc v1.64.1
+	google.golang.org/protobuf v1.34.2
+)
 
 require (
 	github.com/chzyer/readline v0.0.0-20180603132655-2972be24d48e // indirect
-	github.com/manifoldco/promptui v0.9.0 // indirect
-	golang.org/x/sys v0.28.0 // indirect
+	github.com/gdamore/encoding v1.0.1 // indirect
+	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
+	github.com/rivo/uniseg v0.4.3 // indirect
+	golang.org/x/sys v0.29.0 // indirect
+	golang.org/x/term v0.28.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240318140521-94a12d6c2237 // indirect
 )
@@ -1,19 +1,117 @@
 package main
 
-import "wallkeiro/core"
+import (
+	"wallkeiro/core"
+	"wallkeiro/core/api"
+	"wallkeiro/core/history"
+	"wallkeiro/core/rpc"
+	"wallkeiro/core/s3"
+	"wallkeiro/core/syncer"
+	"wallkeiro/core/webdav"
+
+	"flag"
+	"fmt"
+	"os"
+)
 
 // main is the entry point for the Wallkeiro application. It creates the
 // profiles folder if it does not exist, and then starts the application by
 // calling core.Start(). If there is an error creating the folder or starting
 // the application, it panics with the error message.
+// Started as "wallkeiro serve", it serves the REST API and the web interface
+// instead of the menus, on the address given with -addr, and the gRPC API on
+// the address given with -grpc-addr, unless that is empty.
+// Started as "wallkeiro users", it shows the menu managing the user accounts
+// of the server and with whom each profile is shared.
+// Started as "wallkeiro sync-server", it serves the sync server on the address
+// given with -addr, keeping the synced profiles in the folder given with -dir.
+// Started as "wallkeiro sync", it syncs every profile with the sync server.
+// Started as "wallkeiro history", it shows the changes to a profile, and manages
+// the git repository keeping them; see core.History.
+// Once that repository exists, every change to a profile is committed to it.
+// Started as "wallkeiro webdav", it sets up storing the profiles on a WebDAV
+// server; see core.WebDAV. Started as "wallkeiro s3", it sets up storing the
+// profiles encrypted in a bucket of an S3-compatible store; see core.S3.
 func main(){
 	var err error
 	err = core.CreateFolder()
 	if err != nil {
 		panic(err)
 	}
+	history.Watch()
+	// the storages are set up and turned off before they are used, so a storage that can not
+	// be used, like S3 without the encryption key, can still be turned off
+	if len(os.Args) > 1 && os.Args[1] == "webdav" {
+		err = core.WebDAV(os.Args[2:])
+		if err != nil {
+			panic(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "s3" {
+		err = core.S3(os.Args[2:])
+		if err != nil {
+			panic(err)
+		}
+		return
+	}
+	err = webdav.Use()
+	if err == nil {
+		err = s3.Use()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "The profiles can not be read from where they are stored: %v\n", err)
+		fmt.Fprintln(os.Stderr, "Fix that, or store them in the profiles folder again with \"wallkeiro webdav off\" or \"wallkeiro s3 off\".")
+		os.Exit(1)
+	}
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		flags := flag.NewFlagSet("serve", flag.ExitOnError)
+		address := flags.String("addr", api.DefaultAddress, "address to serve the API and the web interface on")
+		grpcAddress := flags.String("grpc-addr", rpc.DefaultAddress, "address to serve the gRPC API on, or empty to not serve it")
+		flags.Parse(os.Args[2:])
+		failed := make(chan error, 2)
+		go func() {
+			failed <- api.Serve(*address)
+		}()
+		if *grpcAddress != "" {
+			go func() {
+				failed <- rpc.Serve(*grpcAddress)
+			}()
+		}
+		panic(<-failed)
+	}
+	if len(os.Args) > 1 && os.Args[1] == "sync-server" {
+		flags := flag.NewFlagSet("sync-server", flag.ExitOnError)
+		address := flags.String("addr", syncer.DefaultAddress, "address to serve the sync server on")
+		folder := flags.String("dir", syncer.DefaultFolder, "folder to keep the synced profiles in")
+		flags.Parse(os.Args[2:])
+		server := syncer.NewServer(syncer.NewDirStorage(*folder))
+		server.Login = syncer.AccountLogin
+		panic(server.Serve(*address))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "sync" {
+		err = core.SyncAll()
+		if err != nil {
+			panic(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "history" {
+		err = core.History(os.Args[2:])
+		if err != nil {
+			panic(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "users" {
+		err = core.UsersMenu()
+		if err != nil {
+			panic(err)
+		}
+		return
+	}
 	err = core.Start()
 	if err != nil {
 		panic(err)
 	}
-}
\ No newline at end of file
+}